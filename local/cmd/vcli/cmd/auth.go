@@ -1,18 +1,22 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
 )
 
 func NewAuthCmd() *cobra.Command {
@@ -52,7 +56,7 @@ Environment variables:
 		},
 	}
 
-	cmd.Flags().StringVarP(&vaultID, "vault", "v", "", "Vault ID or public key prefix")
+	cmd.Flags().StringVarP(&vaultID, "vault", "v", "", "Vault name or public key prefix (default: active vault)")
 	cmd.Flags().StringVar(&password, "password", "", "Fast Vault password (or set VAULT_PASSWORD)")
 
 	return cmd
@@ -61,7 +65,7 @@ Environment variables:
 func newAuthStatusCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "status",
-		Short: "Show current authentication status",
+		Short: "Show all stored authentication sessions",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runAuthStatus()
 		},
@@ -69,169 +73,336 @@ func newAuthStatusCmd() *cobra.Command {
 }
 
 func newAuthLogoutCmd() *cobra.Command {
-	return &cobra.Command{
+	var vaultID string
+	var all bool
+
+	cmd := &cobra.Command{
 		Use:   "logout",
 		Short: "Clear stored authentication token",
+		Long: `Clear the stored authentication token for a vault.
+
+By default the token of the active vault (for the configured verifier) is
+removed. Use --vault to pick another vault or --all to clear every session.
+`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAuthLogout()
+			return runAuthLogout(vaultID, all)
 		},
 	}
+
+	cmd.Flags().StringVarP(&vaultID, "vault", "v", "", "Vault public key prefix (default: active vault)")
+	cmd.Flags().BoolVar(&all, "all", false, "Clear tokens for all vaults and verifiers")
+
+	return cmd
 }
 
+// AuthToken is a verifier session for one vault. Tokens are stored per
+// (ECDSA public key, verifier URL) pair in DevConfig.AuthTokens.
 type AuthToken struct {
-	Token     string    `json:"token"`
-	PublicKey string    `json:"public_key"`
-	ExpiresAt time.Time `json:"expires_at"`
+	Token       string    `json:"token"`
+	PublicKey   string    `json:"public_key"`
+	VerifierURL string    `json:"verifier_url,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
 }
 
 func runAuthLogin(vaultID, password string) error {
-	cfg, err := LoadConfig()
+	vault, err := resolveVault(vaultID)
+	if err != nil {
+		return err
+	}
+	err = requireKeyshares(vault)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
-	vault, err := LoadVault(vaultID)
+	password, err = promptPassword(password, fmt.Sprintf("Enter Fast Vault password for '%s': ", vault.Name))
 	if err != nil {
-		if vaultID == "" {
-			vaults, listErr := ListVaults()
-			if listErr != nil || len(vaults) == 0 {
-				return fmt.Errorf("no vaults found. Import a vault first with: vcli vault import")
-			}
-			vault = vaults[0]
-			fmt.Printf("Using vault: %s\n", vault.Name)
-		} else {
-			return fmt.Errorf("vault not found: %s", vaultID)
-		}
+		return err
+	}
+
+	fmt.Println("Authenticating with verifier...")
+	_, err = authenticateVault(vault, password)
+	if err != nil {
+		return err
+	}
+	rememberVaultPassword(password)
+
+	fmt.Println("\n✓ Authentication successful!")
+
+	return nil
+}
+
+// authenticateVault signs a JSON nonce message (EIP-191, default EVM account)
+// with the Fast Vault Server and exchanges the signature for a verifier JWT,
+// which is stored for the vault and the configured verifier. It is the single
+// auth path used by 'auth login', 'vault import' and automatic re-auth.
+func authenticateVault(vault *LocalVault, password string) (*AuthToken, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		cfg = DefaultConfig()
 	}
 
 	if vault.PublicKeyECDSA == "" {
-		return fmt.Errorf("vault has no ECDSA public key")
+		return nil, fmt.Errorf("vault has no ECDSA public key")
 	}
 	if vault.HexChainCode == "" {
-		return fmt.Errorf("vault has no chain code")
+		return nil, fmt.Errorf("vault has no chain code")
+	}
+	err = requireKeyshares(vault)
+	if err != nil {
+		return nil, err
 	}
 
+	// Generate nonce for auth message
 	nonceBytes := make([]byte, 16)
 	_, err = rand.Read(nonceBytes)
 	if err != nil {
-		return fmt.Errorf("generate nonce: %w", err)
+		return nil, fmt.Errorf("generate nonce: %w", err)
 	}
 	nonce := hex.EncodeToString(nonceBytes)
-
 	expiryTime := time.Now().Add(5 * time.Minute)
-	message := fmt.Sprintf("%s:%d", nonce, expiryTime.Unix())
 
-	fmt.Printf("Authenticating with verifier...\n")
+	// Message must be JSON format for verifier
+	messageObj := map[string]string{
+		"nonce":     nonce,
+		"expiresAt": expiryTime.Format(time.RFC3339),
+	}
+	messageJSON, err := json.Marshal(messageObj)
+	if err != nil {
+		return nil, fmt.Errorf("marshal message: %w", err)
+	}
+	message := string(messageJSON)
+
 	fmt.Printf("  Vault: %s\n", vault.Name)
-	fmt.Printf("  Public Key: %s...\n", vault.PublicKeyECDSA[:16])
 	fmt.Printf("  Verifier: %s\n", cfg.Verifier)
 
+	// Create Ethereum-prefixed message hash for signing
+	ethPrefixedMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)
+	messageHash := crypto.Keccak256([]byte(ethPrefixedMessage))
+	hexMessage := hex.EncodeToString(messageHash)
+
+	// Perform TSS keysign
 	tss := NewTSSService(vault.LocalPartyID)
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
-	fmt.Println("\nPerforming TSS keysign for authentication...")
+	fmt.Println("  Performing TSS keysign...")
 
-	derivePath := "m/44'/60'/0'/0/0"
-	results, err := tss.Keysign(ctx, vault, []string{message}, derivePath, false, password)
+	// The verifier checks auth signatures against the default account key
+	derivePath := Account{}.EVMDerivePath()
+	results, err := tss.KeysignWithFastVault(ctx, vault, []string{hexMessage}, derivePath, password)
 	if err != nil {
-		return fmt.Errorf("TSS keysign failed: %w", err)
+		return nil, fmt.Errorf("TSS keysign failed: %w", err)
 	}
 
 	if len(results) == 0 {
-		return fmt.Errorf("no signature result")
+		return nil, fmt.Errorf("no signature result")
 	}
 
-	signature := results[0].DerSignature
+	// Build signature in Ethereum format (R + S + V)
+	signature := "0x" + results[0].R + results[0].S + results[0].RecoveryID
 
-	authReq := map[string]string{
-		"message":        message,
-		"signature":      signature,
-		"chain_code_hex": vault.HexChainCode,
-		"public_key":     vault.PublicKeyECDSA,
+	// Send auth request to verifier
+	token, err := verifier.New(cfg.Verifier).Authenticate(ctx, verifier.AuthRequest{
+		Message:      message,
+		Signature:    signature,
+		ChainCodeHex: vault.HexChainCode,
+		PublicKey:    vault.PublicKeyECDSA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
 	}
 
-	reqJSON, err := json.Marshal(authReq)
-	if err != nil {
-		return fmt.Errorf("marshal auth request: %w", err)
+	// Save token
+	authToken := AuthToken{
+		Token:       token,
+		PublicKey:   vault.PublicKeyECDSA,
+		VerifierURL: cfg.Verifier,
+		ExpiresAt:   tokenExpiry(token),
 	}
 
-	url := cfg.Verifier + "/auth"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
+	err = SaveAuthToken(&authToken)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return nil, fmt.Errorf("save auth token: %w", err)
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(httpReq)
+	fmt.Printf("  Token expires: %s\n", authToken.ExpiresAt.Format(time.RFC3339))
+
+	return &authToken, nil
+}
+
+func runAuthStatus() error {
+	tokens, err := ListAuthTokens()
 	if err != nil {
-		return fmt.Errorf("auth request failed: %w", err)
+		return fmt.Errorf("load auth tokens: %w", err)
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("authentication failed (%d): %s", resp.StatusCode, string(body))
+	if len(tokens) == 0 {
+		fmt.Println("Not authenticated.")
+		fmt.Println("\nRun 'vcli auth login' to authenticate.")
+		return nil
 	}
 
-	var authResp struct {
-		Data struct {
-			Token string `json:"token"`
-		} `json:"data"`
+	cfg, _ := LoadConfig()
+
+	fmt.Printf("=== Auth Sessions (%d) ===\n", len(tokens))
+	for _, token := range tokens {
+		fmt.Println()
+
+		name := "(vault not found locally)"
+		if len(token.PublicKey) >= 16 {
+			if v, err := LoadVault(token.PublicKey[:16]); err == nil {
+				name = v.Name
+			}
+		}
+		markers := ""
+		if cfg != nil && token.PublicKey == cfg.PublicKeyECDSA {
+			markers += " [ACTIVE VAULT]"
+		}
+		if cfg != nil && sameVerifier(token.VerifierURL, cfg.Verifier) {
+			markers += " [CURRENT VERIFIER]"
+		}
+
+		fmt.Printf("%s%s\n", name, markers)
+		fmt.Printf("  Public Key: %s\n", token.PublicKey)
+		fmt.Printf("  Verifier: %s\n", token.VerifierURL)
+		if time.Now().After(token.ExpiresAt) {
+			fmt.Printf("  Status: expired %s\n", token.ExpiresAt.Format(time.RFC3339))
+		} else {
+			fmt.Printf("  Status: valid until %s (in %s)\n", token.ExpiresAt.Format(time.RFC3339), time.Until(token.ExpiresAt).Round(time.Second))
+		}
+		if len(token.Token) >= 20 {
+			fmt.Printf("  Token: %s...\n", token.Token[:20])
+		}
+		printTokenClaims(token.Token)
 	}
-	err = json.Unmarshal(body, &authResp)
+
+	return nil
+}
+
+func printTokenClaims(token string) {
+	claims, err := parseTokenClaims(token)
 	if err != nil {
-		return fmt.Errorf("parse auth response: %w", err)
+		fmt.Printf("  Claims: (not a decodable JWT: %v)\n", err)
+		return
 	}
 
-	authToken := AuthToken{
-		Token:     authResp.Data.Token,
-		PublicKey: vault.PublicKeyECDSA,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+	keys := make([]string, 0, len(claims))
+	for k := range claims {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Println("  Claims:")
+	for _, k := range keys {
+		v := claims[k]
+		switch k {
+		case "exp", "iat", "nbf":
+			if n, ok := v.(float64); ok {
+				fmt.Printf("    %-12s %s\n", k+":", time.Unix(int64(n), 0).UTC().Format(time.RFC3339))
+				continue
+			}
+		}
+		fmt.Printf("    %-12s %v\n", k+":", v)
 	}
+}
 
-	err = SaveAuthToken(&authToken)
+// parseTokenClaims decodes the JWT payload without verifying the signature;
+// only the verifier can do that, we just need the claims (mainly exp).
+func parseTokenClaims(token string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, _, err := jwt.NewParser().ParseUnverified(token, claims)
 	if err != nil {
-		return fmt.Errorf("save auth token: %w", err)
+		return nil, err
 	}
+	return claims, nil
+}
 
-	fmt.Println("\n✓ Authentication successful!")
-	fmt.Printf("  Token expires: %s\n", authToken.ExpiresAt.Format(time.RFC3339))
-
-	return nil
+// tokenExpiry returns the token's exp claim, falling back to the verifier's
+// default 7 day lifetime for tokens without one.
+func tokenExpiry(token string) time.Time {
+	claims, err := parseTokenClaims(token)
+	if err == nil {
+		exp, err := claims.GetExpirationTime()
+		if err == nil && exp != nil {
+			return exp.Time
+		}
+	}
+	return time.Now().Add(7 * 24 * time.Hour)
 }
 
-func runAuthStatus() error {
-	token, err := LoadAuthToken()
-	if err != nil {
-		fmt.Println("Not authenticated.")
-		fmt.Println("\nRun 'vcli auth login' to authenticate.")
+func runAuthLogout(vaultID string, all bool) error {
+	if all {
+		err := DeleteAuthToken("")
+		if err != nil {
+			return fmt.Errorf("delete tokens: %w", err)
+		}
+		fmt.Println("Logged out of all sessions.")
 		return nil
 	}
 
-	if time.Now().After(token.ExpiresAt) {
-		fmt.Println("Authentication expired.")
-		fmt.Println("\nRun 'vcli auth login' to re-authenticate.")
-		return nil
+	publicKey := ""
+	if vaultID != "" {
+		vault, err := LoadVault(vaultID)
+		if err != nil {
+			return fmt.Errorf("vault not found: %s", vaultID)
+		}
+		publicKey = vault.PublicKeyECDSA
+	} else {
+		cfg, err := LoadConfig()
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		publicKey = cfg.PublicKeyECDSA
+	}
+	if publicKey == "" {
+		return fmt.Errorf("no active vault. Use --vault or --all")
 	}
 
-	fmt.Println("Authenticated:")
-	fmt.Printf("  Public Key: %s...\n", token.PublicKey[:16])
-	fmt.Printf("  Expires: %s\n", token.ExpiresAt.Format(time.RFC3339))
-	fmt.Printf("  Token: %s...\n", token.Token[:20])
+	err := DeleteAuthToken(publicKey)
+	if err != nil {
+		return fmt.Errorf("delete token: %w", err)
+	}
 
+	fmt.Println("Logged out successfully.")
 	return nil
 }
 
-func runAuthLogout() error {
-	err := DeleteAuthToken()
+func authTokenKey(publicKey, verifierURL string) string {
+	return publicKey + "@" + strings.TrimRight(verifierURL, "/")
+}
+
+func sameVerifier(a, b string) bool {
+	return strings.TrimRight(a, "/") == strings.TrimRight(b, "/")
+}
+
+// migrateLegacyAuthToken moves a token from the old single-session config
+// fields into the per-vault store.
+func migrateLegacyAuthToken(cfg *DevConfig) {
+	if cfg.AuthToken == "" {
+		return
+	}
+	if cfg.AuthTokens == nil {
+		cfg.AuthTokens = map[string]*AuthToken{}
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, cfg.AuthExpiresAt)
 	if err != nil {
-		return fmt.Errorf("delete token: %w", err)
+		expiresAt = tokenExpiry(cfg.AuthToken)
+	}
+	token := &AuthToken{
+		Token:       cfg.AuthToken,
+		PublicKey:   cfg.AuthPublicKey,
+		VerifierURL: cfg.Verifier,
+		ExpiresAt:   expiresAt,
+	}
+	key := authTokenKey(token.PublicKey, token.VerifierURL)
+	if _, exists := cfg.AuthTokens[key]; !exists {
+		cfg.AuthTokens[key] = token
 	}
 
-	fmt.Println("Logged out successfully.")
-	return nil
+	cfg.AuthToken = ""
+	cfg.AuthPublicKey = ""
+	cfg.AuthExpiresAt = ""
 }
 
 func SaveAuthToken(token *AuthToken) error {
@@ -239,56 +410,225 @@ func SaveAuthToken(token *AuthToken) error {
 	if err != nil {
 		cfg = DefaultConfig()
 	}
+	migrateLegacyAuthToken(cfg)
 
-	cfg.AuthToken = token.Token
-	cfg.AuthPublicKey = token.PublicKey
-	cfg.AuthExpiresAt = token.ExpiresAt.Format(time.RFC3339)
+	if token.VerifierURL == "" {
+		token.VerifierURL = cfg.Verifier
+	}
+	if cfg.AuthTokens == nil {
+		cfg.AuthTokens = map[string]*AuthToken{}
+	}
+	cfg.AuthTokens[authTokenKey(token.PublicKey, token.VerifierURL)] = token
 	return SaveConfig(cfg)
 }
 
-func LoadAuthToken() (*AuthToken, error) {
+// LoadAuthToken returns the token of the given vault for the configured verifier.
+func LoadAuthToken(publicKey string) (*AuthToken, error) {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return nil, err
 	}
+	return loadAuthTokenFor(cfg, publicKey, cfg.Verifier)
+}
 
-	if cfg.AuthToken == "" {
+func loadAuthTokenFor(cfg *DevConfig, publicKey, verifierURL string) (*AuthToken, error) {
+	migrateLegacyAuthToken(cfg)
+
+	token, ok := cfg.AuthTokens[authTokenKey(publicKey, verifierURL)]
+	if !ok || token.Token == "" {
 		return nil, fmt.Errorf("no auth token found")
 	}
 
-	expiresAt, err := time.Parse(time.RFC3339, cfg.AuthExpiresAt)
-	if err != nil {
-		return nil, fmt.Errorf("parse expiry: %w", err)
+	// The token's own exp claim is authoritative over the stored value
+	claims, err := parseTokenClaims(token.Token)
+	if err == nil {
+		exp, err := claims.GetExpirationTime()
+		if err == nil && exp != nil {
+			token.ExpiresAt = exp.Time
+		}
 	}
 
-	return &AuthToken{
-		Token:     cfg.AuthToken,
-		PublicKey: cfg.AuthPublicKey,
-		ExpiresAt: expiresAt,
-	}, nil
+	return token, nil
 }
 
-func DeleteAuthToken() error {
+// ListAuthTokens returns every stored session, ordered by verifier and key.
+func ListAuthTokens() ([]*AuthToken, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	migrateLegacyAuthToken(cfg)
+
+	tokens := make([]*AuthToken, 0, len(cfg.AuthTokens))
+	for _, token := range cfg.AuthTokens {
+		claims, err := parseTokenClaims(token.Token)
+		if err == nil {
+			exp, err := claims.GetExpirationTime()
+			if err == nil && exp != nil {
+				token.ExpiresAt = exp.Time
+			}
+		}
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].VerifierURL != tokens[j].VerifierURL {
+			return tokens[i].VerifierURL < tokens[j].VerifierURL
+		}
+		return tokens[i].PublicKey < tokens[j].PublicKey
+	})
+	return tokens, nil
+}
+
+// DeleteAuthToken removes all sessions of a vault; an empty key removes all.
+func DeleteAuthToken(publicKey string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return nil
 	}
+	migrateLegacyAuthToken(cfg)
 
-	cfg.AuthToken = ""
-	cfg.AuthPublicKey = ""
-	cfg.AuthExpiresAt = ""
+	for key, token := range cfg.AuthTokens {
+		if publicKey == "" || token.PublicKey == publicKey {
+			delete(cfg.AuthTokens, key)
+		}
+	}
 	return SaveConfig(cfg)
 }
 
-func GetAuthHeader() (string, error) {
-	token, err := LoadAuthToken()
+var (
+	errNotAuthenticated = errors.New("not authenticated. Run 'vcli auth login' first")
+	errAuthExpired      = errors.New("authentication expired. Run 'vcli auth login' to re-authenticate")
+)
+
+// GetAuthHeader returns the Authorization header for vault against the
+// configured verifier. It refuses to hand out another vault's token.
+func GetAuthHeader(vault *LocalVault) (string, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return "", fmt.Errorf("load config: %w", err)
+	}
+	return authHeaderFor(cfg, vault, cfg.Verifier)
+}
+
+func authHeaderFor(cfg *DevConfig, vault *LocalVault, verifierURL string) (string, error) {
+	token, err := loadAuthTokenFor(cfg, vault.PublicKeyECDSA, verifierURL)
 	if err != nil {
-		return "", fmt.Errorf("not authenticated. Run 'vcli auth login' first")
+		var others []string
+		for _, t := range cfg.AuthTokens {
+			if sameVerifier(t.VerifierURL, verifierURL) && len(t.PublicKey) >= 16 {
+				others = append(others, t.PublicKey[:16]+"...")
+			}
+		}
+		if len(others) > 0 {
+			sort.Strings(others)
+			return "", fmt.Errorf("%w: no token for vault '%s' (%s...) on %s; stored sessions belong to %s. Run 'vcli auth login --vault %s'",
+				errNotAuthenticated, vault.Name, vault.PublicKeyECDSA[:16], verifierURL, strings.Join(others, ", "), vault.PublicKeyECDSA[:16])
+		}
+		return "", errNotAuthenticated
+	}
+
+	claims, err := parseTokenClaims(token.Token)
+	if err == nil {
+		if claimKey, ok := claims["public_key"].(string); ok && claimKey != "" && claimKey != vault.PublicKeyECDSA {
+			return "", fmt.Errorf("auth token mismatch: stored token for vault '%s' was issued to %s... Run 'vcli auth login --vault %s'",
+				vault.Name, claimKey[:min(16, len(claimKey))], vault.PublicKeyECDSA[:16])
+		}
 	}
 
 	if time.Now().After(token.ExpiresAt) {
-		return "", fmt.Errorf("authentication expired. Run 'vcli auth login' to re-authenticate")
+		return "", errAuthExpired
 	}
 
 	return "Bearer " + token.Token, nil
 }
+
+// ensureAuthHeader is GetAuthHeader, but re-authenticates once when the
+// stored token has expired.
+func ensureAuthHeader(vault *LocalVault) (string, error) {
+	header, err := GetAuthHeader(vault)
+	if errors.Is(err, errAuthExpired) {
+		fmt.Fprintln(os.Stderr, "Auth token expired, re-authenticating...")
+		return reauthenticate(vault)
+	}
+	return header, err
+}
+
+// vaultAuth authenticates verifier client requests as a local vault,
+// re-running the TSS auth when its token expired or was rejected.
+type vaultAuth struct {
+	vault *LocalVault
+}
+
+func (a vaultAuth) AuthHeader(ctx context.Context) (string, error) {
+	return ensureAuthHeader(a.vault)
+}
+
+func (a vaultAuth) Refresh(ctx context.Context) (string, error) {
+	fmt.Fprintln(os.Stderr, "Verifier rejected auth token (401), re-authenticating...")
+	return reauthenticate(a.vault)
+}
+
+// newVerifierClient returns a client for the configured verifier. Requests to
+// authenticated endpoints are signed as vault; pass nil for public endpoints.
+func newVerifierClient(vault *LocalVault) (*verifier.Client, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	var opts []verifier.Option
+	if vault != nil {
+		opts = append(opts, verifier.WithAuth(vaultAuth{vault: vault}))
+	}
+	return verifier.New(cfg.Verifier, opts...), nil
+}
+
+// newStoredAuthClient returns a client that sends vault's stored auth token
+// as is. It never prompts or re-authenticates, for unattended and best-effort
+// requests.
+func newStoredAuthClient(vault *LocalVault, opts ...verifier.Option) (*verifier.Client, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	authHeader, err := GetAuthHeader(vault)
+	if err != nil {
+		return nil, err
+	}
+	opts = append([]verifier.Option{verifier.WithAuth(verifier.StaticAuth(authHeader))}, opts...)
+	return verifier.New(cfg.Verifier, opts...), nil
+}
+
+// sessionPassword is the vault password given to the running command, kept so
+// re-authentication does not have to prompt for it again.
+var sessionPassword string
+
+func rememberVaultPassword(password string) {
+	if password != "" {
+		sessionPassword = password
+	}
+}
+
+// reauthenticate re-runs the TSS auth for vault and returns the new
+// Authorization header.
+func reauthenticate(vault *LocalVault) (string, error) {
+	password := os.Getenv("VAULT_PASSWORD")
+	if password == "" {
+		password = sessionPassword
+	}
+	if password == "" {
+		var err error
+		password, err = promptPassword("", fmt.Sprintf("Enter Fast Vault password for '%s' to re-authenticate: ", vault.Name))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	_, err := authenticateVault(vault, password)
+	if err != nil {
+		return "", err
+	}
+	rememberVaultPassword(password)
+
+	return GetAuthHeader(vault)
+}
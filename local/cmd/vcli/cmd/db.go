@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/vultisig/vcli/local/pkg/devdb"
+)
+
+// dbTimeout bounds each lookup in the dev databases, so commands do not hang
+// when Postgres is down.
+const dbTimeout = 5 * time.Second
+
+const dbTimeFormat = "2006-01-02 15:04:05"
+
+// openVerifierDB opens the verifier database (DevConfig.DatabaseDSN).
+func openVerifierDB() (*devdb.DB, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	return devdb.Open(cfg.DatabaseDSN)
+}
+
+// openPluginDB opens a plugin's own database as named by its descriptor.
+func openPluginDB(pluginID string) (*devdb.DB, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	dsn, err := pluginDatabaseDSN(cfg, pluginDescriptor(pluginID))
+	if err != nil {
+		return nil, err
+	}
+	return devdb.Open(dsn)
+}
+
+// pluginDB returns an opener for pluginID's database, for withDB.
+func pluginDB(pluginID string) func() (*devdb.DB, error) {
+	return func() (*devdb.DB, error) {
+		return openPluginDB(pluginID)
+	}
+}
+
+// policyPluginID returns the plugin a policy belongs to, from the verifier
+// database or, if that cannot be read, the local policy registry.
+func policyPluginID(policyID string) (string, error) {
+	var pluginID string
+	err := withDB(openVerifierDB, func(ctx context.Context, db *devdb.DB) error {
+		p, err := db.Policy(ctx, policyID)
+		if err != nil {
+			return err
+		}
+		pluginID = p.PluginID
+		return nil
+	})
+	if err == nil {
+		return pluginID, nil
+	}
+	if entry, regErr := loadLocalPolicy(policyID); regErr == nil && entry.PluginID != "" {
+		return entry.PluginID, nil
+	}
+	if errors.Is(err, devdb.ErrNotFound) {
+		return "", fmt.Errorf("policy %s not found", policyID)
+	}
+	return "", fmt.Errorf("look up plugin of policy %s: %w", policyID, err)
+}
+
+// dsnHost returns the host:port of a postgres:// DSN, for display.
+func dsnHost(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// withDB runs fn against the database opened by open, within dbTimeout.
+func withDB(open func() (*devdb.DB, error), fn func(ctx context.Context, db *devdb.DB) error) error {
+	db, err := open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return fn(ctx, db)
+}
+
+// checkPolicyInDB returns the active flag and creation time of a policy in
+// the verifier database; created is empty if the policy does not exist.
+func checkPolicyInDB(policyID string) (active bool, created string, err error) {
+	err = withDB(openVerifierDB, func(ctx context.Context, db *devdb.DB) error {
+		p, err := db.Policy(ctx, policyID)
+		if errors.Is(err, devdb.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		active, created = p.Active, p.CreatedAt.Format(dbTimeFormat)
+		return nil
+	})
+	return active, created, err
+}
+
+// pluginNextExecution returns the policy's next execution in pluginID's
+// scheduler, or "" if it has no entry for it.
+func pluginNextExecution(pluginID, policyID string) (string, error) {
+	var next string
+	err := withDB(pluginDB(pluginID), func(ctx context.Context, db *devdb.DB) error {
+		t, err := db.NextExecution(ctx, policyID)
+		if errors.Is(err, devdb.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		next = t.Format(dbTimeFormat)
+		return nil
+	})
+	return next, err
+}
+
+// pluginTransactions returns the policy's latest transactions from
+// pluginID's tx_indexer, newest first.
+func pluginTransactions(pluginID, policyID string, limit int) ([]TxRecord, error) {
+	var txs []TxRecord
+	err := withDB(pluginDB(pluginID), func(ctx context.Context, db *devdb.DB) error {
+		rows, err := db.RecentTxs(ctx, policyID, limit)
+		if err != nil {
+			return err
+		}
+		for _, r := range rows {
+			txs = append(txs, TxRecord{
+				ID:            r.ID,
+				TxHash:        r.TxHash,
+				Status:        r.Status,
+				OnChainStatus: r.OnChainStatus,
+				CreatedAt:     r.CreatedAt.Format(dbTimeFormat),
+				chainID:       r.ChainID,
+				proposedTx:    r.ProposedTx,
+			})
+		}
+		return nil
+	})
+	return txs, err
+}
+
+// checkPluginInstallation returns when the plugin was installed for the
+// vault, or "" if it is not installed.
+func checkPluginInstallation(pluginID, publicKey string) (string, error) {
+	var installed string
+	err := withDB(openVerifierDB, func(ctx context.Context, db *devdb.DB) error {
+		inst, err := db.Installation(ctx, pluginID, publicKey)
+		if errors.Is(err, devdb.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		installed = inst.InstalledAt.Format(dbTimeFormat)
+		return nil
+	})
+	return installed, err
+}
+
+func removePluginInstallation(pluginID, publicKey string) error {
+	return withDB(openVerifierDB, func(ctx context.Context, db *devdb.DB) error {
+		_, err := db.DeleteInstallation(ctx, pluginID, publicKey)
+		return err
+	})
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	mobiletss "github.com/vultisig/mobile-tss-lib/tss"
+	"github.com/vultisig/vultisig-go/address"
+	"github.com/vultisig/vultisig-go/common"
+)
+
+// Account selects a BIP44 account and address index within a vault.
+// The zero value is the default account (m/44'/coin'/0'/0/0).
+type Account struct {
+	Account uint32 `json:"account"`
+	Index   uint32 `json:"index"`
+}
+
+func (a Account) IsDefault() bool {
+	return a.Account == 0 && a.Index == 0
+}
+
+func (a Account) String() string {
+	return fmt.Sprintf("account %d, index %d", a.Account, a.Index)
+}
+
+// DerivePath returns the chain's standard derivation path with the account
+// and address index substituted, e.g. m/44'/60'/1'/0/3.
+func (a Account) DerivePath(chain common.Chain) (string, error) {
+	if chain.IsEdDSA() {
+		return "", fmt.Errorf("%s uses EdDSA keys, which have no derivation path", chain)
+	}
+
+	base := chain.GetDerivePath()
+	parts := strings.Split(base, "/")
+	if len(parts) != 6 || parts[0] != "m" {
+		return "", fmt.Errorf("unsupported derivation path for %s: %q", chain, base)
+	}
+
+	parts[3] = fmt.Sprintf("%d'", a.Account)
+	parts[5] = fmt.Sprintf("%d", a.Index)
+	return strings.Join(parts, "/"), nil
+}
+
+// EVMDerivePath is the path used for message signing (auth, policies).
+func (a Account) EVMDerivePath() string {
+	path, _ := a.DerivePath(common.Ethereum)
+	return path
+}
+
+func addAccountFlags(cmd *cobra.Command, acct *Account) {
+	cmd.Flags().Uint32Var(&acct.Account, "account", 0, "BIP44 account number (m/44'/coin'/ACCOUNT'/0/index)")
+	cmd.Flags().Uint32Var(&acct.Index, "index", 0, "BIP44 address index (m/44'/coin'/account'/0/INDEX)")
+}
+
+// deriveVaultAddress derives the vault's address on chain for the given
+// account. EdDSA chains only have the root key, so only the default account
+// is accepted for them.
+func deriveVaultAddress(vault *LocalVault, chain common.Chain, acct Account) (string, error) {
+	if chain.IsEdDSA() {
+		if !acct.IsDefault() {
+			return "", fmt.Errorf("%s uses EdDSA keys, which do not support --account/--index", chain)
+		}
+		addr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, chain)
+		if err != nil {
+			return "", fmt.Errorf("derive address for %s: %w", chain, err)
+		}
+		return addr, nil
+	}
+
+	if acct.IsDefault() {
+		addr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, chain)
+		if err != nil {
+			return "", fmt.Errorf("derive address for %s: %w", chain, err)
+		}
+		return addr, nil
+	}
+
+	path, err := acct.DerivePath(chain)
+	if err != nil {
+		return "", err
+	}
+
+	pubKey, err := mobiletss.GetDerivedPubKey(vault.PublicKeyECDSA, vault.HexChainCode, path, false)
+	if err != nil {
+		return "", fmt.Errorf("derive public key for %s (%s): %w", chain, path, err)
+	}
+
+	addr, err := addressFromPubKey(pubKey, chain)
+	if err != nil {
+		return "", fmt.Errorf("derive address for %s: %w", chain, err)
+	}
+	return addr, nil
+}
+
+// addressFromPubKey encodes an already-derived ECDSA public key as an address.
+// address.GetAddress always derives from the root key with the default path,
+// so non-default accounts need this instead.
+func addressFromPubKey(pubKey string, chain common.Chain) (string, error) {
+	switch chain {
+	case common.Bitcoin:
+		return address.GetBitcoinAddress(pubKey)
+	case common.BitcoinCash:
+		return address.GetBitcoinCashAddress(pubKey)
+	case common.Litecoin:
+		return address.GetLitecoinAddress(pubKey)
+	case common.Dogecoin:
+		return address.GetDogecoinAddress(pubKey)
+	case common.Dash:
+		return address.GetDashAddress(pubKey)
+	case common.Zcash:
+		return address.GetZcashAddress(pubKey)
+	case common.GaiaChain:
+		return address.GetBech32Address(pubKey, "cosmos")
+	case common.THORChain:
+		return address.GetBech32Address(pubKey, "thor")
+	case common.MayaChain:
+		return address.GetBech32Address(pubKey, "maya")
+	case common.Kujira:
+		return address.GetBech32Address(pubKey, "kujira")
+	case common.Dydx:
+		return address.GetBech32Address(pubKey, "dydx")
+	case common.TerraClassic, common.Terra:
+		return address.GetBech32Address(pubKey, "terra")
+	case common.Osmosis:
+		return address.GetBech32Address(pubKey, "osmosis")
+	case common.Noble:
+		return address.GetBech32Address(pubKey, "noble")
+	case common.Tron:
+		return address.GetTronAddress(pubKey)
+	case common.XRP:
+		return address.GetXRPAddress(pubKey)
+	}
+
+	if chain.IsEvm() {
+		return address.GetEVMAddress(pubKey)
+	}
+	return "", fmt.Errorf("unsupported chain: %s", chain)
+}
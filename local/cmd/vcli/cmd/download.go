@@ -47,9 +47,9 @@ func EnsureGoWrappers() (string, error) {
 		return "", fmt.Errorf("create lib dir: %w", err)
 	}
 
-	fmt.Println("Downloading go-wrappers libraries...")
-	fmt.Printf("  Source: %s\n", goWrappersURL)
-	fmt.Printf("  Target: %s\n", platformDir)
+	fmt.Fprintln(os.Stderr, "Downloading go-wrappers libraries...")
+	fmt.Fprintf(os.Stderr, "  Source: %s\n", goWrappersURL)
+	fmt.Fprintf(os.Stderr, "  Target: %s\n", platformDir)
 
 	// Download the tarball
 	resp, err := http.Get(goWrappersURL)
@@ -112,7 +112,7 @@ func EnsureGoWrappers() (string, error) {
 				return "", fmt.Errorf("chmod %s: %w", filename, err)
 			}
 
-			fmt.Printf("  Extracted: %s\n", filename)
+			fmt.Fprintf(os.Stderr, "  Extracted: %s\n", filename)
 			extractedFiles++
 		}
 
@@ -143,7 +143,7 @@ func EnsureGoWrappers() (string, error) {
 		return "", fmt.Errorf("write marker: %w", err)
 	}
 
-	fmt.Printf("  Done! Extracted %d files\n", extractedFiles)
+	fmt.Fprintf(os.Stderr, "  Done! Extracted %d files\n", extractedFiles)
 
 	return platformDir, nil
 }
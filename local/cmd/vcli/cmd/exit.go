@@ -0,0 +1,16 @@
+package cmd
+
+// ExitError is returned by commands whose exit status carries meaning beyond
+// success/failure (e.g. 'policy watch' timing out). main exits with Code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logLevel  string
+	logFormat string
+	logFile   string
+
+	// logFileHandle is the open --log-file, closed by CloseLogging.
+	logFileHandle *os.File
+)
+
+// baseLogger is the logger shared by all vcli components. It is configured
+// from the global --log-* flags before any command runs and always redacts.
+var baseLogger = newBaseLogger()
+
+func newBaseLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	logger.SetFormatter(&redactingFormatter{inner: &logrus.TextFormatter{FullTimestamp: true}})
+	return logger
+}
+
+// componentLogger returns the shared logger tagged with a component name.
+func componentLogger(component string) *logrus.Entry {
+	return baseLogger.WithField("component", component)
+}
+
+// AddLoggingFlags registers the global --log-level, --log-format and
+// --log-file flags on root and applies them before every command.
+func AddLoggingFlags(root *cobra.Command) {
+	root.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log level: trace, debug, info, warn, error")
+	root.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log format: text or json")
+	root.PersistentFlags().StringVar(&logFile, "log-file", "", "Append logs to this file instead of stderr")
+
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return setupLogging(logLevel, logFormat, logFile)
+	}
+}
+
+func setupLogging(level, format, file string) error {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid --log-level %q: %w", level, err)
+	}
+
+	var inner logrus.Formatter
+	switch strings.ToLower(format) {
+	case "text", "":
+		inner = &logrus.TextFormatter{FullTimestamp: true}
+	case "json":
+		inner = &logrus.JSONFormatter{}
+	default:
+		return fmt.Errorf("invalid --log-format %q: expected text or json", format)
+	}
+
+	var out io.Writer = os.Stderr
+	if file != "" {
+		f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("open log file: %w", err)
+		}
+		CloseLogging()
+		logFileHandle = f
+		out = f
+	}
+
+	// Libraries (TSS, relay) log through the standard logger; treat it the same
+	for _, logger := range []*logrus.Logger{baseLogger, logrus.StandardLogger()} {
+		logger.SetLevel(lvl)
+		logger.SetFormatter(&redactingFormatter{inner: inner})
+		logger.SetOutput(out)
+	}
+
+	if lvl >= logrus.DebugLevel {
+		http.DefaultClient.Transport = &dumpTransport{base: http.DefaultTransport, logger: componentLogger("http")}
+	}
+
+	return nil
+}
+
+// CloseLogging flushes and closes the --log-file, if any, and points the
+// loggers back at stderr. main calls it once the command has finished.
+func CloseLogging() error {
+	if logFileHandle == nil {
+		return nil
+	}
+	f := logFileHandle
+	logFileHandle = nil
+	for _, logger := range []*logrus.Logger{baseLogger, logrus.StandardLogger()} {
+		logger.SetOutput(os.Stderr)
+	}
+
+	syncErr := f.Sync()
+	closeErr := f.Close()
+	if syncErr != nil {
+		return fmt.Errorf("sync log file: %w", syncErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("close log file: %w", closeErr)
+	}
+	return nil
+}
+
+// redactingFormatter masks secrets in the message and every field before
+// handing the entry to the real formatter.
+type redactingFormatter struct {
+	inner logrus.Formatter
+}
+
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	clean := entry.Dup()
+	clean.Level = entry.Level
+	clean.Caller = entry.Caller
+	clean.Buffer = entry.Buffer
+	clean.Message = redactString(entry.Message)
+	for key, value := range entry.Data {
+		clean.Data[key] = redactField(key, value)
+	}
+	return f.inner.Format(clean)
+}
+
+const redacted = "[REDACTED]"
+
+var sensitiveKeys = []string{
+	"password", "passphrase", "secret", "token", "authorization", "jwt",
+	"encryption_key", "encryptionkey", "hex_encryption_key",
+	"keyshare", "key_share", "private",
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactField(key string, value interface{}) interface{} {
+	if isSensitiveKey(key) {
+		return redacted
+	}
+	return redactValue(value)
+}
+
+// redactValue masks secrets inside value. Maps and slices are walked
+// recursively; structs are first converted to their JSON form so nested keys
+// are checked under the names they are logged with.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return redactString(v)
+	case []byte:
+		return redactString(string(v))
+	case error:
+		return redactString(v.Error())
+	case fmt.Stringer:
+		return redactString(v.String())
+	case map[string]interface{}:
+		clean := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			clean[key] = redactField(key, item)
+		}
+		return clean
+	case []interface{}:
+		clean := make([]interface{}, len(v))
+		for i, item := range v {
+			clean[i] = redactValue(item)
+		}
+		return clean
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return value
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return value
+		}
+		var generic interface{}
+		err = json.Unmarshal(data, &generic)
+		if err != nil {
+			return value
+		}
+		return redactValue(generic)
+	}
+	return value
+}
+
+var (
+	// "key": "value" pairs in JSON bodies
+	jsonSecretPattern = regexp.MustCompile(`(?i)("(?:[a-z_]*password|passphrase|[a-z_]*secret|[a-z_]*token|hex_encryption_key|encryption_key|[a-z_]*keyshare|key_share|private_key)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// key=value pairs in query strings and form bodies
+	querySecretPattern = regexp.MustCompile(`(?i)\b((?:[a-z_]*password|passphrase|[a-z_]*secret|[a-z_]*token|hex_encryption_key|encryption_key)=)[^&\s"]+`)
+	bearerPattern      = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+	jwtPattern         = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+)
+
+// redactString masks passwords, encryption keys, keyshares and JWTs in free
+// text such as log messages, JSON bodies and HTTP dumps.
+func redactString(s string) string {
+	s = jsonSecretPattern.ReplaceAllString(s, `$1"`+redacted+`"`)
+	s = querySecretPattern.ReplaceAllString(s, "${1}"+redacted)
+	s = bearerPattern.ReplaceAllString(s, "${1}"+redacted)
+	s = jwtPattern.ReplaceAllString(s, redacted)
+	return s
+}
+
+// dumpTransport logs redacted request and response dumps at debug level.
+type dumpTransport struct {
+	base   http.RoundTripper
+	logger *logrus.Entry
+}
+
+func (t *dumpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	dump, err := httputil.DumpRequestOut(req, true)
+	if err == nil {
+		t.logger.Debug(redactString(string(dump)))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.logger.WithError(err).Debugf("%s %s failed", req.Method, req.URL.Redacted())
+		return nil, err
+	}
+
+	// Buffer the body so it can be both logged and handed back to the caller,
+	// including a read error part-way through.
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	dump, err = httputil.DumpResponse(resp, true)
+	if err == nil {
+		t.logger.Debug(redactString(string(dump)))
+	}
+	resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{readErr}))
+	if readErr != nil {
+		t.logger.WithError(readErr).Debugf("%s %s: reading response body failed", req.Method, req.URL.Redacted())
+	}
+	return resp, nil
+}
+
+// errReader returns err, or io.EOF when err is nil.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read(p []byte) (int, error) {
+	if r.err == nil {
+		return 0, io.EOF
+	}
+	return 0, r.err
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// structuredOutputAnnotation marks commands that can print their result as
+// JSON or YAML.
+const structuredOutputAnnotation = "vcli.structured-output"
+
+var outputFormat = OutputTable
+
+// resultOut receives the command result. In json/yaml mode os.Stdout is
+// pointed at stderr so progress chatter (including TSS and relay output)
+// never mixes with the document on the real stdout.
+var resultOut io.Writer = os.Stdout
+
+// AddOutputFlags registers the global --output flag on root. Commands with a
+// local --output flag (vault export) keep their own meaning.
+func AddOutputFlags(root *cobra.Command) {
+	root.PersistentFlags().StringVarP(&outputFormat, "output", "o", OutputTable, "Output format: table, json or yaml")
+
+	prev := root.PersistentPreRunE
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if prev != nil {
+			err := prev(cmd, args)
+			if err != nil {
+				return err
+			}
+		}
+		return setupOutput(cmd)
+	}
+}
+
+// withStructuredOutput marks cmd as supporting --output json|yaml.
+func withStructuredOutput(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[structuredOutputAnnotation] = "true"
+	return cmd
+}
+
+func setupOutput(cmd *cobra.Command) error {
+	// Shadowed by a local --output (a file path)
+	if cmd.Flags().Lookup("output") != cmd.Root().PersistentFlags().Lookup("output") {
+		outputFormat = OutputTable
+		return nil
+	}
+
+	switch outputFormat {
+	case OutputTable:
+		return nil
+	case OutputJSON, OutputYAML:
+	default:
+		return fmt.Errorf("invalid --output %q: expected table, json or yaml", outputFormat)
+	}
+
+	if cmd.Annotations[structuredOutputAnnotation] == "" {
+		return fmt.Errorf("'%s' does not support --output %s", cmd.CommandPath(), outputFormat)
+	}
+
+	resultOut = os.Stdout
+	os.Stdout = os.Stderr
+	return nil
+}
+
+// structuredOutput reports whether the result is printed as JSON or YAML.
+func structuredOutput() bool {
+	return outputFormat == OutputJSON || outputFormat == OutputYAML
+}
+
+// printResult writes result as JSON or YAML, or calls table for the
+// human-readable format.
+func printResult(result any, table func()) error {
+	switch outputFormat {
+	case OutputJSON:
+		enc := json.NewEncoder(resultOut)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case OutputYAML:
+		return writeYAML(resultOut, result)
+	default:
+		table()
+		return nil
+	}
+}
+
+// printJSON is the table view of results that have no tabular layout.
+func printJSON(v any) {
+	prettyJSON, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Printf("format result: %v\n", err)
+		return
+	}
+	fmt.Println(string(prettyJSON))
+}
+
+// writeYAML encodes v through its JSON form so YAML keys, omitempty and
+// field order match the JSON schema exactly.
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode result: %w", err)
+	}
+
+	var node yaml.Node
+	err = yaml.Unmarshal(data, &node)
+	if err != nil {
+		return fmt.Errorf("encode result: %w", err)
+	}
+	clearYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	err = enc.Encode(&node)
+	if err != nil {
+		return fmt.Errorf("encode result: %w", err)
+	}
+	err = enc.Close()
+	if err != nil {
+		return fmt.Errorf("encode result: %w", err)
+	}
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// clearYAMLStyle drops the flow/quoted styles inherited from the JSON input
+// so the document is emitted in block style.
+func clearYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearYAMLStyle(c)
+	}
+}
@@ -5,14 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
 )
 
 func NewPluginCmd() *cobra.Command {
@@ -23,72 +24,217 @@ func NewPluginCmd() *cobra.Command {
 
 	cmd.AddCommand(newPluginListCmd())
 	cmd.AddCommand(newPluginAliasesCmd())
+	cmd.AddCommand(newPluginSyncCmd())
+	cmd.AddCommand(newPluginSetURLCmd())
 	cmd.AddCommand(newPluginInfoCmd())
 	cmd.AddCommand(newPluginInstallCmd())
 	cmd.AddCommand(newPluginUninstallCmd())
 	cmd.AddCommand(newPluginSpecCmd())
+	cmd.AddCommand(newPluginRegisterCmd())
+	cmd.AddCommand(newPluginUnregisterCmd())
+	cmd.AddCommand(newPluginConformanceCmd())
 
 	return cmd
 }
 
 func newPluginListCmd() *cobra.Command {
-	return &cobra.Command{
+	return withStructuredOutput(&cobra.Command{
 		Use:   "list",
 		Short: "List available plugins from verifier",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPluginList()
 		},
-	}
+	})
 }
 
 func newPluginAliasesCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := withStructuredOutput(&cobra.Command{
 		Use:   "aliases",
-		Short: "Show plugin aliases (short names)",
-		Long: `Show available plugin aliases for convenience.
+		Short: "Show known plugins, their aliases and where they came from",
+		Long: `Show the plugin registry: every plugin vcli knows, its aliases and server URL.
+
+Entries come from three places, later ones winning:
+  builtin   the local stack's plugins (dca, fees, sends)
+  verifier  the verifier's /plugins list, synced every 10 minutes
+  config    plugin_aliases / plugin_server_urls in ~/.vultisig/vcli.json
 
-You can use either the short alias or the full plugin ID in any command.
+You can use either an alias or the full plugin ID in any command.
 
 Examples:
   vcli plugin install dca              # Uses alias
   vcli plugin install vultisig-dca-0000  # Uses full ID
+  vcli plugin aliases set mine my-plugin-0000
+  vcli plugin set-url my-plugin-0000 http://localhost:8090
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPluginAliases()
+			return runPluginAliases(pluginSyncIfStale)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "set [alias] [plugin-id]",
+		Short: "Add a user-defined alias",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPluginAliasSet(args[0], args[1])
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "unset [alias]",
+		Short: "Remove a user-defined alias",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPluginAliasSet(args[0], "")
+		},
+	})
+
+	return cmd
+}
+
+func newPluginSyncCmd() *cobra.Command {
+	return withStructuredOutput(&cobra.Command{
+		Use:   "sync",
+		Short: "Refresh the plugin registry from the verifier",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return runPluginAliases(pluginSyncAlways)
+		},
+	})
+}
+
+func newPluginSetURLCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-url [plugin-id] [url]",
+		Short: "Override a plugin's server URL (omit the URL to clear)",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			url := ""
+			if len(args) == 2 {
+				url = args[1]
+			}
+			return runPluginSetURL(ResolvePluginID(args[0]), url)
 		},
 	}
 }
 
-func runPluginAliases() error {
-	fmt.Println("Available Plugin Aliases:")
-	fmt.Println()
-	fmt.Println("┌──────────┬─────────────────────────────────┬────────────────────────────────┐")
-	fmt.Println("│ Alias    │ Full Plugin ID                  │ Description                    │")
-	fmt.Println("├──────────┼─────────────────────────────────┼────────────────────────────────┤")
-	for _, p := range KnownPlugins {
-		aliases := strings.Join(p.Aliases, ", ")
-		name := p.Name
-		if len(name) > 30 {
-			name = name[:27] + "..."
+func runPluginAliases(sync int) error {
+	r := loadPluginRegistry(sync)
+	if r.syncErr != nil {
+		if sync == pluginSyncAlways {
+			return fmt.Errorf("sync plugins: %w", r.syncErr)
 		}
-		fmt.Printf("│ %-8s │ %-31s │ %-30s │\n", aliases, p.ID, name)
+		fmt.Fprintf(os.Stderr, "Warning: could not sync plugins from the verifier: %v\n", r.syncErr)
+	}
+
+	plugins := make([]PluginInfo, len(r.plugins))
+	for i, p := range r.plugins {
+		plugins[i] = *p
+	}
+
+	return printResult(plugins, func() {
+		fmt.Println("Plugin Registry:")
+		fmt.Println()
+		fmt.Println("┌──────────────┬─────────────────────────────────┬──────────┬────────────────────────────────────────┐")
+		fmt.Println("│ Alias        │ Plugin ID                       │ Source   │ Server URL                             │")
+		fmt.Println("├──────────────┼─────────────────────────────────┼──────────┼────────────────────────────────────────┤")
+		userAliases := false
+		for _, p := range plugins {
+			var aliases []string
+			for _, a := range p.Aliases {
+				if slices.Contains(p.ConfigAliases, a) {
+					a += "*"
+					userAliases = true
+				}
+				aliases = append(aliases, a)
+			}
+			url := p.ServerURL
+			if url != "" && p.ServerURLSource != p.Source {
+				url += " (" + p.ServerURLSource + ")"
+			}
+			fmt.Printf("│ %-12s │ %-31s │ %-8s │ %-38s │\n",
+				truncate(strings.Join(aliases, ", "), 12), truncate(p.ID, 31), p.Source, truncate(url, 38))
+		}
+		fmt.Println("└──────────────┴─────────────────────────────────┴──────────┴────────────────────────────────────────┘")
+		if userAliases {
+			fmt.Printf("* user-defined alias (plugin_aliases in %s)\n", ConfigPath())
+		}
+		if r.cache != nil {
+			fmt.Printf("\nVerifier list synced %s ago from %s\n", time.Since(r.cache.SyncedAt).Round(time.Second), r.cache.VerifierURL)
+		} else {
+			fmt.Println("\nNot synced with the verifier yet (run 'vcli plugin sync')")
+		}
+		fmt.Println()
+		fmt.Println("Usage: vcli plugin install <alias-or-full-id>")
+		fmt.Println("       vcli policy add --plugin <alias-or-full-id> ...")
+	})
+}
+
+func runPluginAliasSet(alias, pluginID string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	if pluginID == "" {
+		if _, ok := cfg.PluginAliases[alias]; !ok {
+			return fmt.Errorf("%s is not a user-defined alias", alias)
+		}
+		delete(cfg.PluginAliases, alias)
+	} else {
+		if cfg.PluginAliases == nil {
+			cfg.PluginAliases = map[string]string{}
+		}
+		cfg.PluginAliases[alias] = pluginID
+	}
+
+	err = SaveConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if pluginID == "" {
+		fmt.Printf("Removed alias %s\n", alias)
+	} else {
+		fmt.Printf("Alias %s -> %s\n", alias, pluginID)
+	}
+	return nil
+}
+
+func runPluginSetURL(pluginID, url string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	if url == "" {
+		delete(cfg.PluginServerURLs, pluginID)
+	} else {
+		if cfg.PluginServerURLs == nil {
+			cfg.PluginServerURLs = map[string]string{}
+		}
+		cfg.PluginServerURLs[pluginID] = url
+	}
+
+	err = SaveConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if url == "" {
+		fmt.Printf("Cleared the server URL override of %s\n", pluginID)
+	} else {
+		fmt.Printf("%s -> %s\n", pluginID, url)
 	}
-	fmt.Println("└──────────┴─────────────────────────────────┴────────────────────────────────┘")
-	fmt.Println()
-	fmt.Println("Usage: vcli plugin install <alias-or-full-id>")
-	fmt.Println("       vcli policy add --plugin <alias-or-full-id> ...")
 	return nil
 }
 
 func newPluginInfoCmd() *cobra.Command {
-	return &cobra.Command{
+	return withStructuredOutput(&cobra.Command{
 		Use:   "info [plugin-id]",
 		Short: "Show plugin details",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPluginInfo(args[0])
+			return runPluginInfo(ResolvePluginID(args[0]))
 		},
-	}
+	})
 }
 
 func newPluginInstallCmd() *cobra.Command {
@@ -139,7 +285,7 @@ func newPluginUninstallCmd() *cobra.Command {
 		Short: "Uninstall a plugin",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPluginUninstall(args[0])
+			return runPluginUninstall(ResolvePluginID(args[0]))
 		},
 	}
 }
@@ -150,85 +296,59 @@ func newPluginSpecCmd() *cobra.Command {
 		Short: "Show plugin recipe specification",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPluginSpec(args[0])
+			return runPluginSpec(ResolvePluginID(args[0]))
 		},
 	}
 }
 
 func runPluginList() error {
-	cfg, err := LoadConfig()
+	client, err := newVerifierClient(nil)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
 	fmt.Println("Fetching available plugins...")
 
-	url := cfg.Verifier + "/plugins"
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	resp, err := http.DefaultClient.Do(req)
+	list, err := client.ListPlugins(context.Background(), verifier.Page{})
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("list plugins: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
+	if list.Plugins == nil {
+		list.Plugins = []verifier.Plugin{}
+	}
+	err = savePluginCache(client.BaseURL(), list.Plugins)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not update the plugin registry: %v\n", err)
+	}
 
-	if data, ok := result["data"].(map[string]interface{}); ok {
-		if plugins, ok := data["plugins"].([]interface{}); ok {
-			fmt.Printf("\nAvailable Plugins (%d):\n\n", len(plugins))
-			for _, p := range plugins {
-				plugin := p.(map[string]interface{})
-				fmt.Printf("  %s\n", plugin["id"])
-				fmt.Printf("    Name: %s\n", plugin["title"])
-				if desc, ok := plugin["description"].(string); ok && desc != "" {
-					fmt.Printf("    Description: %s\n", desc)
-				}
-				fmt.Println()
+	return printResult(list, func() {
+		fmt.Printf("\nAvailable Plugins (%d):\n\n", len(list.Plugins))
+		for _, plugin := range list.Plugins {
+			fmt.Printf("  %s\n", plugin.ID)
+			fmt.Printf("    Name: %s\n", plugin.Title)
+			if plugin.Description != "" {
+				fmt.Printf("    Description: %s\n", plugin.Description)
 			}
-			return nil
+			fmt.Println()
 		}
-	}
-
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
-
-	return nil
+	})
 }
 
 func runPluginInfo(pluginID string) error {
-	cfg, err := LoadConfig()
+	client, err := newVerifierClient(nil)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
 	fmt.Printf("Fetching plugin info for %s...\n\n", pluginID)
 
-	url := fmt.Sprintf("%s/plugins/%s", cfg.Verifier, pluginID)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	resp, err := http.DefaultClient.Do(req)
+	plugin, err := client.GetPlugin(context.Background(), pluginID)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("get plugin: %w", err)
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
-
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
-
-	return nil
+	return printResult(plugin, func() { printJSON(plugin) })
 }
 
 func runPluginInstall(pluginIDOrAlias string, password string) error {
@@ -245,16 +365,20 @@ func runPluginInstall(pluginIDOrAlias string, password string) error {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	authHeader, err := GetAuthHeader()
+	vault, err := ActiveVault()
 	if err != nil {
-		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import --password xxx' to authenticate first", err)
+		return err
+	}
+	err = requireKeyshares(vault)
+	if err != nil {
+		return err
 	}
 
-	vaults, err := ListVaults()
-	if err != nil || len(vaults) == 0 {
-		return fmt.Errorf("no vaults found. Import a vault first: vcli vault import")
+	rememberVaultPassword(password)
+	authHeader, err := ensureAuthHeader(vault)
+	if err != nil {
+		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import --password xxx' to authenticate first", err)
 	}
-	vault := vaults[0]
 
 	fmt.Printf("Installing plugin %s...\n", pluginID)
 	fmt.Printf("  Vault: %s (%s...)\n", vault.Name, vault.PublicKeyECDSA[:16])
@@ -274,7 +398,10 @@ func runPluginInstall(pluginIDOrAlias string, password string) error {
 	}
 
 	// Check if plugin is already installed
-	dbRecord := checkPluginInstallation(pluginID, vault.PublicKeyECDSA)
+	dbRecord, dbErr := checkPluginInstallation(pluginID, vault.PublicKeyECDSA)
+	if dbErr != nil {
+		fmt.Printf("  ⚠ Could not check the installation record: %v\n", dbErr)
+	}
 	if dbRecord != "" {
 		fmt.Printf("\n  Plugin %s is already installed for this vault.\n", pluginID)
 		fmt.Printf("  Installed at: %s\n", dbRecord)
@@ -283,20 +410,13 @@ func runPluginInstall(pluginIDOrAlias string, password string) error {
 	}
 
 	fmt.Println("\nChecking plugin availability...")
-	pluginURL := fmt.Sprintf("%s/plugins/%s", cfg.Verifier, pluginID)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	req, _ := http.NewRequestWithContext(ctx, "GET", pluginURL, nil)
-	resp, err := http.DefaultClient.Do(req)
+	client, err := newVerifierClient(vault)
 	if err != nil {
-		return fmt.Errorf("check plugin: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("plugin not found: %s", string(body))
+	_, err = client.GetPlugin(context.Background(), pluginID)
+	if err != nil {
+		return fmt.Errorf("plugin not found: %w", err)
 	}
 
 	fmt.Println("  Plugin found!")
@@ -329,10 +449,14 @@ func runPluginInstall(pluginIDOrAlias string, password string) error {
 
 	// Validate storage - check MinIO buckets (with retry)
 	verifierFile, verifierSize := checkMinioFileWithRetry("vultisig-verifier", pluginID, vault.PublicKeyECDSA, 3)
-	dcaFile, dcaSize := checkMinioFileWithRetry("vultisig-dca", pluginID, vault.PublicKeyECDSA, 3)
+	desc := pluginDescriptor(pluginID)
+	var pluginFile, pluginSize string
+	if desc.Bucket != "" {
+		pluginFile, pluginSize = checkMinioFileWithRetry(desc.Bucket, pluginID, vault.PublicKeyECDSA, 3)
+	}
 
 	// Check database record
-	dbRecord = checkPluginInstallation(pluginID, vault.PublicKeyECDSA)
+	dbRecord, dbErr = checkPluginInstallation(pluginID, vault.PublicKeyECDSA)
 
 	// Print completion report
 	fmt.Println()
@@ -349,7 +473,7 @@ func runPluginInstall(pluginIDOrAlias string, password string) error {
 	fmt.Println("│                                                                 │")
 	fmt.Println("│  TSS Reshare:                                                   │")
 	for i, signer := range newVault.Signers {
-		role := getSignerRole(signer, vault.LocalPartyID)
+		role := getSignerRole(signer, vault.LocalPartyID, desc)
 		signerDisplay := signer
 		if len(signerDisplay) > 25 {
 			signerDisplay = signerDisplay[:25] + ".."
@@ -360,19 +484,24 @@ func runPluginInstall(pluginIDOrAlias string, password string) error {
 	fmt.Println("│                                                                 │")
 	fmt.Println("│  Keyshares Stored:                                              │")
 	if verifierFile != "" {
-		fmt.Printf("│    Verifier (MinIO): ✓ %-41s │\n", verifierSize)
+		printKeyshareRow("Verifier (MinIO):", "✓", verifierSize)
 	} else {
-		fmt.Printf("│    Verifier (MinIO): ✗ %-41s │\n", "Not found")
+		printKeyshareRow("Verifier (MinIO):", "✗", "Not found")
 	}
-	if dcaFile != "" {
-		fmt.Printf("│    DCA Plugin (MinIO): ✓ %-39s │\n", dcaSize)
-	} else {
-		fmt.Printf("│    DCA Plugin (MinIO): ✗ %-39s │\n", "Not found")
+	switch {
+	case desc.Bucket == "":
+		printKeyshareRow(desc.Label+" (MinIO):", "-", "No bucket in plugin descriptor")
+	case pluginFile != "":
+		printKeyshareRow(desc.Label+" (MinIO):", "✓", pluginSize)
+	default:
+		printKeyshareRow(desc.Label+" (MinIO):", "✗", "Not found")
 	}
 	fmt.Println("│                                                                 │")
 	fmt.Println("│  Database:                                                      │")
 	if dbRecord != "" {
 		fmt.Printf("│    plugin_installations: ✓ %-37s │\n", dbRecord)
+	} else if dbErr != nil {
+		fmt.Printf("│    plugin_installations: ✗ %-37s │\n", truncate(dbErr.Error(), 37))
 	} else {
 		fmt.Printf("│    plugin_installations: ✗ %-37s │\n", "Not found")
 	}
@@ -381,13 +510,16 @@ func runPluginInstall(pluginIDOrAlias string, password string) error {
 	fmt.Println("│                                                                 │")
 	fmt.Println("└─────────────────────────────────────────────────────────────────┘")
 	fmt.Println()
-	fmt.Println("Next: vcli policy generate --from <asset> --to <asset> --amount <amount> --output policy.json")
+	fmt.Println("Next: vcli policy generate --from <asset> --to <asset> --amount <amount> --output-file policy.json")
 	fmt.Println("      vcli policy add --plugin", pluginID, "--policy-file policy.json --password <password>")
 
 	return nil
 }
 
-func getSignerRole(signer, localPartyID string) string {
+// getSignerRole labels a reshare party. Plugin workers are matched by
+// descriptor party prefix, the installed plugin's own descriptor first since
+// plugins sharing a worker share its prefix.
+func getSignerRole(signer, localPartyID string, desc PluginDescriptor) string {
 	if signer == localPartyID {
 		return "(CLI)"
 	}
@@ -397,12 +529,24 @@ func getSignerRole(signer, localPartyID string) string {
 	if strings.HasPrefix(signer, "verifier-") {
 		return "(Verifier)"
 	}
-	if strings.HasPrefix(signer, "dca-worker-") {
-		return "(DCA Plugin)"
+	if desc.PartyPrefix != "" && strings.HasPrefix(signer, desc.PartyPrefix+"-") {
+		return "(" + desc.Label + ")"
+	}
+	for _, p := range loadPluginRegistry(pluginSyncNever).plugins {
+		d := p.Descriptor
+		if d.PartyPrefix != "" && strings.HasPrefix(signer, d.PartyPrefix+"-") {
+			return "(" + d.Label + ")"
+		}
 	}
 	return ""
 }
 
+// printKeyshareRow prints a "label mark detail" row of the install and
+// uninstall reports, padded to the box width.
+func printKeyshareRow(label, mark, detail string) {
+	fmt.Printf("│    %s %s %-*s │\n", label, mark, max(58-len(label), 0), detail)
+}
+
 func checkMinioFileWithRetry(bucket, pluginID, publicKey string, maxRetries int) (string, string) {
 	for i := 0; i < maxRetries; i++ {
 		file, size := checkMinioFile(bucket, pluginID, publicKey)
@@ -451,28 +595,6 @@ func formatBytesShort(bytes int64) string {
 	return fmt.Sprintf("%.1fMB", float64(bytes)/(1024*1024))
 }
 
-func checkPluginInstallation(pluginID, publicKey string) string {
-	cmd := exec.Command("docker", "exec", "vultisig-postgres",
-		"psql", "-U", "vultisig", "-d", "vultisig-verifier", "-t", "-c",
-		fmt.Sprintf("SELECT installed_at FROM plugin_installations WHERE plugin_id='%s' AND public_key='%s' LIMIT 1", pluginID, publicKey))
-
-	output, err := cmd.Output()
-	if err != nil {
-		return ""
-	}
-
-	result := strings.TrimSpace(string(output))
-	if result == "" {
-		return ""
-	}
-
-	t, err := time.Parse("2006-01-02 15:04:05.999999-07", result)
-	if err != nil {
-		return result
-	}
-	return t.Format("2006-01-02 15:04:05")
-}
-
 func runPluginUninstall(pluginID string) error {
 	startTime := time.Now()
 
@@ -489,11 +611,18 @@ func runPluginUninstall(pluginID string) error {
 	fmt.Printf("  Vault: %s\n", cfg.PublicKeyECDSA[:16]+"...")
 
 	// Check current installation status
-	dbRecord := checkPluginInstallation(pluginID, cfg.PublicKeyECDSA)
+	dbRecord, dbErr := checkPluginInstallation(pluginID, cfg.PublicKeyECDSA)
+	if dbErr != nil {
+		fmt.Printf("  ⚠ Could not check the installation record: %v\n", dbErr)
+	}
 	verifierFile, _ := checkMinioFile("vultisig-verifier", pluginID, cfg.PublicKeyECDSA)
-	dcaFile, _ := checkMinioFile("vultisig-dca", pluginID, cfg.PublicKeyECDSA)
+	desc := pluginDescriptor(pluginID)
+	var pluginFile string
+	if desc.Bucket != "" {
+		pluginFile, _ = checkMinioFile(desc.Bucket, pluginID, cfg.PublicKeyECDSA)
+	}
 
-	if dbRecord == "" && verifierFile == "" && dcaFile == "" {
+	if dbErr == nil && dbRecord == "" && verifierFile == "" && pluginFile == "" {
 		fmt.Println("\n  Plugin is not installed for this vault.")
 		return nil
 	}
@@ -502,10 +631,14 @@ func runPluginUninstall(pluginID string) error {
 
 	// Remove MinIO files (verifier + plugin 2-of-4 shares)
 	verifierRemoved := removeMinioFile("vultisig-verifier", pluginID, cfg.PublicKeyECDSA)
-	dcaRemoved := removeMinioFile("vultisig-dca", pluginID, cfg.PublicKeyECDSA)
+	pluginRemoved := pluginFile != "" && removeMinioFile(desc.Bucket, pluginID, cfg.PublicKeyECDSA)
 
 	// Remove database record
-	dbRemoved := removePluginInstallation(pluginID, cfg.PublicKeyECDSA)
+	dbErr = removePluginInstallation(pluginID, cfg.PublicKeyECDSA)
+	if dbErr != nil {
+		fmt.Printf("  ⚠ Database record not deleted: %v\n", dbErr)
+	}
+	dbRemoved := dbErr == nil
 
 	totalDuration := time.Since(startTime)
 
@@ -520,18 +653,21 @@ func runPluginUninstall(pluginID string) error {
 	fmt.Println("│                                                                 │")
 	fmt.Println("│  Removed:                                                       │")
 	if verifierRemoved {
-		fmt.Printf("│    Verifier keyshare (MinIO): ✓ %-32s │\n", "Deleted")
+		printKeyshareRow("Verifier keyshare (MinIO):", "✓", "Deleted")
 	} else if verifierFile != "" {
-		fmt.Printf("│    Verifier keyshare (MinIO): ✗ %-32s │\n", "Failed to delete")
+		printKeyshareRow("Verifier keyshare (MinIO):", "✗", "Failed to delete")
 	} else {
-		fmt.Printf("│    Verifier keyshare (MinIO): - %-32s │\n", "Not found")
-	}
-	if dcaRemoved {
-		fmt.Printf("│    DCA Plugin keyshare (MinIO): ✓ %-30s │\n", "Deleted")
-	} else if dcaFile != "" {
-		fmt.Printf("│    DCA Plugin keyshare (MinIO): ✗ %-30s │\n", "Failed to delete")
+		printKeyshareRow("Verifier keyshare (MinIO):", "-", "Not found")
+	}
+	pluginLabel := desc.Label + " keyshare (MinIO):"
+	if pluginRemoved {
+		printKeyshareRow(pluginLabel, "✓", "Deleted")
+	} else if pluginFile != "" {
+		printKeyshareRow(pluginLabel, "✗", "Failed to delete")
+	} else if desc.Bucket == "" {
+		printKeyshareRow(pluginLabel, "-", "No bucket in plugin descriptor")
 	} else {
-		fmt.Printf("│    DCA Plugin keyshare (MinIO): - %-30s │\n", "Not found")
+		printKeyshareRow(pluginLabel, "-", "Not found")
 	}
 	if dbRemoved {
 		fmt.Printf("│    Database record: ✓ %-42s │\n", "Deleted")
@@ -561,76 +697,25 @@ func removeMinioFile(bucket, pluginID, publicKey string) bool {
 	return err == nil
 }
 
-func removePluginInstallation(pluginID, publicKey string) bool {
-	cmd := exec.Command("docker", "exec", "vultisig-postgres",
-		"psql", "-U", "vultisig", "-d", "vultisig-verifier", "-c",
-		fmt.Sprintf("DELETE FROM plugin_installations WHERE plugin_id='%s' AND public_key='%s'", pluginID, publicKey))
-
-	err := cmd.Run()
-	return err == nil
-}
-
 func runPluginSpec(pluginID string) error {
-	cfg, err := LoadConfig()
+	client, err := newVerifierClient(nil)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
 	fmt.Printf("Fetching recipe specification for %s...\n\n", pluginID)
 
-	url := fmt.Sprintf("%s/plugins/%s/recipe-specification", cfg.Verifier, pluginID)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
-
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
-
-	return nil
-}
-
-func doRequest(method, url string, body interface{}) ([]byte, int, error) {
-	cfg, err := LoadConfig()
+	spec, err := client.RecipeSpecification(context.Background(), pluginID)
 	if err != nil {
-		return nil, 0, err
+		return fmt.Errorf("get recipe specification: %w", err)
 	}
-	_ = cfg
 
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, _ := json.Marshal(body)
-		reqBody = bytes.NewReader(jsonBody)
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-
-	resp, err := http.DefaultClient.Do(req)
+	var pretty bytes.Buffer
+	err = json.Indent(&pretty, spec, "", "  ")
 	if err != nil {
-		return nil, 0, err
+		return fmt.Errorf("format recipe specification: %w", err)
 	}
-	defer resp.Body.Close()
+	fmt.Println(pretty.String())
 
-	respBody, _ := io.ReadAll(resp.Body)
-	return respBody, resp.StatusCode, nil
+	return nil
 }
@@ -0,0 +1,758 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	rtypes "github.com/vultisig/recipes/types"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/vultisig/vcli/local/pkg/devdb"
+	"github.com/vultisig/vcli/local/pkg/schema"
+	"github.com/vultisig/vcli/local/pkg/verifier"
+)
+
+const (
+	conformancePass = "pass"
+	conformanceFail = "fail"
+	conformanceSkip = "skip"
+)
+
+// ConformanceCase is one check of a conformance run.
+type ConformanceCase struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+type ConformanceReport struct {
+	PluginID   string            `json:"plugin_id"`
+	ServerURL  string            `json:"server_url"`
+	Cases      []ConformanceCase `json:"cases"`
+	Passed     int               `json:"passed"`
+	Failed     int               `json:"failed"`
+	Skipped    int               `json:"skipped"`
+	DurationMs int64             `json:"duration_ms"`
+}
+
+func newPluginConformanceCmd() *cobra.Command {
+	var serverURL, policyFile, password, junitPath string
+	var smoke bool
+
+	cmd := &cobra.Command{
+		Use:   "conformance [plugin-id]",
+		Short: "Check that a plugin server speaks the verifier's protocol",
+		Long: `Run protocol checks against a plugin server:
+
+  healthz                       GET /healthz answers 200
+  recipe-specification          GET /plugin/recipe-specification parses and names the plugin
+  recipe-specification/schema   it has an object configuration schema and supported chains
+  suggest/valid                 POST .../suggest with a valid configuration returns a
+                                PolicySuggest with rules
+  suggest/invalid-empty         an empty configuration is rejected with a 4xx
+  suggest/invalid-type          a configuration violating the schema is rejected with a 4xx
+  pricing/billing               the verifier's /plugins pricing converts to billing that
+                                policy add accepts (fetchPluginBilling)
+  pricing/database              /plugins serves the plugin's rows of the verifier's
+                                pricings table (skipped if the database is unreachable)
+
+The valid configuration is the recipe of --policy-file, or else one built from
+the schema's defaults, enums and examples. --smoke also installs the plugin if
+needed (4-party reshare), adds the --policy-file policy, checks the verifier
+activated it and deletes it again.
+
+The exit code is 1 if any check fails. --junit writes a JUnit XML report for CI;
+-o json prints the same results as JSON.
+`,
+		Example: `  vcli plugin conformance my-plugin-0000 --server http://localhost:8090
+  vcli plugin conformance dca --policy-file policies/dca.json --junit conformance.xml
+  vcli plugin conformance dca --policy-file policies/dca.json --smoke --password xxx`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			if envPass := os.Getenv("VAULT_PASSWORD"); password == "" && envPass != "" {
+				password = envPass
+			}
+			if smoke && (policyFile == "" || password == "") {
+				return fmt.Errorf("--smoke needs --policy-file and --password (or VAULT_PASSWORD)")
+			}
+			return runPluginConformance(ResolvePluginID(args[0]), serverURL, policyFile, password, junitPath, smoke)
+		},
+	}
+
+	cmd.Flags().StringVar(&serverURL, "server", "", "Plugin server URL (default: from the plugin registry)")
+	cmd.Flags().StringVar(&policyFile, "policy-file", "", "Policy file whose recipe is the valid configuration")
+	cmd.Flags().BoolVar(&smoke, "smoke", false, "Also install the plugin and add, check and delete a policy")
+	cmd.Flags().StringVar(&password, "password", "", "Fast Vault password for --smoke (or set VAULT_PASSWORD env var)")
+	cmd.Flags().StringVar(&junitPath, "junit", "", "Write a JUnit XML report to this file")
+
+	return withStructuredOutput(cmd)
+}
+
+// conformanceRun collects the cases of one run.
+type conformanceRun struct {
+	report ConformanceReport
+}
+
+// check runs fn as the named case. fn returns a detail message and the
+// case status; an error fails the case with its message.
+func (r *conformanceRun) check(name string, fn func() (string, string, error)) string {
+	start := time.Now()
+	msg, status, err := fn()
+	if err != nil {
+		msg, status = err.Error(), conformanceFail
+	}
+	c := ConformanceCase{Name: name, Status: status, Message: msg, DurationMs: time.Since(start).Milliseconds()}
+	r.report.Cases = append(r.report.Cases, c)
+	switch status {
+	case conformancePass:
+		r.report.Passed++
+		fmt.Printf("  ✓ %s\n", name)
+	case conformanceFail:
+		r.report.Failed++
+		fmt.Printf("  ✗ %s: %s\n", name, msg)
+	default:
+		r.report.Skipped++
+		fmt.Printf("  - %s: %s\n", name, msg)
+	}
+	return status
+}
+
+func (r *conformanceRun) skip(name, reason string) {
+	r.check(name, func() (string, string, error) { return reason, conformanceSkip, nil })
+}
+
+func runPluginConformance(pluginID, serverURL, policyFile, password, junitPath string, smoke bool) error {
+	startTime := time.Now()
+
+	if serverURL == "" {
+		var err error
+		serverURL, err = GetPluginServerURL(pluginID)
+		if err != nil {
+			return err
+		}
+	}
+	serverURL = strings.TrimRight(serverURL, "/")
+
+	fmt.Printf("Conformance of %s at %s\n\n", pluginID, serverURL)
+	run := &conformanceRun{report: ConformanceReport{PluginID: pluginID, ServerURL: serverURL, Cases: []ConformanceCase{}}}
+
+	run.check("healthz", func() (string, string, error) {
+		status, body, err := conformanceRequest(http.MethodGet, serverURL+"/healthz", nil)
+		if err != nil {
+			return "", "", err
+		}
+		if status != http.StatusOK {
+			return "", "", fmt.Errorf("HTTP %d: %s", status, truncate(strings.TrimSpace(string(body)), 200))
+		}
+		return "", conformancePass, nil
+	})
+
+	var spec []byte
+	var configSchema map[string]any
+	var chains []string
+	specStatus := run.check("recipe-specification", func() (string, string, error) {
+		var err error
+		spec, err = fetchServerRecipeSpec(serverURL)
+		if err != nil {
+			return "", "", err
+		}
+		configSchema, chains, err = parseRecipeSpec(spec)
+		if err != nil {
+			return "", "", err
+		}
+		switch id := recipeSpecPluginID(spec); id {
+		case pluginID:
+		case "":
+			return "", "", fmt.Errorf("plugin_id is missing")
+		default:
+			return "", "", fmt.Errorf("plugin_id is %s", id)
+		}
+		return "", conformancePass, nil
+	})
+
+	if specStatus != conformancePass {
+		for _, name := range []string{"recipe-specification/schema", "suggest/valid", "suggest/invalid-empty", "suggest/invalid-type"} {
+			run.skip(name, "no recipe specification")
+		}
+	} else {
+		run.check("recipe-specification/schema", func() (string, string, error) {
+			var problems []string
+			if configSchema == nil {
+				problems = append(problems, "configuration schema is missing")
+			} else if schemaType(configSchema) != "object" {
+				problems = append(problems, "configuration schema is not an object")
+			} else if _, ok := configSchema["properties"].(map[string]any); !ok {
+				problems = append(problems, "configuration schema has no properties")
+			}
+			if len(chains) == 0 {
+				problems = append(problems, "requirements.supported_chains is empty")
+			}
+			if len(problems) > 0 {
+				return "", "", fmt.Errorf("%s", strings.Join(problems, "; "))
+			}
+			return fmt.Sprintf("%d supported chain(s)", len(chains)), conformancePass, nil
+		})
+
+		valid, source, err := conformanceConfig(configSchema, policyFile)
+		if err != nil {
+			run.skip("suggest/valid", err.Error())
+		} else {
+			run.check("suggest/valid", func() (string, string, error) {
+				suggest, err := postConformanceSuggest(serverURL, valid)
+				if err != nil {
+					return "", "", err
+				}
+				if len(suggest.GetRules()) == 0 {
+					return "", "", fmt.Errorf("PolicySuggest has no rules")
+				}
+				for i, rule := range suggest.GetRules() {
+					if rule.GetResource() == "" {
+						return "", "", fmt.Errorf("rule %d has no resource", i)
+					}
+				}
+				return fmt.Sprintf("%d rule(s) for the %s configuration", len(suggest.GetRules()), source), conformancePass, nil
+			})
+		}
+
+		if required, _ := configSchema["required"].([]any); len(required) == 0 {
+			run.skip("suggest/invalid-empty", "the schema requires no fields")
+		} else {
+			run.check("suggest/invalid-empty", func() (string, string, error) {
+				return expectSuggestRejected(serverURL, map[string]any{})
+			})
+		}
+
+		invalid := invalidConformanceConfig(configSchema, valid)
+		if invalid == nil {
+			run.skip("suggest/invalid-type", "no valid configuration to break")
+		} else {
+			run.check("suggest/invalid-type", func() (string, string, error) {
+				return expectSuggestRejected(serverURL, invalid)
+			})
+		}
+	}
+
+	pricing, pricingErr := verifierPluginPricing(pluginID)
+	run.check("pricing/billing", func() (string, string, error) {
+		if pricingErr != nil {
+			return "", "", pricingErr
+		}
+		return checkConformanceBilling(pluginID, pricing)
+	})
+	run.check("pricing/database", func() (string, string, error) {
+		if pricingErr != nil {
+			return "", "", pricingErr
+		}
+		return checkConformancePricingRows(pluginID, pricing)
+	})
+
+	if smoke {
+		runConformanceSmoke(run, pluginID, policyFile, password)
+	}
+
+	run.report.DurationMs = time.Since(startTime).Milliseconds()
+
+	if junitPath != "" {
+		err := writeJUnitReport(junitPath, run.report)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := printResult(run.report, func() {
+		fmt.Println()
+		fmt.Println("┌──────────────────────────────┬────────┬──────────────────────────────────────────────┐")
+		fmt.Println("│ Case                         │ Result │ Detail                                       │")
+		fmt.Println("├──────────────────────────────┼────────┼──────────────────────────────────────────────┤")
+		for _, c := range run.report.Cases {
+			fmt.Printf("│ %-28s │ %-6s │ %-44s │\n", truncate(c.Name, 28), c.Status, truncate(c.Message, 44))
+		}
+		fmt.Println("└──────────────────────────────┴────────┴──────────────────────────────────────────────┘")
+		fmt.Printf("\n%d passed, %d failed, %d skipped in %v\n",
+			run.report.Passed, run.report.Failed, run.report.Skipped, time.Duration(run.report.DurationMs)*time.Millisecond)
+		if junitPath != "" {
+			fmt.Printf("JUnit report: %s\n", junitPath)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if run.report.Failed > 0 {
+		return &ExitError{Code: 1, Err: fmt.Errorf("%s failed %d conformance check(s)", pluginID, run.report.Failed)}
+	}
+	return nil
+}
+
+// conformanceConfig returns the valid configuration for the suggest checks
+// and where it came from: the recipe of policyFile, or one built from the
+// schema that validates against it.
+func conformanceConfig(configSchema map[string]any, policyFile string) (map[string]any, string, error) {
+	if policyFile != "" {
+		vault, err := ActiveVault()
+		if err == nil {
+			pf, err := loadPolicyFile(policyFile, vault, nil)
+			if err != nil {
+				return nil, "", err
+			}
+			return pf.recipe, "--policy-file", nil
+		}
+		// No vault to fill addresses from; use the recipe as written
+		data, err := os.ReadFile(policyFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("read policy file: %w", err)
+		}
+		var doc struct {
+			Recipe map[string]any `json:"recipe"`
+		}
+		err = json.Unmarshal(data, &doc)
+		if err != nil || doc.Recipe == nil {
+			return nil, "", fmt.Errorf("%s has no recipe object", policyFile)
+		}
+		return doc.Recipe, "--policy-file", nil
+	}
+
+	example, _ := schemaExample(configSchema, configSchema).(map[string]any)
+	if example == nil {
+		return nil, "", fmt.Errorf("pass --policy-file: the schema has no example configuration")
+	}
+	violations := schema.Validate(configSchema, example, "$")
+	if len(violations) > 0 {
+		return nil, "", fmt.Errorf("pass --policy-file: the schema's example configuration is invalid (%s)", violations[0])
+	}
+	return example, "schema example", nil
+}
+
+// schemaExample builds a value for s from its default, const, first enum
+// value or first example, recursing into required object properties and
+// falling back to nil where the schema gives no hint.
+func schemaExample(root, s map[string]any) any {
+	s, err := schema.Deref(root, s)
+	if err != nil || s == nil {
+		return nil
+	}
+	for _, key := range []string{"default", "const"} {
+		if v, ok := s[key]; ok {
+			return v
+		}
+	}
+	for _, key := range []string{"enum", "examples"} {
+		if list, ok := s[key].([]any); ok && len(list) > 0 {
+			return list[0]
+		}
+	}
+	if options, ok := s["oneOf"].([]any); ok && len(options) > 0 {
+		option, _ := options[0].(map[string]any)
+		return schemaExample(root, option)
+	}
+
+	switch schemaType(s) {
+	case "object":
+		obj := map[string]any{}
+		props, _ := s["properties"].(map[string]any)
+		required, _ := s["required"].([]any)
+		for _, r := range required {
+			name, _ := r.(string)
+			prop, _ := props[name].(map[string]any)
+			if v := schemaExample(root, prop); v != nil {
+				obj[name] = v
+			}
+		}
+		return obj
+	case "array":
+		items, _ := s["items"].(map[string]any)
+		if v := schemaExample(root, items); v != nil {
+			return []any{v}
+		}
+		return []any{}
+	case "boolean":
+		return false
+	}
+	return nil
+}
+
+// invalidConformanceConfig copies valid with its first required property
+// set to a value of the wrong type, or returns nil if that still validates.
+func invalidConformanceConfig(configSchema, valid map[string]any) map[string]any {
+	if valid == nil {
+		return nil
+	}
+	required, _ := configSchema["required"].([]any)
+	props, _ := configSchema["properties"].(map[string]any)
+	for _, r := range required {
+		name, _ := r.(string)
+		prop, _ := props[name].(map[string]any)
+		prop, _ = schema.Deref(configSchema, prop)
+
+		var wrong any = "not-a-" + schemaType(prop)
+		if schemaType(prop) == "string" {
+			wrong = 12345
+		}
+		invalid := make(map[string]any, len(valid))
+		for k, v := range valid {
+			invalid[k] = v
+		}
+		invalid[name] = wrong
+		if len(schema.Validate(configSchema, invalid, "$")) > 0 {
+			return invalid
+		}
+	}
+	return nil
+}
+
+// expectSuggestRejected posts an invalid configuration and passes if the
+// plugin answers with a 4xx.
+func expectSuggestRejected(serverURL string, configuration map[string]any) (string, string, error) {
+	body, _ := json.Marshal(map[string]any{"configuration": configuration})
+	status, resp, err := conformanceRequest(http.MethodPost, serverURL+"/plugin/recipe-specification/suggest", body)
+	if err != nil {
+		return "", "", err
+	}
+	switch {
+	case status >= 400 && status < 500:
+		return fmt.Sprintf("rejected with HTTP %d", status), conformancePass, nil
+	case status == http.StatusOK:
+		return "", "", fmt.Errorf("accepted an invalid configuration")
+	default:
+		return "", "", fmt.Errorf("want a 4xx, got HTTP %d: %s", status, truncate(strings.TrimSpace(string(resp)), 200))
+	}
+}
+
+// postConformanceSuggest posts a configuration and decodes the answer as a
+// PolicySuggest, rejecting unknown fields.
+func postConformanceSuggest(serverURL string, configuration map[string]any) (*rtypes.PolicySuggest, error) {
+	body, err := json.Marshal(map[string]any{"configuration": configuration})
+	if err != nil {
+		return nil, fmt.Errorf("marshal configuration: %w", err)
+	}
+	status, resp, err := conformanceRequest(http.MethodPost, serverURL+"/plugin/recipe-specification/suggest", body)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d: %s", status, truncate(strings.TrimSpace(string(resp)), 200))
+	}
+	suggest := &rtypes.PolicySuggest{}
+	err = protojson.Unmarshal(resp, suggest)
+	if err != nil {
+		return nil, fmt.Errorf("response is not a PolicySuggest: %w", err)
+	}
+	return suggest, nil
+}
+
+func conformanceRequest(method, url string, body []byte) (int, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, fmt.Errorf("create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("read response: %w", err)
+	}
+	return resp.StatusCode, data, nil
+}
+
+// verifierPluginPricing returns the plugin's pricing as the verifier's
+// /plugins lists it.
+func verifierPluginPricing(pluginID string) ([]verifier.Pricing, error) {
+	client, err := newVerifierClient(nil)
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.ListPlugins(context.Background(), verifier.Page{})
+	if err != nil {
+		return nil, fmt.Errorf("list plugins: %w", err)
+	}
+	idx := slices.IndexFunc(list.Plugins, func(p verifier.Plugin) bool { return p.ID == pluginID })
+	if idx < 0 {
+		return nil, fmt.Errorf("the verifier's /plugins does not list %s (run 'vcli plugin register')", pluginID)
+	}
+	pricing := list.Plugins[idx].Pricing
+	if len(pricing) == 0 {
+		return nil, fmt.Errorf("no pricing; the verifier rejects policies without it")
+	}
+	return pricing, nil
+}
+
+// checkConformanceBilling checks the plugin's /plugins pricing: every entry
+// must have a known type and frequency, and the billing fetchPluginBilling
+// builds from it must pass policy validation and convert to one fee policy
+// per entry, as policy add does.
+func checkConformanceBilling(pluginID string, pricing []verifier.Pricing) (string, string, error) {
+	var problems []string
+	for i, p := range pricing {
+		freq := derefOr(p.Frequency, "")
+		switch p.Type {
+		case "once", "per-tx":
+			if freq != "" {
+				problems = append(problems, fmt.Sprintf("pricing[%d]: %s with frequency %s", i, p.Type, freq))
+			}
+		case "recurring":
+			if !slices.Contains([]string{"daily", "weekly", "biweekly", "monthly"}, freq) {
+				problems = append(problems, fmt.Sprintf("pricing[%d]: recurring with frequency %q", i, freq))
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("pricing[%d]: unknown type %q", i, p.Type))
+		}
+	}
+
+	billing, err := fetchPluginBilling(pluginID)
+	if err != nil {
+		return "", "", err
+	}
+	// Policy files carry billing as JSON; compare in that form
+	data, err := json.Marshal(billing)
+	if err != nil {
+		return "", "", fmt.Errorf("marshal billing: %w", err)
+	}
+	var billingDoc any
+	err = json.Unmarshal(data, &billingDoc)
+	if err != nil {
+		return "", "", fmt.Errorf("normalise billing: %w", err)
+	}
+	for _, v := range validateBilling(billingDoc, pricing) {
+		problems = append(problems, v.String())
+	}
+	fees, err := buildFeePolicies(billingDoc)
+	if err != nil {
+		problems = append(problems, err.Error())
+	} else if len(fees) != len(pricing) {
+		problems = append(problems, fmt.Sprintf("%d fee policies for %d pricing entries", len(fees), len(pricing)))
+	}
+
+	if len(problems) > 0 {
+		return "", "", fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return pricingSummary(pricing), conformancePass, nil
+}
+
+// checkConformancePricingRows compares the plugin's /plugins pricing with its
+// rows in the verifier's pricings table, which 'plugin register' writes.
+func checkConformancePricingRows(pluginID string, pricing []verifier.Pricing) (string, string, error) {
+	var row *devdb.Plugin
+	err := withDB(openVerifierDB, func(ctx context.Context, db *devdb.DB) error {
+		var err error
+		row, err = db.Plugin(ctx, pluginID)
+		return err
+	})
+	if errors.Is(err, devdb.ErrNotFound) {
+		return "", "", fmt.Errorf("the verifier database has no plugins row for %s", pluginID)
+	}
+	if err != nil {
+		return "verifier database unreachable: " + err.Error(), conformanceSkip, nil
+	}
+
+	served := make([]string, len(pricing))
+	for i, p := range pricing {
+		served[i] = pricingKey(p.Type, derefOr(p.Frequency, ""), p.Amount, p.Asset, p.Metric)
+	}
+	stored := make([]string, len(row.Pricing))
+	for i, p := range row.Pricing {
+		stored[i] = pricingKey(p.Type, p.Frequency, p.Amount, p.Asset, p.Metric)
+	}
+	slices.Sort(served)
+	slices.Sort(stored)
+	if slices.Equal(served, stored) {
+		return fmt.Sprintf("%d entries match the pricings table", len(stored)), conformancePass, nil
+	}
+
+	var problems []string
+	for _, k := range served {
+		if !slices.Contains(stored, k) {
+			problems = append(problems, "served but not stored: "+k)
+		}
+	}
+	for _, k := range stored {
+		if !slices.Contains(served, k) {
+			problems = append(problems, "stored but not served: "+k)
+		}
+	}
+	if len(problems) == 0 {
+		problems = append(problems, fmt.Sprintf("%d entries served, %d stored", len(served), len(stored)))
+	}
+	return "", "", fmt.Errorf("%s", strings.Join(problems, "; "))
+}
+
+// pricingKey renders one pricing entry for comparison; the metric defaults
+// to fixed as in the pricings table.
+func pricingKey(typ, frequency string, amount uint64, asset, metric string) string {
+	if metric == "" {
+		metric = "fixed"
+	}
+	if frequency == "" {
+		frequency = "-"
+	}
+	return fmt.Sprintf("%s/%s %d %s (%s)", typ, frequency, amount, strings.ToLower(asset), metric)
+}
+
+// runConformanceSmoke installs the plugin if needed, then adds the policy
+// file's policy, checks that the verifier has it active and deletes it.
+func runConformanceSmoke(run *conformanceRun, pluginID, policyPath, password string) {
+	rememberVaultPassword(password)
+	vault, err := ActiveVault()
+	if err != nil {
+		for _, name := range []string{"smoke/install", "smoke/policy-add", "smoke/policy-active", "smoke/policy-delete"} {
+			run.skip(name, err.Error())
+		}
+		return
+	}
+
+	installStatus := run.check("smoke/install", func() (string, string, error) {
+		installed, err := checkPluginInstallation(pluginID, vault.PublicKeyECDSA)
+		if err != nil {
+			return "", "", err
+		}
+		if installed != "" {
+			return "already installed " + installed, conformancePass, nil
+		}
+		err = runPluginInstall(pluginID, password)
+		if err != nil {
+			return "", "", err
+		}
+		installed, err = checkPluginInstallation(pluginID, vault.PublicKeyECDSA)
+		if err != nil {
+			return "", "", err
+		}
+		if installed == "" {
+			return "", "", fmt.Errorf("reshare finished but plugin_installations has no record")
+		}
+		return "installed", conformancePass, nil
+	})
+	if installStatus != conformancePass {
+		for _, name := range []string{"smoke/policy-add", "smoke/policy-active", "smoke/policy-delete"} {
+			run.skip(name, "plugin not installed")
+		}
+		return
+	}
+
+	var policyID string
+	var pf *policyFile
+	addStatus := run.check("smoke/policy-add", func() (string, string, error) {
+		err := requireKeyshares(vault)
+		if err != nil {
+			return "", "", err
+		}
+		_, err = ensureAuthHeader(vault)
+		if err != nil {
+			return "", "", fmt.Errorf("authentication required: %w", err)
+		}
+		pf, err = loadPolicyFile(policyPath, vault, nil)
+		if err != nil {
+			return "", "", err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+		defer cancel()
+		created, rules, err := createPolicy(ctx, vault, pluginID, pf, password)
+		if err != nil {
+			return "", "", err
+		}
+		policyID = created.ID
+		return fmt.Sprintf("%s (%d rules)", policyID, rules), conformancePass, nil
+	})
+	if addStatus != conformancePass {
+		for _, name := range []string{"smoke/policy-active", "smoke/policy-delete"} {
+			run.skip(name, "no policy")
+		}
+		return
+	}
+
+	run.check("smoke/policy-active", func() (string, string, error) {
+		client, err := newVerifierClient(vault)
+		if err != nil {
+			return "", "", err
+		}
+		policy, err := client.GetPolicy(context.Background(), policyID)
+		if err != nil {
+			return "", "", fmt.Errorf("get policy: %w", err)
+		}
+		if !policy.Active {
+			return "", "", fmt.Errorf("policy %s is not active", policyID)
+		}
+		return "", conformancePass, nil
+	})
+
+	run.check("smoke/policy-delete", func() (string, string, error) {
+		err := runPolicyDelete(policyID, password)
+		if err != nil {
+			return "", "", err
+		}
+		return "", conformancePass, nil
+	})
+}
+
+type junitTestSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+func writeJUnitReport(path string, report ConformanceReport) error {
+	seconds := func(ms int64) string { return fmt.Sprintf("%.3f", float64(ms)/1000) }
+	suite := junitTestSuite{
+		Name:     "plugin-conformance/" + report.PluginID,
+		Tests:    len(report.Cases),
+		Failures: report.Failed,
+		Skipped:  report.Skipped,
+		Time:     seconds(report.DurationMs),
+	}
+	for _, c := range report.Cases {
+		tc := junitTestCase{Name: c.Name, ClassName: report.PluginID, Time: seconds(c.DurationMs)}
+		switch c.Status {
+		case conformanceFail:
+			tc.Failure = &junitMessage{Message: c.Message}
+		case conformanceSkip:
+			tc.Skipped = &junitMessage{Message: c.Message}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal JUnit report: %w", err)
+	}
+	err = os.WriteFile(path, append([]byte(xml.Header), append(data, '\n')...), 0644)
+	if err != nil {
+		return fmt.Errorf("write JUnit report: %w", err)
+	}
+	return nil
+}
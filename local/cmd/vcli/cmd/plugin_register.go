@@ -0,0 +1,453 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vultisig/vcli/local/pkg/devdb"
+)
+
+// pluginManifest is a plugin entry of a registration file, in the format of
+// proposed_local.yaml plus pricing and an optional plugin API key.
+type pluginManifest struct {
+	ID             string                  `yaml:"id"`
+	Title          string                  `yaml:"title"`
+	Description    string                  `yaml:"description"`
+	ServerEndpoint string                  `yaml:"server_endpoint"`
+	Category       string                  `yaml:"category"`
+	LogoURL        string                  `yaml:"logo_url"`
+	ThumbnailURL   string                  `yaml:"thumbnail_url"`
+	Audited        bool                    `yaml:"audited"`
+	Features       []string                `yaml:"features"`
+	FAQs           []pluginManifestFAQ     `yaml:"faqs"`
+	Pricing        []pluginManifestPricing `yaml:"pricing"`
+	APIKey         string                  `yaml:"apikey"`
+}
+
+type pluginManifestFAQ struct {
+	Question string `yaml:"question"`
+	Answer   string `yaml:"answer"`
+}
+
+type pluginManifestPricing struct {
+	Type      string `yaml:"type"`
+	Frequency string `yaml:"frequency"`
+	Amount    uint64 `yaml:"amount"`
+	Asset     string `yaml:"asset"`
+	Metric    string `yaml:"metric"`
+}
+
+// PluginRegistration is the outcome of registering one plugin. Action is
+// created, updated or unchanged.
+type PluginRegistration struct {
+	PluginID      string `json:"plugin_id"`
+	Action        string `json:"action"`
+	Diff          string `json:"diff,omitempty"`
+	EndpointCheck string `json:"endpoint_check"`
+}
+
+type PluginRegisterResult struct {
+	File       string               `json:"file"`
+	DryRun     bool                 `json:"dry_run"`
+	Plugins    []PluginRegistration `json:"plugins"`
+	DurationMs int64                `json:"duration_ms"`
+}
+
+func newPluginRegisterCmd() *cobra.Command {
+	var file string
+	var dryRun, skipCheck bool
+
+	cmd := &cobra.Command{
+		Use:   "register",
+		Short: "Add or update plugins in the local verifier's marketplace",
+		Long: `Upsert plugins rows in the verifier database from a YAML file, so a new
+plugin shows up in the local marketplace without editing seed-plugins.sql.
+
+The file holds one plugin, or a "plugins:" list as in proposed_local.yaml:
+
+  id: my-plugin-0000
+  title: My Plugin
+  description: What it does
+  server_endpoint: http://localhost:8090
+  category: app
+  features: [Scheduled swaps]
+  faqs:
+    - question: What is it?
+      answer: An example.
+  pricing:                     # replaces the plugin's pricings; omit to keep them
+    - type: once               # once, per-tx or recurring
+      amount: 0
+    - type: recurring
+      frequency: monthly       # daily, weekly, biweekly or monthly
+      amount: 1000000
+  apikey: local-dev-my-apikey  # optional plugin_apikey entry
+
+Before writing, each server_endpoint must answer GET /plugin/recipe-specification
+with a recipe schema, and the changes against the current row are shown as a
+diff. Registering the same file again changes nothing.
+`,
+		Example: `  vcli plugin register --file plugin.yaml
+  vcli plugin register --file proposed_local.yaml --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return runPluginRegister(file, dryRun, skipCheck)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Plugin YAML file (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the diff without writing to the database")
+	cmd.Flags().BoolVar(&skipCheck, "skip-check", false, "Don't require the server endpoint to answer its recipe specification")
+	cmd.MarkFlagRequired("file")
+
+	return withStructuredOutput(cmd)
+}
+
+func newPluginUnregisterCmd() *cobra.Command {
+	return withStructuredOutput(&cobra.Command{
+		Use:   "unregister [plugin-id]",
+		Short: "Remove a plugin, its pricings and API keys from the verifier database",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return runPluginUnregister(ResolvePluginID(args[0]))
+		},
+	})
+}
+
+func runPluginRegister(file string, dryRun, skipCheck bool) error {
+	startTime := time.Now()
+
+	manifests, err := loadPluginManifests(file)
+	if err != nil {
+		return err
+	}
+
+	result := PluginRegisterResult{File: file, DryRun: dryRun, Plugins: []PluginRegistration{}}
+	changed := false
+	for _, m := range manifests {
+		fmt.Printf("Registering %s (%s)...\n", m.ID, m.ServerEndpoint)
+		reg := PluginRegistration{PluginID: m.ID, EndpointCheck: "skipped"}
+
+		if !skipCheck {
+			reg.EndpointCheck, err = checkRecipeSpecEndpoint(m.ServerEndpoint, m.ID)
+			if err != nil {
+				return fmt.Errorf("%s: %w\n\nStart the plugin server first, or pass --skip-check", m.ID, err)
+			}
+			fmt.Printf("  ✓ Recipe specification: %s\n", reg.EndpointCheck)
+		}
+
+		next := m.row()
+		err = withDB(openVerifierDB, func(ctx context.Context, db *devdb.DB) error {
+			current, err := db.Plugin(ctx, m.ID)
+			if errors.Is(err, devdb.ErrNotFound) {
+				current = nil
+			} else if err != nil {
+				return err
+			}
+			if current != nil && next.Pricing == nil {
+				next.Pricing = current.Pricing
+			}
+
+			reg.Diff, err = pluginRowDiff(current, next)
+			if err != nil {
+				return err
+			}
+			switch {
+			case current == nil:
+				reg.Action = "created"
+			case reg.Diff != "":
+				reg.Action = "updated"
+			default:
+				reg.Action = "unchanged"
+			}
+			if dryRun || (reg.Action == "unchanged" && m.APIKey == "") {
+				return nil
+			}
+			return db.UpsertPlugin(ctx, next, m.APIKey)
+		})
+		if err != nil {
+			return fmt.Errorf("register %s: %w", m.ID, err)
+		}
+
+		if reg.Diff != "" {
+			fmt.Println()
+			fmt.Print(reg.Diff)
+			fmt.Println()
+		}
+		if len(next.Pricing) == 0 {
+			fmt.Printf("  ⚠ %s has no pricing; the verifier rejects its policies until it does\n", m.ID)
+		}
+		changed = changed || reg.Action != "unchanged"
+		result.Plugins = append(result.Plugins, reg)
+	}
+	result.DurationMs = time.Since(startTime).Milliseconds()
+
+	if changed && !dryRun {
+		// Best effort: pick the new entries up in aliases, spec, policy add
+		_ = loadPluginRegistry(pluginSyncAlways)
+	}
+
+	return printResult(result, func() {
+		fmt.Println()
+		fmt.Println("┌──────────────────────────────────┬───────────┬──────────────────────────────┐")
+		fmt.Println("│ Plugin ID                        │ Action    │ Recipe Specification         │")
+		fmt.Println("├──────────────────────────────────┼───────────┼──────────────────────────────┤")
+		for _, reg := range result.Plugins {
+			fmt.Printf("│ %-32s │ %-9s │ %-28s │\n", truncate(reg.PluginID, 32), reg.Action, truncate(reg.EndpointCheck, 28))
+		}
+		fmt.Println("└──────────────────────────────────┴───────────┴──────────────────────────────┘")
+		if dryRun {
+			fmt.Println("\nDry run: verifier database not changed.")
+			return
+		}
+		fmt.Println()
+		fmt.Println("Next: vcli plugin list")
+		fmt.Println("      vcli plugin install <plugin-id> --password <password>")
+	})
+}
+
+// loadPluginManifests reads a registration file holding one plugin or a
+// "plugins:" list, validates it and fills in defaults.
+func loadPluginManifests(path string) ([]pluginManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read plugin file: %w", err)
+	}
+	var doc struct {
+		Plugins []pluginManifest `yaml:"plugins"`
+	}
+	err = yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("parse plugin file: %w", err)
+	}
+	manifests := doc.Plugins
+	if len(manifests) == 0 {
+		var single pluginManifest
+		err = yaml.Unmarshal(data, &single)
+		if err != nil {
+			return nil, fmt.Errorf("parse plugin file: %w", err)
+		}
+		manifests = []pluginManifest{single}
+	}
+
+	for i := range manifests {
+		m := &manifests[i]
+		if m.ID == "" || m.Title == "" || m.ServerEndpoint == "" {
+			return nil, fmt.Errorf("plugin file: entry %d needs id, title and server_endpoint", i+1)
+		}
+		m.ServerEndpoint = strings.TrimRight(m.ServerEndpoint, "/")
+		if m.Category == "" {
+			m.Category = "app"
+		}
+		for j := range m.Pricing {
+			p := &m.Pricing[j]
+			switch p.Type {
+			case "once", "per-tx":
+				if p.Frequency != "" {
+					return nil, fmt.Errorf("plugin file: %s pricing %d: %s pricing takes no frequency", m.ID, j+1, p.Type)
+				}
+			case "recurring":
+				if !slices.Contains([]string{"daily", "weekly", "biweekly", "monthly"}, p.Frequency) {
+					return nil, fmt.Errorf("plugin file: %s pricing %d: recurring frequency must be daily, weekly, biweekly or monthly", m.ID, j+1)
+				}
+			default:
+				return nil, fmt.Errorf("plugin file: %s pricing %d: type must be once, per-tx or recurring, got %q", m.ID, j+1, p.Type)
+			}
+			if p.Asset == "" {
+				p.Asset = "usdc"
+			}
+			if p.Metric == "" {
+				p.Metric = "fixed"
+			}
+		}
+	}
+	return manifests, nil
+}
+
+// row converts the manifest to a plugins row; Pricing stays nil when the
+// file has no pricing key, which keeps the current pricings.
+func (m pluginManifest) row() devdb.Plugin {
+	p := devdb.Plugin{
+		ID:             m.ID,
+		Title:          m.Title,
+		Description:    m.Description,
+		ServerEndpoint: m.ServerEndpoint,
+		Category:       m.Category,
+		LogoURL:        m.LogoURL,
+		ThumbnailURL:   m.ThumbnailURL,
+		Features:       m.Features,
+		Audited:        m.Audited,
+	}
+	for _, f := range m.FAQs {
+		p.FAQs = append(p.FAQs, devdb.FAQ{Question: f.Question, Answer: f.Answer})
+	}
+	if m.Pricing != nil {
+		p.Pricing = []devdb.Pricing{}
+		for _, pr := range m.Pricing {
+			p.Pricing = append(p.Pricing, devdb.Pricing(pr))
+		}
+	}
+	return p
+}
+
+// checkRecipeSpecEndpoint fetches the plugin server's recipe specification
+// and checks that it parses and belongs to pluginID.
+func checkRecipeSpecEndpoint(serverEndpoint, pluginID string) (string, error) {
+	body, err := fetchServerRecipeSpec(serverEndpoint)
+	if err != nil {
+		return "", err
+	}
+	_, chains, err := parseRecipeSpec(body)
+	if err != nil {
+		return "", err
+	}
+	if id := recipeSpecPluginID(body); id != "" && id != pluginID {
+		return "", fmt.Errorf("%s serves the recipe specification of %s", serverEndpoint, id)
+	}
+	return fmt.Sprintf("ok, %d supported chain(s)", len(chains)), nil
+}
+
+// fetchServerRecipeSpec reads GET /plugin/recipe-specification straight from
+// a plugin server, bypassing the verifier.
+func fetchServerRecipeSpec(serverEndpoint string) ([]byte, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	url := serverEndpoint + "/plugin/recipe-specification"
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("recipe specification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read recipe specification: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: HTTP %d: %s", url, resp.StatusCode, truncate(strings.TrimSpace(string(body)), 200))
+	}
+	return body, nil
+}
+
+// recipeSpecPluginID returns the plugin ID a recipe specification declares,
+// under its proto name or protojson's camelCase one.
+func recipeSpecPluginID(spec []byte) string {
+	var ids struct {
+		PluginID      string `json:"plugin_id"`
+		PluginIDCamel string `json:"pluginId"`
+	}
+	_ = json.Unmarshal(spec, &ids)
+	if ids.PluginID != "" {
+		return ids.PluginID
+	}
+	return ids.PluginIDCamel
+}
+
+// pluginRowDiff renders current and next as indented JSON and returns their
+// unified diff, or "" if they match. current is nil for a new plugin.
+func pluginRowDiff(current *devdb.Plugin, next devdb.Plugin) (string, error) {
+	before := ""
+	from := "current (not registered)"
+	if current != nil {
+		doc, err := pluginRowDocument(*current)
+		if err != nil {
+			return "", err
+		}
+		before, from = doc, "current"
+	}
+	after, err := pluginRowDocument(next)
+	if err != nil {
+		return "", err
+	}
+	if before == after {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(before),
+		B:        difflib.SplitLines(after),
+		FromFile: from,
+		ToFile:   "new",
+		Context:  3,
+	})
+}
+
+func pluginRowDocument(p devdb.Plugin) (string, error) {
+	type pricing struct {
+		Type      string `json:"type"`
+		Frequency string `json:"frequency,omitempty"`
+		Amount    uint64 `json:"amount"`
+		Asset     string `json:"asset"`
+		Metric    string `json:"metric"`
+	}
+	prices := []pricing{}
+	for _, pr := range p.Pricing {
+		prices = append(prices, pricing(pr))
+	}
+	// Stored pricings come back sorted; compare them in that order
+	slices.SortFunc(prices, func(a, b pricing) int {
+		return strings.Compare(a.Type+"/"+a.Frequency, b.Type+"/"+b.Frequency)
+	})
+
+	doc, err := json.MarshalIndent(map[string]any{
+		"title":           p.Title,
+		"description":     p.Description,
+		"server_endpoint": p.ServerEndpoint,
+		"category":        p.Category,
+		"logo_url":        p.LogoURL,
+		"thumbnail_url":   p.ThumbnailURL,
+		"audited":         p.Audited,
+		"features":        devdb.NonNil(p.Features),
+		"faqs":            devdb.NonNil(p.FAQs),
+		"pricing":         prices,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(doc) + "\n", nil
+}
+
+type PluginUnregisterResult struct {
+	PluginID string `json:"plugin_id"`
+	Removed  bool   `json:"removed"`
+}
+
+func runPluginUnregister(pluginID string) error {
+	result := PluginUnregisterResult{PluginID: pluginID}
+	err := withDB(openVerifierDB, func(ctx context.Context, db *devdb.DB) error {
+		n, err := db.DeletePlugin(ctx, pluginID)
+		if err != nil {
+			return err
+		}
+		result.Removed = n > 0
+		return nil
+	})
+	if errors.Is(err, devdb.ErrInUse) {
+		return fmt.Errorf("unregister %s: %w\n\nUninstall the plugin and delete its policies first", pluginID, err)
+	}
+	if err != nil {
+		return fmt.Errorf("unregister %s: %w", pluginID, err)
+	}
+	if result.Removed {
+		_ = loadPluginRegistry(pluginSyncAlways)
+	}
+
+	return printResult(result, func() {
+		if !result.Removed {
+			fmt.Printf("Plugin %s is not registered in the verifier database.\n", pluginID)
+			return
+		}
+		fmt.Printf("✓ Removed plugin %s with its pricings and API keys.\n", pluginID)
+	})
+}
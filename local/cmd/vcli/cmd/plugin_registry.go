@@ -0,0 +1,422 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
+)
+
+// Where a registry entry, alias or server URL came from.
+const (
+	pluginSourceBuiltin  = "builtin"
+	pluginSourceVerifier = "verifier"
+	pluginSourceConfig   = "config"
+)
+
+// pluginCacheTTL is how long the plugin list synced from the verifier is used
+// before it is fetched again.
+const pluginCacheTTL = 10 * time.Minute
+
+// PluginInfo is a plugin known to vcli. Source is where the entry came from
+// (builtin, verifier or config), ServerURLSource where its server URL came
+// from. ConfigAliases are the user-defined subset of Aliases.
+type PluginInfo struct {
+	ID              string           `json:"id"`
+	Aliases         []string         `json:"aliases,omitempty"`
+	ConfigAliases   []string         `json:"config_aliases,omitempty"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description,omitempty"`
+	ServerURL       string           `json:"server_url,omitempty"`
+	Source          string           `json:"source"`
+	ServerURLSource string           `json:"server_url_source,omitempty"`
+	Descriptor      PluginDescriptor `json:"descriptor"`
+}
+
+// PluginDescriptor says how a plugin runs in the local stack. Bucket is the
+// MinIO bucket its worker stores keyshares in, PartyPrefix the TSS party ID
+// prefix of that worker, Database the plugin's Postgres database (on the
+// verifier's server unless DatabaseDSN is set). Services are its local
+// processes, API server first, with pid and log files in /tmp. Empty
+// fields are skipped by the checks that use them; HealthURL defaults to the
+// server URL's /healthz.
+type PluginDescriptor struct {
+	Label       string   `json:"label,omitempty"`
+	Bucket      string   `json:"bucket,omitempty"`
+	PartyPrefix string   `json:"party_prefix,omitempty"`
+	Database    string   `json:"database,omitempty"`
+	DatabaseDSN string   `json:"database_dsn,omitempty"`
+	Services    []string `json:"services,omitempty"`
+	HealthURL   string   `json:"health_url,omitempty"`
+}
+
+// merge overrides d's fields with the non-empty fields of o.
+func (d *PluginDescriptor) merge(o PluginDescriptor) {
+	for _, f := range []struct{ dst, src *string }{
+		{&d.Label, &o.Label},
+		{&d.Bucket, &o.Bucket},
+		{&d.PartyPrefix, &o.PartyPrefix},
+		{&d.Database, &o.Database},
+		{&d.DatabaseDSN, &o.DatabaseDSN},
+		{&d.HealthURL, &o.HealthURL},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if len(o.Services) > 0 {
+		d.Services = o.Services
+	}
+}
+
+// builtinPlugins are the Vultisig plugins of the local stack. They work
+// before the first sync with the verifier, which can rename them and move
+// their server.
+var builtinPlugins = []PluginInfo{
+	{
+		ID:          "vultisig-dca-0000",
+		Aliases:     []string{"dca"},
+		Name:        "Recurring Swaps (DCA)",
+		Description: "Dollar-cost averaging with automated token swaps",
+		ServerURL:   "http://localhost:8082",
+		Descriptor: PluginDescriptor{
+			Label:       "DCA Plugin",
+			Bucket:      "vultisig-dca",
+			PartyPrefix: "dca-worker",
+			Database:    "vultisig-dca",
+			Services:    []string{"dca", "dca-worker"},
+		},
+	},
+	{
+		ID:          "vultisig-fees-feee",
+		Aliases:     []string{"fee", "fees"},
+		Name:        "Vultisig Fees",
+		Description: "Fee collection plugin",
+		ServerURL:   "http://localhost:8085",
+		Descriptor: PluginDescriptor{
+			Label:       "Fees Plugin",
+			Bucket:      "vultisig-fee",
+			PartyPrefix: "fee-worker",
+			Database:    "vultisig-fee",
+			Services:    []string{"fee", "fee-worker"},
+		},
+	},
+	{
+		ID:          "vultisig-recurring-sends-0000",
+		Aliases:     []string{"sends"},
+		Name:        "Recurring Sends",
+		Description: "Automated recurring token transfers",
+		ServerURL:   "http://localhost:8083",
+		// Served by app-recurring in send mode, sharing the DCA worker
+		Descriptor: PluginDescriptor{
+			Label:       "Sends Plugin",
+			Bucket:      "vultisig-dca",
+			PartyPrefix: "dca-worker",
+			Database:    "vultisig-dca",
+			Services:    []string{"sends", "dca-worker"},
+		},
+	},
+}
+
+// pluginCache is the plugin list last fetched from a verifier, stored in
+// ~/.vultisig/plugins.json.
+type pluginCache struct {
+	VerifierURL string            `json:"verifier_url"`
+	SyncedAt    time.Time         `json:"synced_at"`
+	Plugins     []verifier.Plugin `json:"plugins"`
+}
+
+func PluginCachePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "plugins.json")
+}
+
+func loadPluginCache() (*pluginCache, error) {
+	data, err := os.ReadFile(PluginCachePath())
+	if err != nil {
+		return nil, err
+	}
+	var cache pluginCache
+	err = json.Unmarshal(data, &cache)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", PluginCachePath(), err)
+	}
+	return &cache, nil
+}
+
+func savePluginCache(verifierURL string, plugins []verifier.Plugin) error {
+	path := PluginCachePath()
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+	data, err := json.MarshalIndent(pluginCache{
+		VerifierURL: verifierURL,
+		SyncedAt:    time.Now().UTC(),
+		Plugins:     plugins,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal plugin cache: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// syncPlugins fetches the verifier's plugin list into the cache.
+func syncPlugins(cfg *DevConfig) (*pluginCache, error) {
+	client := verifier.New(cfg.Verifier, verifier.WithTimeout(5*time.Second), verifier.WithRetries(0, 0))
+	list, err := client.ListPlugins(context.Background(), verifier.Page{})
+	if err != nil {
+		return nil, fmt.Errorf("list plugins: %w", err)
+	}
+	err = savePluginCache(client.BaseURL(), list.Plugins)
+	if err != nil {
+		return nil, err
+	}
+	return loadPluginCache()
+}
+
+// pluginRegistry merges, in increasing precedence, the builtin plugins, the
+// verifier's plugin list and the user's config.
+type pluginRegistry struct {
+	plugins []*PluginInfo
+	aliases map[string]string // alias -> plugin ID
+	cache   *pluginCache      // nil before the first sync
+	syncErr error             // set when a sync was attempted and failed
+}
+
+// Sync modes of loadPluginRegistry.
+const (
+	pluginSyncNever = iota
+	pluginSyncIfStale
+	pluginSyncAlways
+)
+
+func loadPluginRegistry(sync int) *pluginRegistry {
+	cfg, err := LoadConfig()
+	if err != nil {
+		cfg = DefaultConfig()
+	}
+
+	r := &pluginRegistry{aliases: map[string]string{}}
+	r.cache, _ = loadPluginCache()
+	if r.cache != nil && r.cache.VerifierURL != strings.TrimRight(cfg.Verifier, "/") {
+		r.cache = nil
+	}
+
+	stale := r.cache == nil || time.Since(r.cache.SyncedAt) > pluginCacheTTL
+	if sync == pluginSyncAlways || (sync == pluginSyncIfStale && stale) {
+		cache, err := syncPlugins(cfg)
+		if err != nil {
+			r.syncErr = err
+		} else {
+			r.cache = cache
+		}
+	}
+
+	for _, p := range builtinPlugins {
+		entry := p
+		entry.Aliases = append([]string(nil), p.Aliases...)
+		entry.Descriptor.Services = append([]string(nil), p.Descriptor.Services...)
+		entry.Source = pluginSourceBuiltin
+		entry.ServerURLSource = pluginSourceBuiltin
+		r.plugins = append(r.plugins, &entry)
+	}
+
+	if r.cache != nil {
+		for _, p := range r.cache.Plugins {
+			entry := r.lookupID(p.ID)
+			if entry == nil {
+				entry = r.add(p.ID, pluginSourceVerifier)
+			}
+			entry.Source = pluginSourceVerifier
+			if p.Title != "" {
+				entry.Name = p.Title
+			}
+			if p.Description != "" {
+				entry.Description = p.Description
+			}
+			if p.ServerEndpoint != "" {
+				entry.ServerURL = p.ServerEndpoint
+				entry.ServerURLSource = pluginSourceVerifier
+			}
+		}
+	}
+
+	// VCLI_DCA_PLUGIN_URL / VCLI_FEE_PLUGIN_URL predate plugin_server_urls
+	legacy := map[string]string{}
+	if cfg.DCAPlugin != "" && cfg.DCAPlugin != "http://localhost:8082" {
+		legacy["vultisig-dca-0000"] = cfg.DCAPlugin
+	}
+	if cfg.FeePlugin != "" && cfg.FeePlugin != "http://localhost:8085" {
+		legacy["vultisig-fees-feee"] = cfg.FeePlugin
+	}
+	for _, urls := range []map[string]string{legacy, cfg.PluginServerURLs} {
+		for id, url := range urls {
+			entry := r.lookupID(id)
+			if entry == nil {
+				entry = r.add(id, pluginSourceConfig)
+			}
+			entry.ServerURL = url
+			entry.ServerURLSource = pluginSourceConfig
+		}
+	}
+
+	// VCLI_DCA_DATABASE_DSN predates plugin_descriptors and also covers
+	// Sends, which shares the DCA database
+	for _, entry := range r.plugins {
+		if entry.Descriptor.Database == "vultisig-dca" {
+			entry.Descriptor.DatabaseDSN = cfg.DCADatabaseDSN
+		}
+	}
+	for _, id := range sortedKeys(cfg.PluginDescriptors) {
+		entry := r.lookupID(id)
+		if entry == nil {
+			entry = r.add(id, pluginSourceConfig)
+		}
+		entry.Descriptor.merge(cfg.PluginDescriptors[id])
+	}
+	for _, entry := range r.plugins {
+		if entry.Descriptor.Label == "" {
+			entry.Descriptor.Label = entry.Name
+		}
+		if entry.Descriptor.HealthURL == "" && entry.ServerURL != "" {
+			entry.Descriptor.HealthURL = strings.TrimRight(entry.ServerURL, "/") + "/healthz"
+		}
+	}
+
+	for _, entry := range r.plugins {
+		for _, alias := range entry.Aliases {
+			r.aliases[alias] = entry.ID
+		}
+	}
+	for _, alias := range sortedKeys(cfg.PluginAliases) {
+		id := cfg.PluginAliases[alias]
+		if prev, ok := r.aliases[alias]; ok {
+			r.lookupID(prev).removeAlias(alias)
+		}
+		entry := r.lookupID(id)
+		if entry == nil {
+			entry = r.add(id, pluginSourceConfig)
+		}
+		entry.Aliases = append(entry.Aliases, alias)
+		entry.ConfigAliases = append(entry.ConfigAliases, alias)
+		r.aliases[alias] = id
+	}
+
+	return r
+}
+
+func (r *pluginRegistry) add(id, source string) *PluginInfo {
+	entry := &PluginInfo{ID: id, Name: id, Source: source}
+	r.plugins = append(r.plugins, entry)
+	return entry
+}
+
+func (r *pluginRegistry) lookupID(id string) *PluginInfo {
+	for _, p := range r.plugins {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// lookup finds a plugin by ID or alias.
+func (r *pluginRegistry) lookup(idOrAlias string) *PluginInfo {
+	if id, ok := r.aliases[idOrAlias]; ok {
+		return r.lookupID(id)
+	}
+	return r.lookupID(idOrAlias)
+}
+
+func (p *PluginInfo) removeAlias(alias string) {
+	var kept []string
+	for _, a := range p.Aliases {
+		if a != alias {
+			kept = append(kept, a)
+		}
+	}
+	p.Aliases = kept
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ResolvePluginID converts an alias to the full plugin ID.
+// If the input is not an alias, it returns the input unchanged.
+func ResolvePluginID(input string) string {
+	if id, ok := loadPluginRegistry(pluginSyncNever).aliases[input]; ok {
+		return id
+	}
+	return input
+}
+
+// GetPluginServerURL returns the server URL for a plugin ID (or alias): a
+// config override, else the verifier's server_endpoint, else the builtin
+// default. The verifier's list is synced first when the cache is stale or
+// the plugin is not in it.
+func GetPluginServerURL(pluginIDOrAlias string) (string, error) {
+	r := loadPluginRegistry(pluginSyncIfStale)
+	p := r.lookup(pluginIDOrAlias)
+	if (p == nil || p.ServerURL == "") && r.syncErr == nil {
+		r = loadPluginRegistry(pluginSyncAlways)
+		p = r.lookup(pluginIDOrAlias)
+	}
+	if p == nil || p.ServerURL == "" {
+		msg := fmt.Sprintf("unknown plugin: %s", pluginIDOrAlias)
+		if r.syncErr != nil {
+			msg += fmt.Sprintf(" (verifier sync failed: %v)", r.syncErr)
+		}
+		return "", fmt.Errorf("%s; register it with the verifier or set plugin_server_urls in %s", msg, ConfigPath())
+	}
+	return p.ServerURL, nil
+}
+
+// pluginAliasLabel names a plugin by its aliases, or its ID if it has none.
+func pluginAliasLabel(id string) string {
+	p := loadPluginRegistry(pluginSyncNever).lookupID(id)
+	if p == nil || len(p.Aliases) == 0 {
+		return id
+	}
+	return strings.Join(p.Aliases, "/")
+}
+
+// pluginDescriptor returns the descriptor of a plugin ID or alias; a plugin
+// vcli does not know gets one labelled with its ID.
+func pluginDescriptor(idOrAlias string) PluginDescriptor {
+	p := loadPluginRegistry(pluginSyncNever).lookup(idOrAlias)
+	if p == nil {
+		return PluginDescriptor{Label: idOrAlias}
+	}
+	return p.Descriptor
+}
+
+// pluginDatabaseDSN returns the DSN of the plugin's own database: the
+// descriptor's DatabaseDSN, else its Database on the verifier's server.
+func pluginDatabaseDSN(cfg *DevConfig, d PluginDescriptor) (string, error) {
+	if d.DatabaseDSN != "" {
+		return d.DatabaseDSN, nil
+	}
+	if d.Database == "" {
+		return "", fmt.Errorf("%s has no database in its descriptor", d.Label)
+	}
+	u, err := url.Parse(cfg.DatabaseDSN)
+	if err != nil {
+		return "", fmt.Errorf("parse database DSN: %w", err)
+	}
+	u.Path = "/" + d.Database
+	return u.String(), nil
+}
@@ -10,19 +10,21 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	rtypes "github.com/vultisig/recipes/types"
-	"github.com/vultisig/vultisig-go/address"
 	"github.com/vultisig/vultisig-go/common"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/vultisig/vcli/local/pkg/devdb"
+	"github.com/vultisig/vcli/local/pkg/verifier"
 )
 
 func NewPolicyCmd() *cobra.Command {
@@ -33,19 +35,30 @@ func NewPolicyCmd() *cobra.Command {
 
 	cmd.AddCommand(newPolicyListCmd())
 	cmd.AddCommand(newPolicyAddCmd())
+	cmd.AddCommand(newPolicyValidateCmd())
+	cmd.AddCommand(newPolicyUpdateCmd())
+	cmd.AddCommand(newPolicyPauseCmd())
+	cmd.AddCommand(newPolicyResumeCmd())
 	cmd.AddCommand(newPolicyDeleteCmd())
 	cmd.AddCommand(newPolicyInfoCmd())
+	cmd.AddCommand(newPolicyShowCmd())
+	cmd.AddCommand(newPolicyResubmitCmd())
+	cmd.AddCommand(newPolicyDecodeCmd())
+	cmd.AddCommand(newPolicySimulateCmd())
 	cmd.AddCommand(newPolicyHistoryCmd())
 	cmd.AddCommand(newPolicyStatusCmd())
 	cmd.AddCommand(newPolicyTransactionsCmd())
 	cmd.AddCommand(newPolicyTriggerCmd())
+	cmd.AddCommand(newPolicyWatchCmd())
 	cmd.AddCommand(newPolicyGenerateCmd())
+	cmd.AddCommand(newPolicyMatrixCmd())
 
 	return cmd
 }
 
 func newPolicyListCmd() *cobra.Command {
 	var pluginID string
+	var local bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -54,22 +67,33 @@ func newPolicyListCmd() *cobra.Command {
 
 Plugin ID can be an alias (dca, fee, sends) or full ID.
 Run 'vcli plugin aliases' to see available aliases.
+
+With --local, list the policies vcli submitted from its local registry
+(~/.vultisig/policies/), optionally filtered by --plugin, and whether the
+verifier still has each of them.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if local {
+				return runPolicyListLocal(ResolvePluginID(pluginID))
+			}
+			if pluginID == "" {
+				return fmt.Errorf("required flag(s) \"plugin\" not set (or use --local)")
+			}
 			return runPolicyList(ResolvePluginID(pluginID))
 		},
 	}
 
-	cmd.Flags().StringVar(&pluginID, "plugin", "", "Plugin ID or alias (required)")
-	cmd.MarkFlagRequired("plugin")
+	cmd.Flags().StringVar(&pluginID, "plugin", "", "Plugin ID or alias (required without --local)")
+	cmd.Flags().BoolVar(&local, "local", false, "List policies from the local registry")
 
-	return cmd
+	return withStructuredOutput(cmd)
 }
 
 func newPolicyAddCmd() *cobra.Command {
 	var pluginID string
 	var configFile string
 	var password string
+	var acct Account
 
 	cmd := &cobra.Command{
 		Use:   "add",
@@ -100,6 +124,14 @@ Example for DCA plugin (swap ETH to USDC):
   "billing": [{ "type": "once", "amount": 0 }]
 }
 
+Account selection:
+  Empty addresses are filled from the key for --account/--index. If the flags
+  are omitted, the optional "derivation" block in the policy file (written by
+  'policy generate') is used instead:
+  { "derivation": { "account": 1, "index": 0 } }
+  The policy itself is always signed with the vault's default key, which is
+  the key the verifier checks signatures against.
+
 Environment variables:
   VAULT_PASSWORD  - Fast Vault password (or use --password flag)
 
@@ -117,17 +149,22 @@ Note: Requires authentication. Run 'vcli vault import' first.
 					return err
 				}
 			}
-			return runPolicyAdd(ResolvePluginID(pluginID), configFile, actualPassword)
+			var acctOverride *Account
+			if cmd.Flags().Changed("account") || cmd.Flags().Changed("index") {
+				acctOverride = &acct
+			}
+			return runPolicyAdd(ResolvePluginID(pluginID), configFile, actualPassword, acctOverride)
 		},
 	}
 
 	cmd.Flags().StringVar(&pluginID, "plugin", "", "Plugin ID or alias (required)")
 	cmd.Flags().StringVar(&configFile, "policy-file", "", "Policy configuration JSON file (required)")
 	cmd.Flags().StringVar(&password, "password", "", "Fast Vault password (or set VAULT_PASSWORD env var)")
+	addAccountFlags(cmd, &acct)
 	cmd.MarkFlagRequired("plugin")
 	cmd.MarkFlagRequired("policy-file")
 
-	return cmd
+	return withStructuredOutput(cmd)
 }
 
 func newPolicyDeleteCmd() *cobra.Command {
@@ -156,349 +193,443 @@ Environment variables:
 
 	cmd.Flags().StringVar(&password, "password", "", "Vault password for TSS signing (or set VAULT_PASSWORD)")
 
-	return cmd
+	return withStructuredOutput(cmd)
 }
 
 func newPolicyInfoCmd() *cobra.Command {
-	return &cobra.Command{
+	return withStructuredOutput(&cobra.Command{
 		Use:   "info [policy-id]",
 		Short: "Show policy details",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPolicyInfo(args[0])
 		},
-	}
+	})
 }
 
 func newPolicyHistoryCmd() *cobra.Command {
-	return &cobra.Command{
+	return withStructuredOutput(&cobra.Command{
 		Use:   "history [policy-id]",
 		Short: "Show policy transaction history",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPolicyHistory(args[0])
 		},
-	}
+	})
 }
 
 func runPolicyList(pluginID string) error {
-	cfg, err := LoadConfig()
+	vault, err := ActiveVault()
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
+	publicKey := vault.PublicKeyECDSA
 
-	authHeader, err := GetAuthHeader()
+	_, err = ensureAuthHeader(vault)
 	if err != nil {
 		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import' first", err)
 	}
 
-	vaults, err := ListVaults()
-	if err != nil || len(vaults) == 0 {
-		return fmt.Errorf("no vaults found. Import a vault first: vcli vault import")
-	}
-	publicKey := vaults[0].PublicKeyECDSA
-
 	fmt.Printf("Fetching policies for plugin %s...\n", pluginID)
 	fmt.Printf("  Vault: %s...\n\n", publicKey[:20])
 
-	url := fmt.Sprintf("%s/plugin/policies/%s?public_key=%s", cfg.Verifier, pluginID, publicKey)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	client, err := newVerifierClient(vault)
+	if err != nil {
+		return err
+	}
+	list, err := client.ListPolicies(context.Background(), pluginID, publicKey, verifier.PolicyFilter{})
+	if err != nil {
+		return fmt.Errorf("list policies: %w", err)
+	}
+	if list.Policies == nil {
+		list.Policies = []verifier.Policy{}
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	return printResult(list, func() {
+		policies := list.Policies
+		if len(policies) == 0 {
+			fmt.Println("No policies found for this plugin.")
+			return
+		}
+
+		fmt.Printf("Found %d policies:\n\n", len(policies))
+		for i, p := range policies {
+			fmt.Printf("  %d. Policy ID: %s\n", i+1, p.ID)
+			fmt.Printf("     Active: %v\n", p.Active)
+			fmt.Printf("     Version: %d (plugin %s)\n\n", p.PolicyVersion, p.PluginVersion)
+		}
+	})
+}
+
+func runPolicyAdd(pluginID, configFile string, password string, acctOverride *Account) error {
+	startTime := time.Now()
+	rememberVaultPassword(password)
+
+	vault, err := ActiveVault()
+	if err != nil {
+		return err
+	}
+	err = requireKeyshares(vault)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return err
 	}
-	req.Header.Set("Authorization", authHeader)
 
-	resp, err := http.DefaultClient.Do(req)
+	_, err = ensureAuthHeader(vault)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import --password xxx' to authenticate first", err)
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
+	pf, err := loadPolicyFile(configFile, vault, acctOverride)
+	if err != nil {
+		return err
+	}
+	acct := pf.account
+	recipeConfig := pf.recipe
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("request failed (%d): %s", resp.StatusCode, string(body))
+	fmt.Printf("Creating policy for plugin %s...\n", pluginID)
+	fmt.Printf("  Vault: %s (%s...)\n", vault.Name, vault.PublicKeyECDSA[:16])
+	fmt.Printf("  Config: %s\n", configFile)
+	if !acct.IsDefault() {
+		fmt.Printf("  Derivation: %s (%s)\n", acct, acct.EVMDerivePath())
 	}
 
-	var policies []map[string]interface{}
-	err = json.Unmarshal(body, &policies)
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	created, rules, err := createPolicy(ctx, vault, pluginID, pf, password)
 	if err != nil {
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-		prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(prettyJSON))
-		return nil
+		return err
 	}
+	policyVersion := created.PolicyVersion
+	pluginVersion := created.PluginVersion
 
-	if len(policies) == 0 {
-		fmt.Println("No policies found for this plugin.")
-		return nil
-	}
+	totalDuration := time.Since(startTime)
 
-	fmt.Printf("Found %d policies:\n\n", len(policies))
-	for i, p := range policies {
-		policyID := p["id"]
-		active := p["active"]
-		createdAt := p["created_at"]
-		fmt.Printf("  %d. Policy ID: %v\n", i+1, policyID)
-		fmt.Printf("     Active: %v\n", active)
-		fmt.Printf("     Created: %v\n\n", createdAt)
+	result := PolicyAddResult{
+		PolicyID:      created.ID,
+		PluginID:      pluginID,
+		PublicKey:     vault.PublicKeyECDSA,
+		PolicyVersion: policyVersion,
+		PluginVersion: pluginVersion,
+		Summary:       summarizeRecipe(recipeConfig),
+		DurationMs:    totalDuration.Milliseconds(),
+	}
+	result.Summary.Rules = rules
+	if !acct.IsDefault() {
+		result.Derivation = acct.EVMDerivePath()
 	}
 
-	return nil
+	return printResult(result, func() {
+		summary := result.Summary
+		policyID := result.PolicyID
+
+		fmt.Println()
+		fmt.Println("┌─────────────────────────────────────────────────────────────────┐")
+		fmt.Println("│ POLICY ADDED SUCCESSFULLY                                       │")
+		fmt.Println("├─────────────────────────────────────────────────────────────────┤")
+		fmt.Println("│                                                                 │")
+		fmt.Printf("│  Plugin:      %-50s │\n", pluginID)
+		fmt.Printf("│  Vault:       %-50s │\n", vault.PublicKeyECDSA[:16]+"...")
+		if policyID != "" {
+			fmt.Printf("│  Policy ID:   %-50s │\n", policyID)
+		}
+		fmt.Println("│                                                                 │")
+		fmt.Println("│  Summary:                                                      │")
+		if summary.FromChain != "" {
+			fmt.Printf("│    From:      %-47s │\n", fmt.Sprintf("%s (%s)", summary.FromToken, summary.FromChain))
+		}
+		if summary.ToChain != "" {
+			fmt.Printf("│    To:        %-47s │\n", fmt.Sprintf("%s (%s)", summary.ToToken, summary.ToChain))
+		}
+		if summary.Amount != "" {
+			fmt.Printf("│    Amount:    %-47s │\n", summary.Amount)
+		}
+		fmt.Printf("│    Frequency: %-47s │\n", summary.Frequency)
+		fmt.Printf("│    Rules:     %-47d │\n", summary.Rules)
+		fmt.Println("│                                                                 │")
+		fmt.Printf("│  Duration:   %-50s │\n", totalDuration.Round(time.Millisecond).String())
+		fmt.Println("│                                                                 │")
+		fmt.Println("└─────────────────────────────────────────────────────────────────┘")
+		fmt.Println()
+		if policyID != "" {
+			fmt.Println("Monitor policy execution:")
+			fmt.Printf("  vcli policy status %s        # Check status and next execution time\n", policyID)
+			fmt.Printf("  vcli policy transactions %s  # View executed transactions\n", policyID)
+			fmt.Printf("  vcli policy history %s       # View transaction history\n", policyID)
+		}
+	})
 }
 
-func runPolicyAdd(pluginID, configFile string, password string) error {
-	startTime := time.Now()
+// createPolicy builds, signs and submits version 1 of a policy file. It
+// returns the created policy and the number of rules in its recipe.
+func createPolicy(ctx context.Context, vault *LocalVault, pluginID string, pf *policyFile, password string) (*verifier.Policy, int, error) {
+	recipe, err := buildPolicyRecipe(pluginID, pf)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	cfg, err := LoadConfig()
+	policyVersion := 1
+	pluginVersion := "1.0.0"
+
+	signature, err := signPolicy(ctx, vault, recipe.base64, policyVersion, pluginVersion, password)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return nil, 0, err
+	}
+
+	policyRequest := verifier.Policy{
+		PluginID:      pluginID,
+		PublicKey:     vault.PublicKeyECDSA,
+		PluginVersion: pluginVersion,
+		PolicyVersion: policyVersion,
+		Signature:     signature,
+		Recipe:        recipe.base64,
+		Billing:       recipe.billing,
+		Active:        true,
 	}
 
-	authHeader, err := GetAuthHeader()
+	// Submit to verifier
+	fmt.Println("\nSubmitting policy to verifier...")
+
+	client, err := newVerifierClient(vault)
 	if err != nil {
-		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import --password xxx' to authenticate first", err)
+		return nil, 0, err
+	}
+	created, err := client.CreatePolicy(ctx, policyRequest)
+	if err != nil {
+		return nil, 0, fmt.Errorf("create policy: %w", err)
+	}
+	if created.PolicyVersion == 0 {
+		created.PolicyVersion = policyVersion
+	}
+	if created.PluginVersion == "" {
+		created.PluginVersion = pluginVersion
+	}
+	if created.ID != "" {
+		recordPolicy(newLocalPolicy(created.ID, vault, pluginID, pf, recipe, signature, created.PolicyVersion, created.PluginVersion))
 	}
+	return created, len(recipe.suggest.GetRules()), nil
+}
+
+// PolicyAddResult is the result of 'policy add'.
+type PolicyAddResult struct {
+	PolicyID      string        `json:"policy_id"`
+	PluginID      string        `json:"plugin_id"`
+	PublicKey     string        `json:"public_key"`
+	PolicyVersion int           `json:"policy_version"`
+	PluginVersion string        `json:"plugin_version"`
+	Derivation    string        `json:"derivation,omitempty"`
+	Summary       PolicySummary `json:"summary"`
+	DurationMs    int64         `json:"duration_ms"`
+}
 
-	vaults, err := ListVaults()
-	if err != nil || len(vaults) == 0 {
-		return fmt.Errorf("no vaults found. Import a vault first: vcli vault import")
+// PolicySummary is the swap/send a recipe describes; tokens are "native"
+// when the recipe leaves them empty.
+type PolicySummary struct {
+	FromChain string `json:"from_chain,omitempty"`
+	FromToken string `json:"from_token,omitempty"`
+	Amount    string `json:"amount,omitempty"`
+	ToChain   string `json:"to_chain,omitempty"`
+	ToToken   string `json:"to_token,omitempty"`
+	Frequency string `json:"frequency"`
+	Rules     int    `json:"rules"`
+}
+
+func summarizeRecipe(recipeConfig map[string]interface{}) PolicySummary {
+	var summary PolicySummary
+	if from, ok := recipeConfig["from"].(map[string]interface{}); ok {
+		if c, ok := from["chain"].(string); ok {
+			summary.FromChain = c
+		}
+		if t, ok := from["token"].(string); ok && t != "" {
+			summary.FromToken = t
+		} else {
+			summary.FromToken = "native"
+		}
 	}
-	vault := vaults[0]
+	if to, ok := recipeConfig["to"].(map[string]interface{}); ok {
+		if c, ok := to["chain"].(string); ok {
+			summary.ToChain = c
+		}
+		if t, ok := to["token"].(string); ok && t != "" {
+			summary.ToToken = t
+		} else {
+			summary.ToToken = "native"
+		}
+	}
+	if amt, ok := recipeConfig["fromAmount"].(string); ok {
+		summary.Amount = amt
+	}
+	if freq, ok := recipeConfig["frequency"].(string); ok {
+		summary.Frequency = freq
+	} else {
+		summary.Frequency = "one-time"
+	}
+	return summary
+}
+
+// policyFile is a parsed policy configuration file with vault addresses
+// filled in.
+type policyFile struct {
+	path    string
+	source  []byte
+	config  map[string]interface{}
+	recipe  map[string]interface{}
+	account Account
+}
 
+func loadPolicyFile(configFile string, vault *LocalVault, acctOverride *Account) (*policyFile, error) {
 	configData, err := os.ReadFile(configFile)
 	if err != nil {
-		return fmt.Errorf("read config file: %w", err)
+		return nil, fmt.Errorf("read config file: %w", err)
 	}
+	return parsePolicyFile(configFile, configData, vault, acctOverride)
+}
 
+// parsePolicyFile parses the contents of a policy file read from path.
+func parsePolicyFile(path string, configData []byte, vault *LocalVault, acctOverride *Account) (*policyFile, error) {
 	var policyConfig map[string]interface{}
-	err = json.Unmarshal(configData, &policyConfig)
+	err := json.Unmarshal(configData, &policyConfig)
 	if err != nil {
-		return fmt.Errorf("parse config file: %w", err)
+		return nil, fmt.Errorf("parse config file: %w", err)
 	}
 
 	recipeConfig, ok := policyConfig["recipe"].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("missing or invalid 'recipe' in config file")
+		return nil, fmt.Errorf("missing or invalid 'recipe' in config file")
+	}
+
+	acct, err := policyAccount(policyConfig, acctOverride)
+	if err != nil {
+		return nil, err
 	}
 
 	// Auto-fill addresses from vault if empty
-	recipeConfig, err = fillAddressesFromVault(recipeConfig, vault)
+	recipeConfig, err = fillAddressesFromVault(recipeConfig, vault, acct)
 	if err != nil {
-		return fmt.Errorf("fill addresses from vault: %w", err)
+		return nil, fmt.Errorf("fill addresses from vault: %w", err)
 	}
 
-	fmt.Printf("Creating policy for plugin %s...\n", pluginID)
-	fmt.Printf("  Vault: %s (%s...)\n", vault.Name, vault.PublicKeyECDSA[:16])
-	fmt.Printf("  Config: %s\n", configFile)
+	return &policyFile{
+		path:    path,
+		source:  configData,
+		config:  policyConfig,
+		recipe:  recipeConfig,
+		account: acct,
+	}, nil
+}
+
+// policyRecipe is the protobuf recipe and billing built for a policy file.
+type policyRecipe struct {
+	suggest *rtypes.PolicySuggest
+	policy  *rtypes.Policy
+	base64  string
+	billing []verifier.Billing
+}
+
+// buildPolicyRecipe asks the plugin for the rules matching the recipe and
+// builds the base64-encoded protobuf Policy that gets signed.
+func buildPolicyRecipe(pluginID string, pf *policyFile) (*policyRecipe, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
 
-	// Step 1: Get plugin server URL
 	pluginServerURL, err := getPluginServerURL(cfg.Verifier, pluginID)
 	if err != nil {
-		return fmt.Errorf("get plugin server URL: %w", err)
+		return nil, fmt.Errorf("get plugin server URL: %w", err)
 	}
 	fmt.Printf("  Plugin Server: %s\n", pluginServerURL)
 
-	// Step 2: Call plugin's suggest endpoint to get rules
 	fmt.Println("\nFetching policy template from plugin...")
-	policySuggest, err := getPluginPolicySuggest(pluginServerURL, recipeConfig)
+	policySuggest, err := getPluginPolicySuggest(pluginServerURL, pf.recipe)
 	if err != nil {
-		return fmt.Errorf("get policy suggest: %w", err)
+		return nil, fmt.Errorf("get policy suggest: %w", err)
 	}
 	fmt.Printf("  Rules: %d\n", len(policySuggest.GetRules()))
 	if policySuggest.RateLimitWindow != nil {
 		fmt.Printf("  Rate Limit Window: %ds\n", policySuggest.GetRateLimitWindow())
 	}
 
-	// Step 3: Build protobuf Policy
-	policy, err := buildProtobufPolicy(pluginID, recipeConfig, policyConfig["billing"], policySuggest)
+	policy, err := buildProtobufPolicy(pluginID, pf.recipe, pf.config["billing"], policySuggest)
 	if err != nil {
-		return fmt.Errorf("build protobuf policy: %w", err)
+		return nil, fmt.Errorf("build protobuf policy: %w", err)
 	}
 
-	// Step 4: Serialize to protobuf bytes, then base64
 	policyBytes, err := proto.Marshal(policy)
 	if err != nil {
-		return fmt.Errorf("marshal protobuf policy: %w", err)
+		return nil, fmt.Errorf("marshal protobuf policy: %w", err)
 	}
-	recipeBase64 := base64.StdEncoding.EncodeToString(policyBytes)
 
-	policyVersion := 1
-	pluginVersion := "1.0.0"
+	billingArray, err := buildBillingArray(pf.config["billing"])
+	if err != nil {
+		return nil, fmt.Errorf("build billing array: %w", err)
+	}
 
-	// Step 5: Create signature message and sign
-	// Message format: {recipe}*#*{public_key}*#*{policy_version}*#*{plugin_version}
+	return &policyRecipe{
+		suggest: policySuggest,
+		policy:  policy,
+		base64:  base64.StdEncoding.EncodeToString(policyBytes),
+		billing: billingArray,
+	}, nil
+}
+
+// policyMessageHash is the hex Keccak256 of the EIP-191 message the verifier
+// checks policy signatures against:
+// {recipe}*#*{public_key}*#*{policy_version}*#*{plugin_version}
+func policyMessageHash(recipe, publicKey string, policyVersion int, pluginVersion string) string {
 	signatureMessage := fmt.Sprintf("%s*#*%s*#*%d*#*%s",
-		recipeBase64,
-		vault.PublicKeyECDSA,
+		recipe,
+		publicKey,
 		policyVersion,
 		pluginVersion,
 	)
 
-	// DEBUG: print message details
-	fmt.Printf("\n  DEBUG: Signing message:\n")
-	fmt.Printf("    Recipe (first 50 chars): %s...\n", recipeBase64[:min(50, len(recipeBase64))])
-	fmt.Printf("    Public Key: %s\n", vault.PublicKeyECDSA)
-	fmt.Printf("    Policy Version: %d\n", policyVersion)
-	fmt.Printf("    Plugin Version: %s\n", pluginVersion)
-	fmt.Printf("    Full message length: %d\n", len(signatureMessage))
-
 	ethPrefixedMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(signatureMessage), signatureMessage)
-	messageHash := crypto.Keccak256([]byte(ethPrefixedMessage))
-	hexMessage := hex.EncodeToString(messageHash)
-	fmt.Printf("    Message hash: %s\n", hexMessage)
+	return hex.EncodeToString(crypto.Keccak256([]byte(ethPrefixedMessage)))
+}
+
+// signPolicy signs a policy version with TSS keysign and returns the
+// signature in Ethereum format (0x R || S || V). The verifier checks policy
+// signatures against the vault's default key, so the account used to fill in
+// addresses plays no part here.
+func signPolicy(ctx context.Context, vault *LocalVault, recipe string, policyVersion int, pluginVersion string, password string) (string, error) {
+	hexMessage := policyMessageHash(recipe, vault.PublicKeyECDSA, policyVersion, pluginVersion)
+
+	log := componentLogger("policy")
+	log.WithFields(logrus.Fields{
+		"recipe_prefix":  recipe[:min(50, len(recipe))],
+		"public_key":     vault.PublicKeyECDSA,
+		"policy_version": policyVersion,
+		"plugin_version": pluginVersion,
+		"message_hash":   hexMessage,
+	}).Debug("Signing policy message")
 
 	fmt.Println("\nSigning policy with TSS keysign (2-of-2 with Fast Vault Server)...")
 
 	if password == "" {
-		return fmt.Errorf("password is required for TSS keysign. Use --password flag")
+		return "", fmt.Errorf("password is required for TSS keysign. Use --password flag")
 	}
 
 	tss := NewTSSService(vault.LocalPartyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
-	defer cancel()
-
-	derivePath := "m/44'/60'/0'/0/0"
-	results, err := tss.KeysignWithFastVault(ctx, vault, []string{hexMessage}, derivePath, password)
+	results, err := tss.KeysignWithFastVault(ctx, vault, []string{hexMessage}, Account{}.EVMDerivePath(), password)
 	if err != nil {
-		return fmt.Errorf("TSS keysign failed: %w", err)
+		return "", fmt.Errorf("TSS keysign failed: %w", err)
 	}
 
 	if len(results) == 0 {
-		return fmt.Errorf("no signature result")
+		return "", fmt.Errorf("no signature result")
 	}
 
 	// Build signature in Ethereum format (R + S + V) - same as auth signing
 	signature := "0x" + results[0].R + results[0].S + results[0].RecoveryID
-	fmt.Printf("  DEBUG: Signature: %s\n", signature)
-	fmt.Printf("  DEBUG: R: %s, S: %s, V: %s\n", results[0].R, results[0].S, results[0].RecoveryID)
-
-	// Step 6: Build billing array for API request
-	billingArray, err := buildBillingArray(policyConfig["billing"])
-	if err != nil {
-		return fmt.Errorf("build billing array: %w", err)
-	}
-
-	policyRequest := map[string]interface{}{
-		"plugin_id":      pluginID,
-		"public_key":     vault.PublicKeyECDSA,
-		"plugin_version": pluginVersion,
-		"policy_version": policyVersion,
-		"signature":      signature,
-		"recipe":         recipeBase64,
-		"billing":        billingArray,
-		"active":         true,
-	}
-
-	policyJSON, err := json.Marshal(policyRequest)
-	if err != nil {
-		return fmt.Errorf("marshal policy request: %w", err)
-	}
-
-	// Step 7: Submit to verifier
-	fmt.Println("\nSubmitting policy to verifier...")
-
-	url := cfg.Verifier + "/plugin/policy"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(policyJSON))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", authHeader)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("submit policy: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("create policy failed (%d): %s", resp.StatusCode, string(body))
-	}
-
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
-
-	totalDuration := time.Since(startTime)
-
-	// Extract recipe summary
-	var fromChain, fromToken, fromAmount, toChain, toToken, frequency string
-	if from, ok := recipeConfig["from"].(map[string]interface{}); ok {
-		if c, ok := from["chain"].(string); ok {
-			fromChain = c
-		}
-		if t, ok := from["token"].(string); ok && t != "" {
-			fromToken = t
-		} else {
-			fromToken = "native"
-		}
-	}
-	if to, ok := recipeConfig["to"].(map[string]interface{}); ok {
-		if c, ok := to["chain"].(string); ok {
-			toChain = c
-		}
-		if t, ok := to["token"].(string); ok && t != "" {
-			toToken = t
-		} else {
-			toToken = "native"
-		}
-	}
-	if amt, ok := recipeConfig["fromAmount"].(string); ok {
-		fromAmount = amt
-	}
-	if freq, ok := recipeConfig["frequency"].(string); ok {
-		frequency = freq
-	} else {
-		frequency = "one-time"
-	}
-
-	// Print completion report
-	fmt.Println()
-	fmt.Println("┌─────────────────────────────────────────────────────────────────┐")
-	fmt.Println("│ POLICY ADDED SUCCESSFULLY                                       │")
-	fmt.Println("├─────────────────────────────────────────────────────────────────┤")
-	fmt.Println("│                                                                 │")
-	fmt.Printf("│  Plugin:      %-50s │\n", pluginID)
-	fmt.Printf("│  Vault:       %-50s │\n", vault.PublicKeyECDSA[:16]+"...")
-	var policyID string
-	if data, ok := result["data"].(map[string]interface{}); ok {
-		if id, ok := data["id"].(string); ok {
-			policyID = id
-			fmt.Printf("│  Policy ID:   %-50s │\n", id)
-		}
-	}
-	fmt.Println("│                                                                 │")
-	fmt.Println("│  Summary:                                                      │")
-	if fromChain != "" {
-		fmt.Printf("│    From:      %-47s │\n", fmt.Sprintf("%s (%s)", fromToken, fromChain))
-	}
-	if toChain != "" {
-		fmt.Printf("│    To:        %-47s │\n", fmt.Sprintf("%s (%s)", toToken, toChain))
-	}
-	if fromAmount != "" {
-		fmt.Printf("│    Amount:    %-47s │\n", fromAmount)
-	}
-	fmt.Printf("│    Frequency: %-47s │\n", frequency)
-	fmt.Printf("│    Rules:     %-47d │\n", len(policySuggest.GetRules()))
-	fmt.Println("│                                                                 │")
-	fmt.Printf("│  Duration:   %-50s │\n", totalDuration.Round(time.Millisecond).String())
-	fmt.Println("│                                                                 │")
-	fmt.Println("└─────────────────────────────────────────────────────────────────┘")
-	fmt.Println()
-	if policyID != "" {
-		fmt.Println("Monitor policy execution:")
-		fmt.Printf("  vcli policy status %s        # Check status and next execution time\n", policyID)
-		fmt.Printf("  vcli policy transactions %s  # View executed transactions\n", policyID)
-		fmt.Printf("  vcli policy history %s       # View transaction history\n", policyID)
-	}
+	log.WithFields(logrus.Fields{
+		"signature": signature,
+		"r":         results[0].R,
+		"s":         results[0].S,
+		"v":         results[0].RecoveryID,
+	}).Debug("Policy signed")
 
-	return nil
+	return signature, nil
 }
 
 func getPluginServerURL(verifierURL, pluginID string) (string, error) {
@@ -640,9 +771,9 @@ func buildFeePolicies(billingConfig interface{}) ([]*rtypes.FeePolicy, error) {
 	return feePolicies, nil
 }
 
-func buildBillingArray(billingConfig interface{}) ([]map[string]interface{}, error) {
+func buildBillingArray(billingConfig interface{}) ([]verifier.Billing, error) {
 	if billingConfig == nil {
-		return []map[string]interface{}{}, nil
+		return []verifier.Billing{}, nil
 	}
 
 	var billingArray []interface{}
@@ -656,11 +787,22 @@ func buildBillingArray(billingConfig interface{}) ([]map[string]interface{}, err
 		return nil, fmt.Errorf("invalid billing config type: %T", billingConfig)
 	}
 
-	var result []map[string]interface{}
+	result := []verifier.Billing{}
 	for _, item := range billingArray {
-		if billing, ok := item.(map[string]interface{}); ok {
-			result = append(result, billing)
+		billing, ok := item.(map[string]interface{})
+		if !ok {
+			continue
 		}
+		data, err := json.Marshal(billing)
+		if err != nil {
+			return nil, fmt.Errorf("invalid billing entry: %w", err)
+		}
+		var entry verifier.Billing
+		err = json.Unmarshal(data, &entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid billing entry: %w", err)
+		}
+		result = append(result, entry)
 	}
 
 	return result, nil
@@ -675,77 +817,43 @@ func min(a, b int) int {
 
 func runPolicyDelete(policyID, password string) error {
 	startTime := time.Now()
+	rememberVaultPassword(password)
 
-	cfg, err := LoadConfig()
+	vault, err := ActiveVault()
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
-
-	authHeader, err := GetAuthHeader()
+	err = requireKeyshares(vault)
 	if err != nil {
-		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import' first", err)
+		return err
 	}
 
-	vaults, err := ListVaults()
-	if err != nil || len(vaults) == 0 {
-		return fmt.Errorf("no vaults found. Import a vault first")
+	_, err = ensureAuthHeader(vault)
+	if err != nil {
+		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import' first", err)
 	}
-	vault := vaults[0]
 
 	fmt.Printf("Deleting policy %s...\n", policyID)
 	fmt.Printf("  Vault: %s...\n", vault.PublicKeyECDSA[:20])
 
 	// Step 1: Fetch existing policy to get its data
 	fmt.Println("\nFetching policy details...")
-	policyURL := fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	fetchReq, err := http.NewRequestWithContext(ctx, "GET", policyURL, nil)
+	client, err := newVerifierClient(vault)
 	if err != nil {
-		return fmt.Errorf("create fetch request: %w", err)
+		return err
 	}
-	fetchReq.Header.Set("Authorization", authHeader)
+	ctx := context.Background()
 
-	fetchResp, err := http.DefaultClient.Do(fetchReq)
+	policy, err := client.GetPolicy(ctx, policyID)
 	if err != nil {
-		return fmt.Errorf("fetch policy failed: %w", err)
+		return fmt.Errorf("fetch policy: %w", err)
 	}
-	defer fetchResp.Body.Close()
 
-	fetchBody, _ := io.ReadAll(fetchResp.Body)
-	if fetchResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("fetch policy failed (%d): %s", fetchResp.StatusCode, string(fetchBody))
-	}
-
-	var policyResp struct {
-		Data struct {
-			Recipe        string `json:"recipe"`
-			PublicKey     string `json:"public_key"`
-			PolicyVersion int    `json:"policy_version"`
-			PluginVersion string `json:"plugin_version"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(fetchBody, &policyResp)
-	if err != nil {
-		return fmt.Errorf("parse policy response: %w", err)
-	}
+	// Step 2: Reconstruct the signed message from the stored policy
+	fmt.Printf("  Policy Version: %d\n", policy.PolicyVersion)
+	fmt.Printf("  Plugin Version: %s\n", policy.PluginVersion)
 
-	// Step 2: Reconstruct signature message using policy data
-	// Message format: {recipe}*#*{public_key}*#*{policy_version}*#*{plugin_version}
-	signatureMessage := fmt.Sprintf("%s*#*%s*#*%d*#*%s",
-		policyResp.Data.Recipe,
-		policyResp.Data.PublicKey,
-		policyResp.Data.PolicyVersion,
-		policyResp.Data.PluginVersion,
-	)
-
-	fmt.Printf("  Policy Version: %d\n", policyResp.Data.PolicyVersion)
-	fmt.Printf("  Plugin Version: %s\n", policyResp.Data.PluginVersion)
-
-	ethPrefixedMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(signatureMessage), signatureMessage)
-	messageHash := crypto.Keccak256([]byte(ethPrefixedMessage))
-	hexMessage := hex.EncodeToString(messageHash)
+	hexMessage := policyMessageHash(policy.Recipe, policy.PublicKey, policy.PolicyVersion, policy.PluginVersion)
 	fmt.Printf("  Message hash: %s\n", hexMessage)
 
 	// Step 3: Sign with TSS keysign
@@ -755,7 +863,8 @@ func runPolicyDelete(policyID, password string) error {
 	signCtx, signCancel := context.WithTimeout(context.Background(), 90*time.Second)
 	defer signCancel()
 
-	derivePath := "m/44'/60'/0'/0/0"
+	// Like auth and policy creation, deletion is signed with the default key
+	derivePath := Account{}.EVMDerivePath()
 	results, err := tss.KeysignWithFastVault(signCtx, vault, []string{hexMessage}, derivePath, password)
 	if err != nil {
 		return fmt.Errorf("TSS keysign failed: %w", err)
@@ -771,126 +880,133 @@ func runPolicyDelete(policyID, password string) error {
 	// Step 4: Send DELETE request with signature
 	fmt.Println("\nDeleting policy...")
 
-	deleteBody := map[string]string{
-		"signature": signature,
-	}
-	deleteJSON, err := json.Marshal(deleteBody)
-	if err != nil {
-		return fmt.Errorf("marshal delete body: %w", err)
-	}
-
-	deleteReq, err := http.NewRequestWithContext(ctx, "DELETE", policyURL, bytes.NewReader(deleteJSON))
-	if err != nil {
-		return fmt.Errorf("create delete request: %w", err)
-	}
-	deleteReq.Header.Set("Content-Type", "application/json")
-	deleteReq.Header.Set("Authorization", authHeader)
-
-	deleteResp, err := http.DefaultClient.Do(deleteReq)
+	err = client.DeletePolicy(ctx, policyID, signature)
 	if err != nil {
-		return fmt.Errorf("delete request failed: %w", err)
+		return fmt.Errorf("delete policy: %w", err)
 	}
-	defer deleteResp.Body.Close()
+	updateLocalPolicy(policyID, func(p *LocalPolicy) {
+		p.Active = false
+		p.DeletedAt = time.Now().UTC().Format(time.RFC3339)
+	})
 
-	deleteRespBody, _ := io.ReadAll(deleteResp.Body)
+	totalDuration := time.Since(startTime)
 
-	if deleteResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("delete policy failed (%d): %s", deleteResp.StatusCode, string(deleteRespBody))
+	result := PolicyDeleteResult{
+		PolicyID:      policyID,
+		PublicKey:     vault.PublicKeyECDSA,
+		PolicyVersion: policy.PolicyVersion,
+		PluginVersion: policy.PluginVersion,
+		Deleted:       true,
+		DurationMs:    totalDuration.Milliseconds(),
 	}
 
-	totalDuration := time.Since(startTime)
-
-	fmt.Println()
-	fmt.Println("┌─────────────────────────────────────────────────────────────────┐")
-	fmt.Println("│ POLICY DELETED SUCCESSFULLY                                     │")
-	fmt.Println("├─────────────────────────────────────────────────────────────────┤")
-	fmt.Println("│                                                                 │")
-	fmt.Printf("│  Policy ID:      %-47s │\n", policyID)
-	fmt.Printf("│  Vault:          %-47s │\n", vault.PublicKeyECDSA[:16]+"...")
-	fmt.Printf("│  Policy Version: %-47d │\n", policyResp.Data.PolicyVersion)
-	fmt.Printf("│  Plugin Version: %-47s │\n", policyResp.Data.PluginVersion)
-	fmt.Println("│                                                                 │")
-	fmt.Printf("│  Duration:       %-47s │\n", totalDuration.Round(time.Millisecond).String())
-	fmt.Println("│                                                                 │")
-	fmt.Println("└─────────────────────────────────────────────────────────────────┘")
-	fmt.Println()
-	fmt.Println("Next: vcli policy list --plugin <plugin-id>   # Check remaining policies")
-	fmt.Println("      vcli plugin uninstall <plugin-id>       # When done testing")
+	return printResult(result, func() {
+		fmt.Println()
+		fmt.Println("┌─────────────────────────────────────────────────────────────────┐")
+		fmt.Println("│ POLICY DELETED SUCCESSFULLY                                     │")
+		fmt.Println("├─────────────────────────────────────────────────────────────────┤")
+		fmt.Println("│                                                                 │")
+		fmt.Printf("│  Policy ID:      %-47s │\n", policyID)
+		fmt.Printf("│  Vault:          %-47s │\n", vault.PublicKeyECDSA[:16]+"...")
+		fmt.Printf("│  Policy Version: %-47d │\n", policy.PolicyVersion)
+		fmt.Printf("│  Plugin Version: %-47s │\n", policy.PluginVersion)
+		fmt.Println("│                                                                 │")
+		fmt.Printf("│  Duration:       %-47s │\n", totalDuration.Round(time.Millisecond).String())
+		fmt.Println("│                                                                 │")
+		fmt.Println("└─────────────────────────────────────────────────────────────────┘")
+		fmt.Println()
+		fmt.Println("Next: vcli policy list --plugin <plugin-id>   # Check remaining policies")
+		fmt.Println("      vcli plugin uninstall <plugin-id>       # When done testing")
+	})
+}
 
-	return nil
+type PolicyDeleteResult struct {
+	PolicyID      string `json:"policy_id"`
+	PublicKey     string `json:"public_key"`
+	PolicyVersion int    `json:"policy_version"`
+	PluginVersion string `json:"plugin_version"`
+	Deleted       bool   `json:"deleted"`
+	DurationMs    int64  `json:"duration_ms"`
 }
 
 func runPolicyInfo(policyID string) error {
-	cfg, err := LoadConfig()
+	vault, err := ActiveVault()
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
-	authHeader, err := GetAuthHeader()
+	_, err = ensureAuthHeader(vault)
 	if err != nil {
 		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import' first", err)
 	}
 
 	fmt.Printf("Fetching policy %s...\n\n", policyID)
 
-	url := fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	client, err := newVerifierClient(vault)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return err
 	}
-	req.Header.Set("Authorization", authHeader)
-
-	resp, err := http.DefaultClient.Do(req)
+	policy, err := client.GetPolicy(context.Background(), policyID)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("fetch policy: %w", err)
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
 
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
-
-	return nil
+	return printResult(policy, func() {
+		printJSON(policy)
+		fmt.Printf("\nDecode the recipe: vcli policy decode %s\n", policyID)
+	})
 }
 
 func runPolicyHistory(policyID string) error {
-	cfg, err := LoadConfig()
+	vault, err := ActiveVault()
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
-	fmt.Printf("Fetching transaction history for policy %s...\n\n", policyID)
+	_, err = ensureAuthHeader(vault)
+	if err != nil {
+		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import' first", err)
+	}
 
-	url := fmt.Sprintf("%s/plugin/policies/%s/history", cfg.Verifier, policyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	fmt.Printf("Fetching transaction history for policy %s...\n\n", policyID)
 
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	resp, err := http.DefaultClient.Do(req)
+	client, err := newVerifierClient(vault)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
+	}
+	history, err := client.PolicyHistory(context.Background(), policyID, verifier.Page{})
+	if err != nil {
+		return fmt.Errorf("fetch history: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
+	return printResult(history, func() { printJSON(history) })
+}
 
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
+// policyAccount returns the account a policy is signed for: the --account/--index
+// flags if given, otherwise the "derivation" block of the policy file.
+func policyAccount(policyConfig map[string]interface{}, override *Account) (Account, error) {
+	if override != nil {
+		return *override, nil
+	}
 
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
+	raw, ok := policyConfig["derivation"]
+	if !ok || raw == nil {
+		return Account{}, nil
+	}
 
-	return nil
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return Account{}, fmt.Errorf("invalid 'derivation' in config file: %w", err)
+	}
+	var acct Account
+	err = json.Unmarshal(data, &acct)
+	if err != nil {
+		return Account{}, fmt.Errorf("invalid 'derivation' in config file: %w", err)
+	}
+	return acct, nil
 }
 
-func fillAddressesFromVault(recipeConfig map[string]interface{}, vault *LocalVault) (map[string]interface{}, error) {
+func fillAddressesFromVault(recipeConfig map[string]interface{}, vault *LocalVault, acct Account) (map[string]interface{}, error) {
 	fromAsset, hasFrom := recipeConfig["from"].(map[string]interface{})
 	toAsset, hasTo := recipeConfig["to"].(map[string]interface{})
 
@@ -904,16 +1020,7 @@ func fillAddressesFromVault(recipeConfig map[string]interface{}, vault *LocalVau
 			return "", fmt.Errorf("unknown chain: %s", chainStr)
 		}
 
-		pubKey := vault.PublicKeyECDSA
-		if chain == common.Solana {
-			pubKey = vault.PublicKeyEdDSA
-		}
-
-		addr, _, _, err := address.GetAddress(pubKey, vault.HexChainCode, chain)
-		if err != nil {
-			return "", fmt.Errorf("derive address for %s: %w", chainStr, err)
-		}
-		return addr, nil
+		return deriveVaultAddress(vault, chain, acct)
 	}
 
 	if hasFrom {
@@ -954,23 +1061,37 @@ func newPolicyStatusCmd() *cobra.Command {
 			return runPolicyStatus(args[0])
 		},
 	}
-	return cmd
+	return withStructuredOutput(cmd)
 }
 
 func newPolicyTransactionsCmd() *cobra.Command {
 	var limit int
+	var decode bool
 
 	cmd := &cobra.Command{
 		Use:   "transactions [policy-id]",
 		Short: "Show transactions for a policy",
-		Args:  cobra.ExactArgs(1),
+		Long: `Show the policy's latest transactions from the plugin's tx_indexer.
+
+With --decode, EVM transactions are decoded from the stored payload (or
+fetched from the chain RPC): native and ERC20 transfers, approvals and
+THORChain/MAYAChain router deposits with their swap memo. Mined
+transactions add gas used and the ERC20 amounts received from the receipt.
+The amount sent is checked against the policy's fromAmount (or the
+recipient's amount for sends).
+
+RPC URLs come from RPC_<CHAIN>_URL (e.g. RPC_ETHEREUM_URL) or public
+defaults.
+`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPolicyTransactions(args[0], limit)
+			return runPolicyTransactions(args[0], limit, decode)
 		},
 	}
 
 	cmd.Flags().IntVarP(&limit, "limit", "n", 10, "Number of transactions to show")
-	return cmd
+	cmd.Flags().BoolVar(&decode, "decode", false, "Decode calldata, memos, amounts and gas used")
+	return withStructuredOutput(cmd)
 }
 
 func newPolicyTriggerCmd() *cobra.Command {
@@ -985,103 +1106,130 @@ func newPolicyTriggerCmd() *cobra.Command {
 	return cmd
 }
 
+// PolicyStatus is the result of 'policy status'. NextExecution is empty
+// when the policy is not scheduled; Errors lists the lookups that failed.
+type PolicyStatus struct {
+	PolicyID      string     `json:"policy_id"`
+	Found         bool       `json:"found"`
+	Active        bool       `json:"active"`
+	CreatedAt     string     `json:"created_at,omitempty"`
+	NextExecution string     `json:"next_execution,omitempty"`
+	Transactions  []TxRecord `json:"transactions"`
+	Errors        []string   `json:"errors,omitempty"`
+}
+
 func runPolicyStatus(policyID string) error {
-	fmt.Printf("Policy Status: %s\n", policyID)
-	fmt.Println(strings.Repeat("=", 50))
-
-	policyActive, policyCreated := checkPolicyInDB(policyID)
-	fmt.Printf("\nPolicy Record:\n")
-	if policyCreated != "" {
-		fmt.Printf("  Active:  %v\n", policyActive)
-		fmt.Printf("  Created: %s\n", policyCreated)
-	} else {
-		fmt.Printf("  ✗ Not found in database\n")
+	status := PolicyStatus{PolicyID: policyID, Transactions: []TxRecord{}}
+	var err error
+	status.Active, status.CreatedAt, err = checkPolicyInDB(policyID)
+	if err != nil {
+		status.Errors = append(status.Errors, "policy record: "+err.Error())
 	}
-
-	nextExec := checkScheduler(policyID)
-	fmt.Printf("\nScheduler:\n")
-	if nextExec != "" {
-		fmt.Printf("  Next Execution: %s\n", nextExec)
+	status.Found = status.CreatedAt != ""
+	pluginID, err := policyPluginID(policyID)
+	if err != nil {
+		status.Errors = append(status.Errors, "plugin: "+err.Error())
 	} else {
-		fmt.Printf("  ✗ Not scheduled (policy may be inactive or one-time completed)\n")
+		status.NextExecution, err = pluginNextExecution(pluginID, policyID)
+		if err != nil {
+			status.Errors = append(status.Errors, "scheduler: "+err.Error())
+		}
+		txs, err := pluginTransactions(pluginID, policyID, 3)
+		if err != nil {
+			status.Errors = append(status.Errors, "transactions: "+err.Error())
+		}
+		status.Transactions = withExplorerURLs(policyID, txs)
 	}
 
-	fmt.Printf("\nRecent Transactions:\n")
-	txs := getRecentTransactions(policyID, 3)
-	if len(txs) == 0 {
-		fmt.Printf("  No transactions found\n")
-	} else {
-		for i, tx := range txs {
+	return printResult(status, func() {
+		fmt.Printf("Policy Status: %s\n", policyID)
+		fmt.Println(strings.Repeat("=", 50))
+		for _, e := range status.Errors {
+			fmt.Printf("⚠ %s\n", e)
+		}
+
+		fmt.Printf("\nPolicy Record:\n")
+		if status.Found {
+			fmt.Printf("  Active:  %v\n", status.Active)
+			fmt.Printf("  Created: %s\n", status.CreatedAt)
+			if !status.Active {
+				fmt.Printf("  (if paused, resume with: vcli policy resume %s)\n", policyID)
+			}
+		} else {
+			fmt.Printf("  ✗ Not found in database\n")
+		}
+
+		fmt.Printf("\nScheduler:\n")
+		if status.NextExecution != "" {
+			fmt.Printf("  Next Execution: %s\n", status.NextExecution)
+		} else {
+			fmt.Printf("  ✗ Not scheduled (policy may be inactive or one-time completed)\n")
+		}
+
+		fmt.Printf("\nRecent Transactions:\n")
+		if len(status.Transactions) == 0 {
+			fmt.Printf("  No transactions found\n")
+			return
+		}
+		for i, tx := range status.Transactions {
 			fmt.Printf("\n  Transaction %d:\n", i+1)
 			fmt.Printf("    TX Hash:    %s\n", tx.TxHash)
 			fmt.Printf("    Status:     %s\n", tx.Status)
 			fmt.Printf("    On-chain:   %s\n", tx.OnChainStatus)
 			fmt.Printf("    Created:    %s\n", tx.CreatedAt)
-			if tx.TxHash != "" && tx.TxHash != "<nil>" && tx.TxHash != "NULL" {
-				if explorerURL := getExplorerURL(policyID, tx.TxHash); explorerURL != "" {
-					fmt.Printf("    Explorer:   %s\n", explorerURL)
-				}
+			if tx.ExplorerURL != "" {
+				fmt.Printf("    Explorer:   %s\n", tx.ExplorerURL)
 			}
 		}
 		fmt.Println()
+	})
+}
+
+// withExplorerURLs fills in explorer links for transactions that have a hash.
+func withExplorerURLs(policyID string, txs []TxRecord) []TxRecord {
+	if txs == nil {
+		return []TxRecord{}
 	}
 
-	return nil
+	chain := ""
+	for i, tx := range txs {
+		if tx.TxHash == "" || tx.TxHash == "<nil>" || tx.TxHash == "NULL" {
+			continue
+		}
+		if chain == "" {
+			chain = getPolicyChain(policyID)
+			if chain == "" {
+				break
+			}
+		}
+		txs[i].ExplorerURL = getExplorerURLForChain(chain, tx.TxHash)
+	}
+	return txs
 }
 
-func getExplorerURL(policyID, txHash string) string {
-	// Try to fetch policy to determine chain
-	cfg, err := LoadConfig()
+// getPolicyChain returns the chain a policy transacts on, decoded from its
+// protobuf recipe, or "" if it can't be determined.
+func getPolicyChain(policyID string) string {
+	vault, err := ActiveVault()
 	if err != nil {
 		return ""
 	}
 
-	authHeader, err := GetAuthHeader()
+	// Best effort: never prompt or re-authenticate just for an explorer link
+	client, err := newStoredAuthClient(vault, verifier.WithTimeout(5*time.Second), verifier.WithRetries(0, 0))
 	if err != nil {
 		return ""
 	}
-
-	policyURL := fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, "GET", policyURL, nil)
+	policy, err := client.GetPolicy(context.Background(), policyID)
 	if err != nil {
 		return ""
 	}
-	req.Header.Set("Authorization", authHeader)
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil || resp.StatusCode != http.StatusOK {
+	recipe, err := decodeRecipe(policy.Recipe)
+	if err != nil {
 		return ""
 	}
-	defer resp.Body.Close()
-
-	var policyResp struct {
-		Data struct {
-			Recipe string `json:"recipe"`
-		} `json:"data"`
-	}
-	body, _ := io.ReadAll(resp.Body)
-	json.Unmarshal(body, &policyResp)
-
-	// Decode recipe to get chain info
-	if policyResp.Data.Recipe != "" {
-		recipeBytes, err := base64.StdEncoding.DecodeString(policyResp.Data.Recipe)
-		if err == nil {
-			// Try to parse as JSON to extract chain
-			var recipe map[string]interface{}
-			if json.Unmarshal(recipeBytes, &recipe) == nil {
-				if from, ok := recipe["from"].(map[string]interface{}); ok {
-					if chain, ok := from["chain"].(string); ok {
-						return getExplorerURLForChain(chain, txHash)
-					}
-				}
-			}
-		}
-	}
-
-	return ""
+	return policyChain(recipe)
 }
 
 func getExplorerURLForChain(chain, txHash string) string {
@@ -1110,59 +1258,86 @@ func getExplorerURLForChain(chain, txHash string) string {
 	}
 }
 
-func runPolicyTransactions(policyID string, limit int) error {
-	fmt.Printf("Transactions for Policy: %s\n", policyID)
-	fmt.Println(strings.Repeat("=", 60))
-
-	txs := getRecentTransactions(policyID, limit)
-	if len(txs) == 0 {
-		fmt.Println("\nNo transactions found for this policy.")
-		fmt.Println("\nPossible reasons:")
-		fmt.Println("  - Policy hasn't executed yet (check scheduler)")
-		fmt.Println("  - Policy is inactive")
-		fmt.Println("  - Scheduler hasn't picked it up (polls every 30s)")
-		return nil
+type PolicyTransactions struct {
+	PolicyID     string     `json:"policy_id"`
+	Transactions []TxRecord `json:"transactions"`
+}
+
+func runPolicyTransactions(policyID string, limit int, decode bool) error {
+	pluginID, err := policyPluginID(policyID)
+	if err != nil {
+		return err
+	}
+	txs, err := pluginTransactions(pluginID, policyID, limit)
+	if err != nil {
+		return fmt.Errorf("read transactions: %w", err)
+	}
+	if decode {
+		decodeTransactions(policyID, txs)
+	}
+	result := PolicyTransactions{
+		PolicyID:     policyID,
+		Transactions: withExplorerURLs(policyID, txs),
 	}
 
-	fmt.Printf("\nFound %d transactions:\n\n", len(txs))
-	for i, tx := range txs {
-		fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-		fmt.Printf("│ Transaction %d                                                  │\n", i+1)
-		fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-		fmt.Printf("│                                                                 │\n")
-		fmt.Printf("│  TX Hash:     %s\n", tx.TxHash)
-		fmt.Printf("│                                                                 │\n")
-		fmt.Printf("│  Status:      %-52s │\n", tx.Status)
-		fmt.Printf("│  On-chain:    %-52s │\n", tx.OnChainStatus)
-		fmt.Printf("│  Created:     %-52s │\n", tx.CreatedAt)
-		if tx.TxHash != "" && tx.TxHash != "<nil>" && tx.TxHash != "NULL" {
-			if explorerURL := getExplorerURL(policyID, tx.TxHash); explorerURL != "" {
+	return printResult(result, func() {
+		fmt.Printf("Transactions for Policy: %s\n", policyID)
+		fmt.Println(strings.Repeat("=", 60))
+
+		txs := result.Transactions
+		if len(txs) == 0 {
+			fmt.Println("\nNo transactions found for this policy.")
+			fmt.Println("\nPossible reasons:")
+			fmt.Println("  - Policy hasn't executed yet (check scheduler)")
+			fmt.Println("  - Policy is inactive")
+			fmt.Println("  - Scheduler hasn't picked it up (polls every 30s)")
+			return
+		}
+
+		fmt.Printf("\nFound %d transactions:\n\n", len(txs))
+		for i, tx := range txs {
+			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
+			fmt.Printf("│ Transaction %d                                                  │\n", i+1)
+			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
+			fmt.Printf("│                                                                 │\n")
+			fmt.Printf("│  TX Hash:     %s\n", tx.TxHash)
+			fmt.Printf("│                                                                 │\n")
+			fmt.Printf("│  Status:      %-52s │\n", tx.Status)
+			fmt.Printf("│  On-chain:    %-52s │\n", tx.OnChainStatus)
+			fmt.Printf("│  Created:     %-52s │\n", tx.CreatedAt)
+			if tx.ExplorerURL != "" {
+				fmt.Printf("│                                                                 │\n")
+				fmt.Printf("│  Explorer:   %s\n", tx.ExplorerURL)
+			}
+			if tx.Decoded != nil {
 				fmt.Printf("│                                                                 │\n")
-				fmt.Printf("│  Explorer:   %s\n", explorerURL)
+				printDecodedTx(tx.Decoded)
 			}
+			fmt.Printf("│                                                                 │\n")
+			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
+			fmt.Println()
 		}
-		fmt.Printf("│                                                                 │\n")
-		fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-		fmt.Println()
-	}
-
-	return nil
+	})
 }
 
 func runPolicyTrigger(policyID string) error {
 	fmt.Printf("Triggering policy: %s\n", policyID)
 
-	cmd := exec.Command("docker", "exec", "vultisig-postgres",
-		"psql", "-U", "vultisig", "-d", "vultisig-dca", "-c",
-		fmt.Sprintf("UPDATE scheduler SET next_execution = NOW() WHERE policy_id = '%s'", policyID))
-
-	output, err := cmd.CombinedOutput()
+	pluginID, err := policyPluginID(policyID)
+	if err != nil {
+		return err
+	}
+	var updated int64
+	err = withDB(pluginDB(pluginID), func(ctx context.Context, db *devdb.DB) error {
+		var err error
+		updated, err = db.TriggerNow(ctx, policyID)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update scheduler: %w\nOutput: %s", err, string(output))
+		return fmt.Errorf("trigger policy: %w", err)
 	}
 
-	result := strings.TrimSpace(string(output))
-	if strings.Contains(result, "UPDATE 0") {
+	if updated == 0 {
 		fmt.Println("⚠ Policy not found in scheduler table.")
 		fmt.Println("  This might mean:")
 		fmt.Println("  - Policy doesn't exist")
@@ -1180,82 +1355,14 @@ func runPolicyTrigger(policyID string) error {
 }
 
 type TxRecord struct {
-	TxHash        string
-	Status        string
-	OnChainStatus string
-	CreatedAt     string
-}
-
-func checkPolicyInDB(policyID string) (bool, string) {
-	cmd := exec.Command("docker", "exec", "vultisig-postgres",
-		"psql", "-U", "vultisig", "-d", "vultisig-verifier", "-t", "-c",
-		fmt.Sprintf("SELECT active, created_at FROM plugin_policies WHERE id = '%s' LIMIT 1", policyID))
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return false, ""
-	}
-
-	result := strings.TrimSpace(string(output))
-	if result == "" {
-		return false, ""
-	}
-
-	parts := strings.Split(result, "|")
-	if len(parts) < 2 {
-		return false, ""
-	}
-
-	active := strings.TrimSpace(parts[0]) == "t"
-	created := strings.TrimSpace(parts[1])
-	return active, created
-}
-
-func checkScheduler(policyID string) string {
-	cmd := exec.Command("docker", "exec", "vultisig-postgres",
-		"psql", "-U", "vultisig", "-d", "vultisig-dca", "-t", "-c",
-		fmt.Sprintf("SELECT next_execution FROM scheduler WHERE policy_id = '%s' LIMIT 1", policyID))
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return ""
-	}
-
-	return strings.TrimSpace(string(output))
-}
-
-func getRecentTransactions(policyID string, limit int) []TxRecord {
-	cmd := exec.Command("docker", "exec", "vultisig-postgres",
-		"psql", "-U", "vultisig", "-d", "vultisig-dca", "-t", "-c",
-		fmt.Sprintf(`SELECT tx_hash, status, status_onchain, created_at
-			FROM tx_indexer
-			WHERE policy_id = '%s'
-			ORDER BY created_at DESC
-			LIMIT %d`, policyID, limit))
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil
-	}
-
-	var txs []TxRecord
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		parts := strings.Split(line, "|")
-		if len(parts) < 4 {
-			continue
-		}
-		txs = append(txs, TxRecord{
-			TxHash:        strings.TrimSpace(parts[0]),
-			Status:        strings.TrimSpace(parts[1]),
-			OnChainStatus: strings.TrimSpace(parts[2]),
-			CreatedAt:     strings.TrimSpace(parts[3]),
-		})
-	}
-
-	return txs
+	ID            string     `json:"id,omitempty"`
+	TxHash        string     `json:"tx_hash"`
+	Status        string     `json:"status"`
+	OnChainStatus string     `json:"status_onchain"`
+	CreatedAt     string     `json:"created_at"`
+	ExplorerURL   string     `json:"explorer_url,omitempty"`
+	Decoded       *DecodedTx `json:"decoded,omitempty"`
+
+	chainID    int
+	proposedTx string
 }
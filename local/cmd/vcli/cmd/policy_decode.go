@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	rtypes "github.com/vultisig/recipes/types"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
+)
+
+func newPolicyDecodeCmd() *cobra.Command {
+	return withStructuredOutput(&cobra.Command{
+		Use:   "decode [policy-id|file]",
+		Short: "Decode a policy's protobuf recipe",
+		Long: `Show the protobuf recipe stored on the verifier in readable form: the
+configuration, each rule with its resource, effect and parameter constraints,
+the fee policies and the rate limit.
+
+The argument is a policy ID (fetched from the verifier) or a file containing
+either the base64 recipe or a policy JSON document with a "recipe" field
+(e.g. saved from 'vcli policy info -o json').
+
+Examples:
+  vcli policy decode <policy-id>
+  vcli policy decode policy.json -o json
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPolicyDecode(args[0])
+		},
+	})
+}
+
+type DecodedPolicy struct {
+	Source          string           `json:"source"`
+	ID              string           `json:"id"`
+	Name            string           `json:"name,omitempty"`
+	Description     string           `json:"description,omitempty"`
+	Version         int32            `json:"version,omitempty"`
+	Chain           string           `json:"chain,omitempty"`
+	Configuration   map[string]any   `json:"configuration"`
+	Rules           []DecodedRule    `json:"rules"`
+	FeePolicies     []DecodedFee     `json:"fee_policies"`
+	RateLimitWindow *uint32          `json:"rate_limit_window,omitempty"`
+	MaxTxsPerWindow *uint32          `json:"max_txs_per_window,omitempty"`
+	Policy          *verifier.Policy `json:"policy,omitempty"`
+}
+
+type DecodedRule struct {
+	ID          string              `json:"id,omitempty"`
+	Resource    string              `json:"resource"`
+	Effect      string              `json:"effect"`
+	Description string              `json:"description,omitempty"`
+	Target      string              `json:"target,omitempty"`
+	Constraints []DecodedConstraint `json:"constraints"`
+}
+
+type DecodedConstraint struct {
+	Parameter     string `json:"parameter"`
+	Type          string `json:"type"`
+	Value         string `json:"value,omitempty"`
+	DenominatedIn string `json:"denominated_in,omitempty"`
+	Period        string `json:"period,omitempty"`
+	Required      bool   `json:"required"`
+}
+
+type DecodedFee struct {
+	ID          string `json:"id,omitempty"`
+	Type        string `json:"type"`
+	Frequency   string `json:"frequency,omitempty"`
+	Amount      int64  `json:"amount"`
+	StartDate   string `json:"start_date,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func runPolicyDecode(arg string) error {
+	var recipe string
+	var stored *verifier.Policy
+
+	data, err := os.ReadFile(arg)
+	switch {
+	case err == nil:
+		recipe, err = recipeFromFile(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", arg, err)
+		}
+	case os.IsNotExist(err):
+		stored, err = fetchPolicy(arg)
+		if err != nil {
+			return err
+		}
+		recipe = stored.Recipe
+	default:
+		return fmt.Errorf("read %s: %w", arg, err)
+	}
+
+	policy, err := decodeRecipe(recipe)
+	if err != nil {
+		return err
+	}
+
+	decoded := decodePolicy(policy)
+	decoded.Source = arg
+	decoded.Policy = stored
+
+	return printResult(decoded, func() { printDecodedPolicy(decoded) })
+}
+
+// recipeFromFile accepts a bare base64 recipe or a JSON document with a
+// "recipe" field.
+func recipeFromFile(data []byte) (string, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(trimmed, "{") {
+		return trimmed, nil
+	}
+
+	var doc struct {
+		Recipe any `json:"recipe"`
+	}
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return "", fmt.Errorf("parse JSON: %w", err)
+	}
+	recipe, ok := doc.Recipe.(string)
+	if !ok {
+		return "", fmt.Errorf("no base64 \"recipe\" field (policy-add config files are not encoded; use 'policy add')")
+	}
+	return recipe, nil
+}
+
+func fetchPolicy(policyID string) (*verifier.Policy, error) {
+	vault, err := ActiveVault()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ensureAuthHeader(vault)
+	if err != nil {
+		return nil, fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import' first", err)
+	}
+
+	client, err := newVerifierClient(vault)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := client.GetPolicy(context.Background(), policyID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch policy: %w", err)
+	}
+	return policy, nil
+}
+
+// decodeRecipe unmarshals the base64 protobuf recipe stored on the verifier.
+func decodeRecipe(recipe string) (*rtypes.Policy, error) {
+	if recipe == "" {
+		return nil, fmt.Errorf("policy has no recipe")
+	}
+	recipeBytes, err := base64.StdEncoding.DecodeString(recipe)
+	if err != nil {
+		return nil, fmt.Errorf("decode recipe base64: %w", err)
+	}
+	var policy rtypes.Policy
+	err = proto.Unmarshal(recipeBytes, &policy)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal recipe: %w", err)
+	}
+	return &policy, nil
+}
+
+func decodePolicy(p *rtypes.Policy) DecodedPolicy {
+	decoded := DecodedPolicy{
+		ID:              p.GetId(),
+		Name:            p.GetName(),
+		Description:     p.GetDescription(),
+		Version:         p.GetVersion(),
+		Chain:           policyChain(p),
+		Configuration:   p.GetConfiguration().AsMap(),
+		Rules:           []DecodedRule{},
+		FeePolicies:     []DecodedFee{},
+		RateLimitWindow: p.RateLimitWindow,
+		MaxTxsPerWindow: p.MaxTxsPerWindow,
+	}
+
+	for _, r := range p.GetRules() {
+		rule := DecodedRule{
+			ID:          r.GetId(),
+			Resource:    r.GetResource(),
+			Effect:      strings.TrimPrefix(r.GetEffect().String(), "EFFECT_"),
+			Description: r.GetDescription(),
+			Target:      targetString(r.GetTarget()),
+			Constraints: []DecodedConstraint{},
+		}
+		for _, pc := range r.GetParameterConstraints() {
+			rule.Constraints = append(rule.Constraints, decodeConstraint(pc.GetParameterName(), pc.GetConstraint()))
+		}
+		// Legacy map constraints, in stable order
+		names := make([]string, 0, len(r.GetConstraints()))
+		for name := range r.GetConstraints() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			rule.Constraints = append(rule.Constraints, decodeConstraint(name, r.GetConstraints()[name]))
+		}
+		decoded.Rules = append(decoded.Rules, rule)
+	}
+
+	for _, f := range p.GetFeePolicies() {
+		fee := DecodedFee{
+			ID:          f.GetId(),
+			Type:        f.GetType().String(),
+			Amount:      f.GetAmount(),
+			Description: f.GetDescription(),
+		}
+		if f.GetFrequency() != rtypes.BillingFrequency_BILLING_FREQUENCY_UNSPECIFIED {
+			fee.Frequency = f.GetFrequency().String()
+		}
+		if f.GetStartDate() != nil {
+			fee.StartDate = f.GetStartDate().AsTime().Format(time.RFC3339)
+		}
+		decoded.FeePolicies = append(decoded.FeePolicies, fee)
+	}
+
+	return decoded
+}
+
+func decodeConstraint(name string, c *rtypes.Constraint) DecodedConstraint {
+	dc := DecodedConstraint{
+		Parameter:     name,
+		Type:          strings.TrimPrefix(c.GetType().String(), "CONSTRAINT_TYPE_"),
+		DenominatedIn: c.GetDenominatedIn(),
+		Period:        c.GetPeriod(),
+		Required:      c.GetRequired(),
+	}
+	switch v := c.GetValue().(type) {
+	case *rtypes.Constraint_FixedValue:
+		dc.Value = v.FixedValue
+	case *rtypes.Constraint_MaxValue:
+		dc.Value = v.MaxValue
+	case *rtypes.Constraint_MinValue:
+		dc.Value = v.MinValue
+	case *rtypes.Constraint_MagicConstantValue:
+		dc.Value = v.MagicConstantValue.String()
+	case *rtypes.Constraint_RegexpValue:
+		dc.Value = v.RegexpValue
+	}
+	return dc
+}
+
+func targetString(t *rtypes.Target) string {
+	switch v := t.GetTarget().(type) {
+	case *rtypes.Target_Address:
+		return v.Address
+	case *rtypes.Target_MagicConstant:
+		return v.MagicConstant.String()
+	}
+	return ""
+}
+
+// policyChain returns the source chain of a decoded recipe: the "from" (swap)
+// or "asset" (send) chain in the configuration, else the chain prefix of the
+// first rule's resource (e.g. "ethereum.erc20.transfer").
+func policyChain(p *rtypes.Policy) string {
+	config := p.GetConfiguration().AsMap()
+	for _, key := range []string{"from", "asset"} {
+		if m, ok := config[key].(map[string]any); ok {
+			if chain, ok := m["chain"].(string); ok && chain != "" {
+				return chain
+			}
+		}
+	}
+	if chain, ok := config["chain"].(string); ok && chain != "" {
+		return chain
+	}
+	for _, r := range p.GetRules() {
+		chain, _, _ := strings.Cut(r.GetResource(), ".")
+		if chain != "" {
+			return chain
+		}
+	}
+	return ""
+}
+
+func printDecodedPolicy(d DecodedPolicy) {
+	fmt.Printf("Policy Recipe: %s\n", d.Source)
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("  ID:      %s\n", d.ID)
+	if d.Name != "" {
+		fmt.Printf("  Name:    %s\n", d.Name)
+	}
+	if d.Version != 0 {
+		fmt.Printf("  Version: %d\n", d.Version)
+	}
+	if d.Chain != "" {
+		fmt.Printf("  Chain:   %s\n", d.Chain)
+	}
+	if d.Policy != nil {
+		fmt.Printf("  Active:  %v (policy v%d, plugin %s)\n", d.Policy.Active, d.Policy.PolicyVersion, d.Policy.PluginVersion)
+	}
+
+	fmt.Println("\nConfiguration:")
+	config, err := json.MarshalIndent(d.Configuration, "  ", "  ")
+	if err != nil {
+		fmt.Printf("  (unprintable: %v)\n", err)
+	} else {
+		fmt.Printf("  %s\n", config)
+	}
+
+	fmt.Printf("\nRules (%d):\n", len(d.Rules))
+	for i, r := range d.Rules {
+		fmt.Printf("\n  %d. %s [%s]\n", i+1, r.Resource, r.Effect)
+		if r.Description != "" {
+			fmt.Printf("     %s\n", r.Description)
+		}
+		if r.Target != "" {
+			fmt.Printf("     Target: %s\n", r.Target)
+		}
+		for _, c := range r.Constraints {
+			line := fmt.Sprintf("     - %s: %s", c.Parameter, c.Type)
+			if c.Value != "" {
+				line += " " + c.Value
+			}
+			if c.DenominatedIn != "" {
+				line += " (" + c.DenominatedIn + ")"
+			}
+			if c.Period != "" {
+				line += " per " + c.Period
+			}
+			if c.Required {
+				line += " [required]"
+			}
+			fmt.Println(line)
+		}
+	}
+
+	fmt.Println("\nFee Policies:")
+	if len(d.FeePolicies) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, f := range d.FeePolicies {
+		line := fmt.Sprintf("  - %s amount=%d", f.Type, f.Amount)
+		if f.Frequency != "" {
+			line += " frequency=" + f.Frequency
+		}
+		if f.StartDate != "" {
+			line += " start=" + f.StartDate
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Println("\nRate Limit:")
+	if d.RateLimitWindow == nil && d.MaxTxsPerWindow == nil {
+		fmt.Println("  (none)")
+	} else {
+		window, maxTxs := "-", "-"
+		if d.RateLimitWindow != nil {
+			window = fmt.Sprintf("%ds", *d.RateLimitWindow)
+		}
+		if d.MaxTxsPerWindow != nil {
+			maxTxs = fmt.Sprintf("%d", *d.MaxTxsPerWindow)
+		}
+		fmt.Printf("  Window: %s  Max txs per window: %s\n", window, maxTxs)
+	}
+	fmt.Println()
+}
@@ -2,24 +2,26 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/vultisig/vultisig-go/address"
+	"github.com/spf13/pflag"
 	"github.com/vultisig/vultisig-go/common"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
 )
 
-func newPolicyGenerateCmd() *cobra.Command {
-	var pluginID, from, to, amount, frequency, vaultName, toVaultName, output, routePreference string
+const policyGenerateLong = `Generate a complete policy JSON file with all addresses filled in.
 
-	cmd := &cobra.Command{
-		Use:   "generate",
-		Short: "Generate a complete policy configuration file",
-		Long: `Generate a complete policy JSON file with all addresses filled in.
+Each plugin has its own generator with its own flags, selected by --plugin
+(default: dca). Show a plugin's flags and examples with:
+  vcli policy generate --plugin <plugin> --help
 
 Asset shortcuts:
   eth, btc, sol, rune, bnb, avax, matic  - Native tokens
@@ -30,124 +32,94 @@ Asset shortcuts:
 Frequency options:
   one-time, minutely, hourly, daily, weekly, bi-weekly, monthly
 
-Route preference (for cross-chain swaps):
-  auto      - Default priority: THORChain → MayaChain → 1inch
-  thorchain - Use THORChain only
-  mayachain - Prefer MayaChain, fallback to THORChain
-
 Vault selection:
   --vault       Source vault (default: first imported vault)
-  --to-vault    Destination vault for sends (default: same as --vault)
 
-Examples:
-  # Swap ETH to USDC (same vault)
-  vcli policy generate --from eth --to usdc --amount 0.01
+Account selection (ECDSA chains only):
+  --account/--index        Source BIP44 account and address index (default: 0/0)
+  A non-default source account is recorded in the policy file so that
+  'policy add' fills addresses from the matching child key.
 
-  # Swap with explicit vault
-  vcli policy generate --from eth --to usdc --amount 0.01 --vault FastPlugin1
+Interactive mode (any plugin):
+  --interactive reads the plugin's recipe specification and prompts for each
+  field with its type, allowed values and default; the plugin's generator
+  flags are not used. Assets resolve like the shortcuts above and addresses
+  are derived from --vault and --account/--index.
 
-  # Swap ETH to ZEC using MayaChain
-  vcli policy generate --from eth --to zec --amount 0.01 --route mayachain
+  vcli policy generate --plugin sends --interactive --output-file sends.json
+`
 
-  # Send ETH from Plugin1 to Plugin2
-  vcli policy generate --from eth --to eth --amount 0.1 --vault Plugin1 --to-vault Plugin2
+func newPolicyGenerateCmd() *cobra.Command {
+	var pluginID, vaultName, output string
+	var acct Account
+	var interactive bool
+	// Flags shared by all plugins; the rest belong to a generator.
+	common := map[string]bool{}
 
-  # Output to file
-  vcli policy generate --from eth --to usdc --amount 0.01 --output swap.json
-`,
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a complete policy configuration file",
+		Long:  policyGenerateLong,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPolicyGenerate(pluginID, from, to, amount, frequency, vaultName, toVaultName, output, routePreference)
+			if interactive {
+				return runPolicyGenerateInteractive(ResolvePluginID(pluginID), vaultName, output, acct)
+			}
+			gen, err := lookupPolicyGenerator(pluginID)
+			if err != nil {
+				return err
+			}
+			// Global flags (--log-level, -o, ...) are inherited, not the generator's
+			flags, err := gen.bind(cmd.LocalFlags(), common)
+			if err != nil {
+				return err
+			}
+			return runPolicyGenerate(gen, pluginID, vaultName, output, acct, flags)
 		},
 	}
 
-	cmd.Flags().StringVar(&pluginID, "plugin", "dca", "Plugin ID or alias")
-	cmd.Flags().StringVar(&from, "from", "", "Source asset (required)")
-	cmd.Flags().StringVar(&to, "to", "", "Destination asset (required)")
-	cmd.Flags().StringVar(&amount, "amount", "", "Amount in human units (required)")
-	cmd.Flags().StringVar(&frequency, "frequency", "one-time", "Frequency: one-time, minutely, hourly, daily, weekly, bi-weekly, monthly")
+	cmd.Flags().StringVar(&pluginID, "plugin", "dca", "Plugin ID or alias (generators: "+strings.Join(generatorAliases(), ", ")+")")
 	cmd.Flags().StringVar(&vaultName, "vault", "", "Source vault name (default: first vault)")
-	cmd.Flags().StringVar(&toVaultName, "to-vault", "", "Destination vault name for sends (default: same as --vault)")
-	cmd.Flags().StringVar(&output, "output", "", "Output file (default: stdout)")
-	cmd.Flags().StringVar(&routePreference, "route", "auto", "Route preference: auto, thorchain, mayachain")
+	cmd.Flags().StringVar(&output, "output-file", "", "Output file (default: stdout)")
+	addAccountFlags(cmd, &acct)
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Prompt for each field of the plugin's recipe specification")
 
-	cmd.MarkFlagRequired("from")
-	cmd.MarkFlagRequired("to")
-	cmd.MarkFlagRequired("amount")
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		common[f.Name] = true
+	})
+	cmd.Flags().AddFlagSet(generatorFlagUsage())
+
+	// Show only the selected plugin's flags, with its usage and defaults.
+	defaultHelp := cmd.HelpFunc()
+	cmd.SetHelpFunc(func(c *cobra.Command, args []string) {
+		if gen, err := lookupPolicyGenerator(pluginID); err == nil {
+			own := gen.flagSet()
+			c.Flags().VisitAll(func(f *pflag.Flag) {
+				if common[f.Name] {
+					return
+				}
+				if o := own.Lookup(f.Name); o != nil {
+					f.Usage, f.DefValue = o.Usage, o.DefValue
+				} else {
+					f.Hidden = true
+				}
+			})
+			c.Long = policyGenerateLong + "\n" + gen.Help
+		}
+		defaultHelp(c, args)
+	})
 
-	return cmd
+	return withStructuredOutput(cmd)
 }
 
-func runPolicyGenerate(pluginID, from, to, amount, frequency, vaultName, toVaultName, output, routePreference string) error {
-	// Load source vault
-	fromVault, err := GetVaultByName(vaultName)
+func runPolicyGenerate(gen *policyGenerator, pluginID, vaultName, output string, acct Account, flags *pflag.FlagSet) error {
+	vault, err := GetVaultByName(vaultName)
 	if err != nil {
 		return err
 	}
 
-	// Load destination vault (defaults to source vault)
-	toVault := fromVault
-	if toVaultName != "" {
-		toVault, err = GetVaultByName(toVaultName)
-		if err != nil {
-			return err
-		}
-	}
-
-	// Resolve assets
-	fromAsset := ResolveAsset(from)
-	toAsset := ResolveAsset(to)
-
-	// Derive addresses
-	fromAddr, err := deriveAddressForChain(fromVault, fromAsset.Chain)
-	if err != nil {
-		return fmt.Errorf("derive from address: %w", err)
-	}
-
-	toAddr, err := deriveAddressForChain(toVault, toAsset.Chain)
-	if err != nil {
-		return fmt.Errorf("derive to address: %w", err)
-	}
-
-	// Convert amount
-	amountSmallest := ConvertToSmallestUnit(amount, fromAsset)
-
-	// Build recipe
-	recipe := map[string]any{
-		"from": map[string]string{
-			"chain":   fromAsset.Chain,
-			"token":   fromAsset.Token,
-			"address": fromAddr,
-		},
-		"to": map[string]string{
-			"chain":   toAsset.Chain,
-			"token":   toAsset.Token,
-			"address": toAddr,
-		},
-		"fromAmount": amountSmallest,
-		"frequency":  frequency,
-	}
-
-	if routePreference != "" && routePreference != "auto" {
-		recipe["routePreference"] = routePreference
-	}
-
-	// Validate recipe with plugin server
-	err = validateRecipeWithPlugin(pluginID, recipe)
-	if err != nil {
-		return fmt.Errorf("recipe validation failed: %w", err)
-	}
-
-	// Fetch plugin pricing to build matching billing entries
-	billing, err := fetchPluginBilling(pluginID)
+	policy, generated, err := generatePolicy(gen, pluginID, vault, acct, flags)
 	if err != nil {
-		// If we can't fetch pricing, use empty billing (plugin may not have pricing)
-		billing = []map[string]any{}
-	}
-
-	// Build policy with recipe and billing
-	policy := map[string]any{
-		"recipe":  recipe,
-		"billing": billing,
+		return err
 	}
 
 	jsonBytes, err := json.MarshalIndent(policy, "", "  ")
@@ -155,26 +127,29 @@ func runPolicyGenerate(pluginID, from, to, amount, frequency, vaultName, toVault
 		return fmt.Errorf("marshal policy: %w", err)
 	}
 
-	// Output
+	// Output; -o json|yaml prints the policy document even when it is saved
 	if output != "" {
 		err = os.WriteFile(output, jsonBytes, 0644)
 		if err != nil {
 			return fmt.Errorf("write file: %w", err)
 		}
 		fmt.Printf("Policy written to %s\n", output)
-	} else {
-		fmt.Println(string(jsonBytes))
+	}
+	err = printResult(policy, func() {
+		if output == "" {
+			fmt.Println(string(jsonBytes))
+		}
+	})
+	if err != nil {
+		return err
 	}
 
 	// Print summary
 	fmt.Fprintln(os.Stderr, "")
 	fmt.Fprintln(os.Stderr, "Policy Summary:")
-	fmt.Fprintf(os.Stderr, "  From: %s %s (%s)\n", amount, from, fromAsset.Chain)
-	fmt.Fprintf(os.Stderr, "        %s [%s]\n", fromAddr, fromVault.Name)
-	fmt.Fprintf(os.Stderr, "  To:   %s (%s)\n", to, toAsset.Chain)
-	fmt.Fprintf(os.Stderr, "        %s [%s]\n", toAddr, toVault.Name)
-	fmt.Fprintf(os.Stderr, "  Amount: %s (smallest unit)\n", amountSmallest)
-	fmt.Fprintf(os.Stderr, "  Frequency: %s\n", frequency)
+	for _, line := range generated.Summary {
+		fmt.Fprintf(os.Stderr, "  %s\n", line)
+	}
 
 	// Print next step
 	if output != "" {
@@ -185,24 +160,45 @@ func runPolicyGenerate(pluginID, from, to, amount, frequency, vaultName, toVault
 	return nil
 }
 
-func deriveAddressForChain(vault *LocalVault, chainName string) (string, error) {
-	chain, err := common.FromString(chainName)
+// generatePolicy builds a policy file document with gen: the recipe,
+// validated by the plugin server, and billing matching the plugin's pricing.
+func generatePolicy(gen *policyGenerator, pluginID string, vault *LocalVault, acct Account, flags *pflag.FlagSet) (map[string]any, *generatedRecipe, error) {
+	generated, err := gen.Build(&generateInput{Flags: flags, Vault: vault, Account: acct})
 	if err != nil {
-		return "", fmt.Errorf("unknown chain: %s", chainName)
+		return nil, nil, err
 	}
 
-	// Use EdDSA key for Solana, ECDSA for everything else
-	pubKey := vault.PublicKeyECDSA
-	if chain == common.Solana {
-		pubKey = vault.PublicKeyEdDSA
+	// Validate recipe with plugin server
+	err = validateRecipeWithPlugin(pluginID, generated.Recipe)
+	if err != nil {
+		return nil, nil, fmt.Errorf("recipe validation failed: %w", err)
 	}
 
-	addr, _, _, err := address.GetAddress(pubKey, vault.HexChainCode, chain)
+	// Fetch plugin pricing to build matching billing entries
+	billing, err := fetchPluginBilling(pluginID)
 	if err != nil {
-		return "", fmt.Errorf("derive address for %s: %w", chainName, err)
+		// If we can't fetch pricing, use empty billing (plugin may not have pricing)
+		billing = []map[string]any{}
 	}
 
-	return addr, nil
+	// Build policy with recipe and billing
+	policy := map[string]any{
+		"recipe":  generated.Recipe,
+		"billing": billing,
+	}
+	if !acct.IsDefault() {
+		policy["derivation"] = acct
+	}
+	return policy, generated, nil
+}
+
+func deriveAddressForChain(vault *LocalVault, chainName string, acct Account) (string, error) {
+	chain, err := common.FromString(chainName)
+	if err != nil {
+		return "", fmt.Errorf("unknown chain: %s", chainName)
+	}
+
+	return deriveVaultAddress(vault, chain, acct)
 }
 
 func validateRecipeWithPlugin(pluginID string, recipe map[string]any) error {
@@ -247,71 +243,53 @@ func extractErrorMessage(body []byte) string {
 // fetchPluginBilling fetches the plugin's pricing and converts it to billing entries.
 // The billing entries must match the plugin's pricing count for policy creation to succeed.
 // Uses uint64 for amount to match verifier's expected type.
-// Uses the public /plugins endpoint (no auth required) instead of /plugin/{id} (requires auth).
 func fetchPluginBilling(pluginID string) ([]map[string]any, error) {
-	cfg, err := LoadConfig()
+	pricing, err := fetchPluginPricing(pluginID)
 	if err != nil {
 		return nil, err
 	}
+	return billingFromPricing(pricing), nil
+}
 
-	resolvedID := ResolvePluginID(pluginID)
+// billingFromPricing converts plugin pricing to policy-file billing entries.
+func billingFromPricing(pricing []verifier.Pricing) []map[string]any {
+	billing := []map[string]any{}
+	for _, p := range pricing {
+		frequency := ""
+		if p.Frequency != nil {
+			frequency = *p.Frequency
+		}
+		entry := map[string]any{
+			"type":      p.Type,
+			"frequency": frequency,
+			"amount":    p.Amount,
+		}
+		billing = append(billing, entry)
+	}
 
-	url := fmt.Sprintf("%s/plugins", cfg.Verifier)
-	resp, err := http.Get(url)
+	return billing
+}
+
+// fetchPluginPricing returns the plugin's pricing entries, or none if the
+// plugin is not listed.
+// Uses the public /plugins endpoint (no auth required) instead of /plugin/{id} (requires auth).
+func fetchPluginPricing(pluginID string) ([]verifier.Pricing, error) {
+	client, err := newVerifierClient(nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch plugins: status %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Data struct {
-			Plugins []struct {
-				ID      string `json:"id"`
-				Pricing []struct {
-					Type      string  `json:"type"`
-					Frequency *string `json:"frequency"`
-					Amount    int64   `json:"amount"`
-					Asset     string  `json:"asset"`
-				} `json:"pricing"`
-			} `json:"plugins"`
-		} `json:"data"`
-	}
+	resolvedID := ResolvePluginID(pluginID)
 
-	err = json.NewDecoder(resp.Body).Decode(&result)
+	list, err := client.ListPlugins(context.Background(), verifier.Page{})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to fetch plugins: %w", err)
 	}
 
-	var targetPricing []struct {
-		Type      string  `json:"type"`
-		Frequency *string `json:"frequency"`
-		Amount    int64   `json:"amount"`
-		Asset     string  `json:"asset"`
-	}
-	for _, p := range result.Data.Plugins {
+	for _, p := range list.Plugins {
 		if p.ID == resolvedID {
-			targetPricing = p.Pricing
-			break
+			return p.Pricing, nil
 		}
 	}
-
-	var billing []map[string]any
-	for _, p := range targetPricing {
-		frequency := ""
-		if p.Frequency != nil {
-			frequency = *p.Frequency
-		}
-		entry := map[string]any{
-			"type":      p.Type,
-			"frequency": frequency,
-			"amount":    uint64(p.Amount),
-		}
-		billing = append(billing, entry)
-	}
-
-	return billing, nil
+	return nil, nil
 }
@@ -0,0 +1,446 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// policyGenerator builds the recipe of one plugin's policy files. Each
+// generator declares its own flags, so 'policy generate --plugin X --help'
+// shows X's fields only.
+type policyGenerator struct {
+	PluginID string
+	// Help is appended to the command's long help when this plugin is selected.
+	Help string
+	// Flags declares the plugin-specific flags on fs.
+	Flags func(fs *pflag.FlagSet)
+	// Required lists flags that must be set.
+	Required []string
+	// Build returns the recipe and summary lines for the policy.
+	Build func(in *generateInput) (*generatedRecipe, error)
+}
+
+// generateInput is what a generator builds from: the source vault and
+// account shared by all plugins, plus the generator's own flags.
+type generateInput struct {
+	Flags   *pflag.FlagSet
+	Vault   *LocalVault
+	Account Account
+}
+
+type generatedRecipe struct {
+	Recipe  map[string]any
+	Summary []string
+}
+
+var policyGenerators = []*policyGenerator{
+	dcaGenerator,
+	sendsGenerator,
+	feesGenerator,
+}
+
+// lookupPolicyGenerator returns the generator for a plugin ID or alias.
+func lookupPolicyGenerator(pluginID string) (*policyGenerator, error) {
+	resolved := ResolvePluginID(pluginID)
+	for _, g := range policyGenerators {
+		if g.PluginID == resolved {
+			return g, nil
+		}
+	}
+	return nil, fmt.Errorf("no policy generator for plugin %s (generators: %s); use --interactive to build the policy from its recipe specification",
+		pluginID, strings.Join(generatorAliases(), ", "))
+}
+
+// generatorAliases names the plugins that have a generator, by alias.
+func generatorAliases() []string {
+	var names []string
+	for _, g := range policyGenerators {
+		names = append(names, pluginAliasLabel(g.PluginID))
+	}
+	return names
+}
+
+// flagSet returns a fresh flag set holding the generator's flags with their
+// defaults.
+func (g *policyGenerator) flagSet() *pflag.FlagSet {
+	fs := pflag.NewFlagSet(g.PluginID, pflag.ContinueOnError)
+	g.Flags(fs)
+	return fs
+}
+
+// bind copies the flags set on the command line into the generator's own
+// flag set. Flags that belong to another plugin's generator are rejected.
+// cmdFlags are the command's local flags; global ones are not the generator's.
+func (g *policyGenerator) bind(cmdFlags *pflag.FlagSet, common map[string]bool) (*pflag.FlagSet, error) {
+	fs := g.flagSet()
+	var err error
+	// VisitAll with Changed: a copied flag set (LocalFlags) does not record
+	// which flags were set, so Visit would see none
+	cmdFlags.VisitAll(func(f *pflag.Flag) {
+		if err != nil || !f.Changed || common[f.Name] {
+			return
+		}
+		dst := fs.Lookup(f.Name)
+		if dst == nil {
+			err = fmt.Errorf("flag --%s is not used by plugin %s (see: vcli policy generate --plugin %s --help)", f.Name, g.PluginID, g.PluginID)
+			return
+		}
+		if src, ok := f.Value.(pflag.SliceValue); ok {
+			err = dst.Value.(pflag.SliceValue).Replace(src.GetSlice())
+		} else {
+			err = dst.Value.Set(f.Value.String())
+		}
+		dst.Changed = true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, name := range g.Required {
+		if !fs.Changed(name) {
+			missing = append(missing, `"`+name+`"`)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("required flag(s) %s not set (or use --interactive)", strings.Join(missing, ", "))
+	}
+	return fs, nil
+}
+
+// toAccount returns the destination account: --to-account/--to-index,
+// defaulting to the source account.
+func (in *generateInput) toAccount() Account {
+	acct := in.Account
+	if in.Flags.Changed("to-account") {
+		acct.Account, _ = in.Flags.GetUint32("to-account")
+	}
+	if in.Flags.Changed("to-index") {
+		acct.Index, _ = in.Flags.GetUint32("to-index")
+	}
+	return acct
+}
+
+// toVault returns --to-vault, defaulting to the source vault.
+func (in *generateInput) toVault() (*LocalVault, error) {
+	name, _ := in.Flags.GetString("to-vault")
+	if name == "" {
+		return in.Vault, nil
+	}
+	return GetVaultByName(name)
+}
+
+func addFrequencyFlag(fs *pflag.FlagSet, def string) {
+	fs.String("frequency", def, "Frequency: one-time, minutely, hourly, daily, weekly, bi-weekly, monthly")
+}
+
+func addToVaultFlags(fs *pflag.FlagSet, what string) {
+	fs.String("to-vault", "", what+" vault name (default: same as --vault)")
+	fs.Uint32("to-account", 0, what+" BIP44 account number (default: same as --account)")
+	fs.Uint32("to-index", 0, what+" BIP44 address index (default: same as --index)")
+}
+
+// assetRecipe is the {chain, token, address} object recipes use for assets.
+func assetRecipe(asset Asset, address string) map[string]string {
+	return map[string]string{
+		"chain":   asset.Chain,
+		"token":   asset.Token,
+		"address": address,
+	}
+}
+
+var dcaGenerator = &policyGenerator{
+	PluginID: "vultisig-dca-0000",
+	Help: `Recurring Swaps (dca):
+  Swaps --amount of --from into --to on every run. Sending to another vault
+  or account of the same asset is a swap with identical assets.
+
+Route preference (for cross-chain swaps):
+  auto      - Default priority: THORChain → MayaChain → 1inch
+  thorchain - Use THORChain only
+  mayachain - Prefer MayaChain, fallback to THORChain
+
+  # Swap ETH to USDC (same vault)
+  vcli policy generate --from eth --to usdc --amount 0.01
+
+  # Swap with explicit vault
+  vcli policy generate --from eth --to usdc --amount 0.01 --vault FastPlugin1
+
+  # Swap ETH to ZEC using MayaChain
+  vcli policy generate --from eth --to zec --amount 0.01 --route mayachain
+
+  # Daily swap into a second vault, written to a file
+  vcli policy generate --from eth --to usdc --amount 0.01 --frequency daily --to-vault Plugin2 --output-file swap.json
+`,
+	Flags: func(fs *pflag.FlagSet) {
+		fs.String("from", "", "Source asset")
+		fs.String("to", "", "Destination asset")
+		fs.String("amount", "", "Amount of --from per run, in human units")
+		addFrequencyFlag(fs, "one-time")
+		fs.String("route", "auto", "Route preference: auto, thorchain, mayachain")
+		addToVaultFlags(fs, "Destination")
+	},
+	Required: []string{"amount", "from", "to"},
+	Build:    buildDCARecipe,
+}
+
+func buildDCARecipe(in *generateInput) (*generatedRecipe, error) {
+	from, _ := in.Flags.GetString("from")
+	to, _ := in.Flags.GetString("to")
+	amount, _ := in.Flags.GetString("amount")
+	frequency, _ := in.Flags.GetString("frequency")
+	route, _ := in.Flags.GetString("route")
+
+	toVault, err := in.toVault()
+	if err != nil {
+		return nil, err
+	}
+	toAcct := in.toAccount()
+
+	fromAsset := ResolveAsset(from)
+	toAsset := ResolveAsset(to)
+
+	fromAddr, err := deriveAddressForChain(in.Vault, fromAsset.Chain, in.Account)
+	if err != nil {
+		return nil, fmt.Errorf("derive from address: %w", err)
+	}
+	toAddr, err := deriveAddressForChain(toVault, toAsset.Chain, toAcct)
+	if err != nil {
+		return nil, fmt.Errorf("derive to address: %w", err)
+	}
+
+	amountSmallest := ConvertToSmallestUnit(amount, fromAsset)
+
+	recipe := map[string]any{
+		"from":       assetRecipe(fromAsset, fromAddr),
+		"to":         assetRecipe(toAsset, toAddr),
+		"fromAmount": amountSmallest,
+		"frequency":  frequency,
+	}
+	if route != "" && route != "auto" {
+		recipe["routePreference"] = route
+	}
+
+	return &generatedRecipe{
+		Recipe: recipe,
+		Summary: []string{
+			fmt.Sprintf("From: %s %s (%s)", amount, from, fromAsset.Chain),
+			fmt.Sprintf("      %s [%s, %s]", fromAddr, in.Vault.Name, in.Account),
+			fmt.Sprintf("To:   %s (%s)", to, toAsset.Chain),
+			fmt.Sprintf("      %s [%s, %s]", toAddr, toVault.Name, toAcct),
+			fmt.Sprintf("Amount: %s (smallest unit)", amountSmallest),
+			fmt.Sprintf("Frequency: %s", frequency),
+		},
+	}, nil
+}
+
+var sendsGenerator = &policyGenerator{
+	PluginID: "vultisig-recurring-sends-0000",
+	Help: `Recurring Sends (sends):
+  Sends --asset from the vault to one or more recipients on every run.
+
+  --recipient ADDRESS[,amount=AMOUNT][,memo=TEXT]  (repeatable)
+    ADDRESS is any address on the asset's chain. Recipients without an
+    amount or memo use --amount and --memo.
+
+  Without --recipient the policy sends to the vault itself, at
+  --to-vault and --to-account/--to-index. Those flags also add the vault
+  as a recipient alongside --recipient entries.
+
+  # Send 0.1 ETH monthly to an external address
+  vcli policy generate --plugin sends --asset eth --amount 0.1 --recipient 0x1234...abcd
+
+  # Split USDC between two recipients, with memos
+  vcli policy generate --plugin sends --asset usdc --frequency weekly \
+    --recipient 0x1234...abcd,amount=25,memo=rent \
+    --recipient 0x9876...ef01,amount=10,memo=gym
+
+  # Send ETH from account 0 to account 1 of the same vault
+  vcli policy generate --plugin sends --asset eth --amount 0.1 --to-account 1
+`,
+	Flags: func(fs *pflag.FlagSet) {
+		fs.String("asset", "", "Asset to send")
+		fs.String("amount", "", "Amount per recipient in human units (default for --recipient entries)")
+		fs.StringArray("recipient", nil, "Recipient ADDRESS[,amount=AMOUNT][,memo=TEXT] (repeatable)")
+		fs.String("memo", "", "Memo for recipients without their own")
+		addFrequencyFlag(fs, "monthly")
+		addToVaultFlags(fs, "Recipient")
+	},
+	Required: []string{"asset"},
+	Build:    buildSendsRecipe,
+}
+
+// sendRecipient is one --recipient entry.
+type sendRecipient struct {
+	Address string
+	Amount  string
+	Memo    string
+}
+
+func parseSendRecipient(s string) (sendRecipient, error) {
+	parts := strings.Split(s, ",")
+	r := sendRecipient{Address: strings.TrimSpace(parts[0])}
+	if r.Address == "" {
+		return r, fmt.Errorf("invalid --recipient %q: missing address", s)
+	}
+	for _, part := range parts[1:] {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return r, fmt.Errorf("invalid --recipient %q: expected key=value, got %q", s, part)
+		}
+		switch strings.TrimSpace(key) {
+		case "amount":
+			r.Amount = strings.TrimSpace(value)
+		case "memo":
+			r.Memo = value
+		default:
+			return r, fmt.Errorf("invalid --recipient %q: unknown key %q (expected amount or memo)", s, key)
+		}
+	}
+	return r, nil
+}
+
+func buildSendsRecipe(in *generateInput) (*generatedRecipe, error) {
+	assetName, _ := in.Flags.GetString("asset")
+	amount, _ := in.Flags.GetString("amount")
+	memo, _ := in.Flags.GetString("memo")
+	frequency, _ := in.Flags.GetString("frequency")
+	entries, _ := in.Flags.GetStringArray("recipient")
+
+	asset := ResolveAsset(assetName)
+	fromAddr, err := deriveAddressForChain(in.Vault, asset.Chain, in.Account)
+	if err != nil {
+		return nil, fmt.Errorf("derive from address: %w", err)
+	}
+
+	var recipients []sendRecipient
+	for _, e := range entries {
+		r, err := parseSendRecipient(e)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, r)
+	}
+
+	var vaultLabel string
+	toVaultSet := in.Flags.Changed("to-vault") || in.Flags.Changed("to-account") || in.Flags.Changed("to-index")
+	if len(recipients) == 0 || toVaultSet {
+		toVault, err := in.toVault()
+		if err != nil {
+			return nil, err
+		}
+		toAcct := in.toAccount()
+		addr, err := deriveAddressForChain(toVault, asset.Chain, toAcct)
+		if err != nil {
+			return nil, fmt.Errorf("derive recipient address: %w", err)
+		}
+		recipients = append(recipients, sendRecipient{Address: addr})
+		vaultLabel = fmt.Sprintf(" [%s, %s]", toVault.Name, toAcct)
+	}
+
+	var list []any
+	summary := []string{
+		fmt.Sprintf("Asset: %s (%s)", assetName, asset.Chain),
+		fmt.Sprintf("       %s [%s, %s]", fromAddr, in.Vault.Name, in.Account),
+	}
+	for i, r := range recipients {
+		path := fmt.Sprintf("--recipient %s", r.Address)
+		if r.Amount == "" {
+			r.Amount = amount
+		}
+		if r.Amount == "" {
+			return nil, fmt.Errorf("%s: no amount (set amount= or --amount)", path)
+		}
+		if r.Memo == "" {
+			r.Memo = memo
+		}
+		problems, checked := validateAddress(r.Address, asset.Chain, path)
+		if len(problems) > 0 {
+			return nil, fmt.Errorf("%s", problems[0].Message)
+		}
+
+		entry := map[string]any{
+			"toAddress": r.Address,
+			"amount":    ConvertToSmallestUnit(r.Amount, asset),
+		}
+		if r.Memo != "" {
+			entry["memo"] = r.Memo
+		}
+		list = append(list, entry)
+
+		label := ""
+		if i == len(recipients)-1 {
+			label = vaultLabel
+		}
+		line := fmt.Sprintf("To:    %s %s%s", r.Amount, r.Address, label)
+		if r.Memo != "" {
+			line += fmt.Sprintf(" (memo %q)", r.Memo)
+		}
+		if !checked {
+			line += " (" + uncheckedAddress(asset.Chain) + ")"
+		}
+		summary = append(summary, line)
+	}
+	summary = append(summary, fmt.Sprintf("Frequency: %s", frequency))
+
+	return &generatedRecipe{
+		Recipe: map[string]any{
+			"asset":      assetRecipe(asset, fromAddr),
+			"recipients": list,
+			"frequency":  frequency,
+		},
+		Summary: summary,
+	}, nil
+}
+
+var feesGenerator = &policyGenerator{
+	PluginID: "vultisig-fees-feee",
+	Help: `Vultisig Fees (fees):
+  Authorizes the fee plugin to collect fees from the vault in --asset,
+  paid to the Vultisig treasury. The verifier's default fee policy
+  collects USDC on Ethereum.
+
+  vcli policy generate --plugin fees --output-file fees.json
+`,
+	Flags: func(fs *pflag.FlagSet) {
+		fs.String("asset", "usdc", "Asset fees are paid in")
+	},
+	Build: buildFeesRecipe,
+}
+
+func buildFeesRecipe(in *generateInput) (*generatedRecipe, error) {
+	assetName, _ := in.Flags.GetString("asset")
+	asset := ResolveAsset(assetName)
+
+	addr, err := deriveAddressForChain(in.Vault, asset.Chain, in.Account)
+	if err != nil {
+		return nil, fmt.Errorf("derive fee address: %w", err)
+	}
+
+	return &generatedRecipe{
+		Recipe: map[string]any{
+			"asset": assetRecipe(asset, addr),
+		},
+		Summary: []string{
+			fmt.Sprintf("Fee asset: %s (%s)", assetName, asset.Chain),
+			fmt.Sprintf("           %s [%s, %s]", addr, in.Vault.Name, in.Account),
+		},
+	}, nil
+}
+
+// generatorFlagUsage returns the flags of every generator for the command's
+// flag set. Generators that share a flag name share one entry; the values
+// are copied into each generator's own flag set by bind.
+func generatorFlagUsage() *pflag.FlagSet {
+	all := pflag.NewFlagSet("generators", pflag.ContinueOnError)
+	for _, g := range policyGenerators {
+		g.flagSet().VisitAll(func(f *pflag.Flag) {
+			if all.Lookup(f.Name) == nil {
+				all.AddFlag(f)
+			}
+		})
+	}
+	return all
+}
@@ -0,0 +1,551 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vultisig/vcli/local/pkg/schema"
+)
+
+// specPrompter builds a recipe by prompting for each field of a plugin's
+// configuration schema. Prompts go to stderr so the policy can be written to
+// stdout.
+type specPrompter struct {
+	in     *bufio.Reader
+	root   map[string]any
+	vault  *LocalVault
+	acct   Account
+	assets map[string]Asset
+	last   *Asset
+}
+
+func runPolicyGenerateInteractive(pluginID, vaultName, output string, acct Account) error {
+	vault, err := GetVaultByName(vaultName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Loading recipe specification for %s...\n", pluginID)
+	spec, source, err := loadPluginSpec(pluginID, false)
+	if err != nil {
+		return err
+	}
+	configSchema, supportedChains, err := parseRecipeSpec(spec.RecipeSpecification)
+	if err != nil {
+		return err
+	}
+	if configSchema == nil {
+		return fmt.Errorf("plugin %s has no configuration schema", pluginID)
+	}
+	fmt.Fprintf(os.Stderr, "  Specification: %s\n", source)
+	fmt.Fprintf(os.Stderr, "  Vault: %s [%s]\n", vault.Name, acct)
+	if len(supportedChains) > 0 {
+		fmt.Fprintf(os.Stderr, "  Supported chains: %s\n", strings.Join(supportedChains, ", "))
+	}
+	fmt.Fprintln(os.Stderr, "\nAssets accept shortcuts (eth, usdc, usdc:arbitrum, base.eth); amounts are in human units.")
+	fmt.Fprintln(os.Stderr, "Press Enter to accept [defaults] or skip optional fields.")
+
+	p := &specPrompter{
+		in:     bufio.NewReader(os.Stdin),
+		root:   configSchema,
+		vault:  vault,
+		acct:   acct,
+		assets: map[string]Asset{},
+	}
+	recipe, err := p.object(configSchema, "")
+	if err != nil {
+		return err
+	}
+
+	violations := schema.Validate(configSchema, recipe, "$.recipe")
+	valueViolations, unchecked := validateRecipeValues(recipe, supportedChains)
+	violations = append(violations, valueViolations...)
+	for _, v := range unchecked {
+		fmt.Fprintf(os.Stderr, "  Note: %s\n", v)
+	}
+	if len(violations) > 0 {
+		fmt.Fprintf(os.Stderr, "\nThe recipe has %d problem(s):\n", len(violations))
+		for _, v := range violations {
+			fmt.Fprintf(os.Stderr, "  %s\n", v)
+		}
+		ok, err := p.confirm("Write it anyway?", false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("recipe is invalid: %d problem(s)", len(violations))
+		}
+	}
+
+	policy := map[string]any{
+		"recipe":  recipe,
+		"billing": billingFromPricing(spec.Pricing),
+	}
+	if !acct.IsDefault() {
+		policy["derivation"] = acct
+	}
+
+	jsonBytes, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal policy: %w", err)
+	}
+
+	if output == "" {
+		return printResult(policy, func() {
+			fmt.Println(string(jsonBytes))
+		})
+	}
+	err = os.WriteFile(output, jsonBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	err = printResult(policy, func() {})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "\nPolicy written to %s\n", output)
+	fmt.Fprintf(os.Stderr, "Next: vcli policy add --plugin %s --policy-file %s --password <password>\n", pluginID, output)
+	return nil
+}
+
+// object prompts for the properties of an object schema: required ones in
+// the order the schema lists them, then optional ones alphabetically.
+func (p *specPrompter) object(s map[string]any, path string) (map[string]any, error) {
+	props, _ := s["properties"].(map[string]any)
+	required := map[string]bool{}
+	var order []string
+	if req, ok := s["required"].([]any); ok {
+		for _, r := range req {
+			name, _ := r.(string)
+			if _, ok := props[name]; ok && !required[name] {
+				required[name] = true
+				order = append(order, name)
+			}
+		}
+	}
+	var optional []string
+	for name := range props {
+		if !required[name] {
+			optional = append(optional, name)
+		}
+	}
+	sort.Strings(optional)
+	order = append(order, optional...)
+
+	result := map[string]any{}
+	for _, name := range order {
+		fs, ok := props[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		fs, err := schema.Deref(p.root, fs)
+		if err != nil {
+			return nil, err
+		}
+		value, set, err := p.field(name, joinPath(path, name), fs, required[name], result)
+		if err != nil {
+			return nil, err
+		}
+		if set {
+			result[name] = value
+		}
+	}
+	return result, nil
+}
+
+// field prompts for one value. siblings holds the fields of the enclosing
+// object entered so far, used for address defaults.
+func (p *specPrompter) field(name, path string, s map[string]any, required bool, siblings map[string]any) (any, bool, error) {
+	typ := schemaType(s)
+	def, hasDefault := s["default"]
+
+	header := fmt.Sprintf("\n%s (%s", path, typ)
+	if required {
+		header += ", required)"
+	} else {
+		header += ", optional)"
+	}
+	if desc, ok := s["description"].(string); ok && desc != "" {
+		header += " - " + desc
+	}
+
+	if typ == "object" {
+		if !required {
+			fmt.Fprint(os.Stderr, header+"\n")
+			ok, err := p.confirm("  Configure "+path+"?", false)
+			if err != nil || !ok {
+				return nil, false, err
+			}
+		}
+		if isAssetSchema(s) {
+			asset, err := p.asset(path, s)
+			return asset, err == nil, err
+		}
+		fmt.Fprintf(os.Stderr, "\n%s:\n", path)
+		obj, err := p.object(s, path)
+		return obj, err == nil, err
+	}
+
+	if typ == "array" {
+		fmt.Fprint(os.Stderr, header+"\n")
+		return p.array(path, s, required)
+	}
+
+	fmt.Fprint(os.Stderr, header+"\n")
+
+	if enum, ok := s["enum"].([]any); ok && len(enum) > 0 {
+		return p.enum(enum, def, hasDefault, required)
+	}
+
+	lower := strings.ToLower(name)
+	var hint string
+	switch {
+	case typ == "boolean":
+		d := hasDefault && def == true
+		v, err := p.confirm("  "+name+"?", d)
+		return v, err == nil, err
+	case strings.HasSuffix(lower, "address"):
+		if addr := p.defaultAddress(siblings); addr != "" {
+			def, hasDefault = addr, true
+			hint = " (vault address)"
+		}
+	case strings.HasSuffix(lower, "amount"):
+		if asset := p.assetFor(name); asset != nil {
+			hint = fmt.Sprintf(" in %s units", describeAsset(*asset))
+			if hasDefault {
+				hasDefault = false // the default is in the smallest unit
+			}
+		}
+	}
+
+	for {
+		input, err := p.line(fmt.Sprintf("  %s%s%s: ", name, hint, defaultSuffix(def, hasDefault)))
+		if err != nil {
+			return nil, false, err
+		}
+		if input == "" {
+			if hasDefault {
+				return def, true, nil
+			}
+			if !required {
+				return nil, false, nil
+			}
+			fmt.Fprintln(os.Stderr, "  A value is required")
+			continue
+		}
+
+		value, err := p.parse(name, typ, input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %v\n", err)
+			continue
+		}
+		if problems := schema.Validate(s, value, path); len(problems) > 0 {
+			for _, v := range problems {
+				fmt.Fprintf(os.Stderr, "  %s\n", v.Message)
+			}
+			continue
+		}
+		return value, true, nil
+	}
+}
+
+func (p *specPrompter) parse(name, typ, input string) (any, error) {
+	switch typ {
+	case "integer":
+		n, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", input)
+		}
+		return float64(n), nil
+	case "number":
+		f, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", input)
+		}
+		return f, nil
+	}
+
+	if strings.HasSuffix(strings.ToLower(name), "amount") {
+		if asset := p.assetFor(name); asset != nil {
+			if _, err := strconv.ParseFloat(input, 64); err != nil {
+				return nil, fmt.Errorf("%q is not an amount", input)
+			}
+			return ConvertToSmallestUnit(input, *asset), nil
+		}
+	}
+	return input, nil
+}
+
+func (p *specPrompter) enum(enum []any, def any, hasDefault, required bool) (any, bool, error) {
+	for i, e := range enum {
+		marker := ""
+		if hasDefault && e == def {
+			marker = " (default)"
+		}
+		fmt.Fprintf(os.Stderr, "  [%d] %v%s\n", i+1, e, marker)
+	}
+	for {
+		input, err := p.line("  Choice: ")
+		if err != nil {
+			return nil, false, err
+		}
+		if input == "" {
+			if hasDefault {
+				return def, true, nil
+			}
+			if !required {
+				return nil, false, nil
+			}
+			fmt.Fprintln(os.Stderr, "  A value is required")
+			continue
+		}
+		if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= len(enum) {
+			return enum[n-1], true, nil
+		}
+		for _, e := range enum {
+			if strings.EqualFold(fmt.Sprint(e), input) {
+				return e, true, nil
+			}
+		}
+		fmt.Fprintf(os.Stderr, "  Choose 1-%d or one of the listed values\n", len(enum))
+	}
+}
+
+// asset prompts for an asset shortcut and fills chain, token and address
+// from ResolveAsset and vault derivation; other properties are prompted.
+func (p *specPrompter) asset(path string, s map[string]any) (map[string]any, error) {
+	var asset Asset
+	for {
+		input, err := p.line(fmt.Sprintf("\n%s asset: ", path))
+		if err != nil {
+			return nil, err
+		}
+		if input == "" {
+			fmt.Fprintln(os.Stderr, "  An asset is required (e.g. eth, usdc, usdc:arbitrum)")
+			continue
+		}
+		asset = ResolveAsset(input)
+		if asset.Chain == "" {
+			fmt.Fprintf(os.Stderr, "  Unknown asset %q\n", input)
+			continue
+		}
+		break
+	}
+	p.assets[path] = asset
+	p.last = &asset
+
+	result := map[string]any{}
+	props, _ := s["properties"].(map[string]any)
+	if _, ok := props["chain"]; ok {
+		result["chain"] = asset.Chain
+	}
+	if _, ok := props["token"]; ok {
+		result["token"] = asset.Token
+	}
+	if _, ok := props["address"]; ok {
+		addr, err := deriveAddressForChain(p.vault, asset.Chain, p.acct)
+		if err != nil {
+			return nil, fmt.Errorf("derive %s address: %w", path, err)
+		}
+		result["address"] = addr
+	}
+	if addr, ok := result["address"].(string); ok {
+		fmt.Fprintf(os.Stderr, "  -> %s, vault address %s\n", describeAsset(asset), addr)
+	} else {
+		fmt.Fprintf(os.Stderr, "  -> %s\n", describeAsset(asset))
+	}
+
+	rest := map[string]any{"type": "object", "properties": map[string]any{}}
+	restProps := rest["properties"].(map[string]any)
+	for name, ps := range props {
+		if _, done := result[name]; !done {
+			restProps[name] = ps
+		}
+	}
+	if req, ok := s["required"]; ok {
+		rest["required"] = req
+	}
+	if len(restProps) > 0 {
+		more, err := p.object(rest, path)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range more {
+			result[k] = v
+		}
+	}
+	return result, nil
+}
+
+// array prompts for primitive items as a comma-separated list and for object
+// items one at a time.
+func (p *specPrompter) array(path string, s map[string]any, required bool) (any, bool, error) {
+	items, _ := s["items"].(map[string]any)
+	items, err := schema.Deref(p.root, items)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if schemaType(items) == "object" {
+		var list []any
+		for i := 0; ; i++ {
+			ok, err := p.confirm(fmt.Sprintf("  Add %s[%d]?", path, i), required && i == 0)
+			if err != nil {
+				return nil, false, err
+			}
+			if !ok {
+				break
+			}
+			item, err := p.object(items, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, false, err
+			}
+			list = append(list, item)
+		}
+		return list, len(list) > 0 || required, nil
+	}
+
+	for {
+		input, err := p.line("  Values (comma-separated): ")
+		if err != nil {
+			return nil, false, err
+		}
+		if input == "" && !required {
+			return nil, false, nil
+		}
+		list := []any{}
+		bad := false
+		for _, part := range strings.Split(input, ",") {
+			v, err := p.parse(path, schemaType(items), strings.TrimSpace(part))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "  %v\n", err)
+				bad = true
+				break
+			}
+			list = append(list, v)
+		}
+		if bad {
+			continue
+		}
+		if problems := schema.Validate(s, list, path); len(problems) > 0 {
+			for _, v := range problems {
+				fmt.Fprintf(os.Stderr, "  %s\n", v)
+			}
+			continue
+		}
+		return list, true, nil
+	}
+}
+
+// assetFor returns the asset an amount field refers to: the asset whose
+// field name prefixes it ("fromAmount" -> "from"), else the last asset.
+func (p *specPrompter) assetFor(name string) *Asset {
+	prefix := strings.TrimSuffix(strings.TrimSuffix(name, "Amount"), "_amount")
+	for path, asset := range p.assets {
+		if path == prefix || strings.HasSuffix(path, "."+prefix) {
+			a := asset
+			return &a
+		}
+	}
+	return p.last
+}
+
+// defaultAddress derives the vault address for the chain of the enclosing
+// object, or of the last asset entered.
+func (p *specPrompter) defaultAddress(siblings map[string]any) string {
+	chain, _ := siblings["chain"].(string)
+	if chain == "" && p.last != nil {
+		chain = p.last.Chain
+	}
+	if chain == "" {
+		return ""
+	}
+	addr, err := deriveAddressForChain(p.vault, chain, p.acct)
+	if err != nil {
+		return ""
+	}
+	return addr
+}
+
+func (p *specPrompter) line(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	input, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || input == "") {
+		if err == io.EOF {
+			return "", fmt.Errorf("input closed before the policy was complete")
+		}
+		return "", err
+	}
+	return strings.TrimSpace(input), nil
+}
+
+func (p *specPrompter) confirm(prompt string, defaultYes bool) (bool, error) {
+	suffix := " [y/N]: "
+	if defaultYes {
+		suffix = " [Y/n]: "
+	}
+	input, err := p.line(prompt + suffix)
+	if err != nil {
+		return false, err
+	}
+	if input == "" {
+		return defaultYes, nil
+	}
+	input = strings.ToLower(input)
+	return input == "y" || input == "yes", nil
+}
+
+// isAssetSchema reports whether an object schema describes an asset, i.e.
+// has a "chain" property.
+func isAssetSchema(s map[string]any) bool {
+	props, _ := s["properties"].(map[string]any)
+	_, ok := props["chain"]
+	return ok
+}
+
+func schemaType(s map[string]any) string {
+	switch t := s["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, x := range t {
+			if name, ok := x.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	if _, ok := s["properties"]; ok {
+		return "object"
+	}
+	if _, ok := s["enum"]; ok {
+		return "enum"
+	}
+	return "string"
+}
+
+func defaultSuffix(def any, hasDefault bool) string {
+	if !hasDefault {
+		return ""
+	}
+	return fmt.Sprintf(" [%v]", def)
+}
+
+func describeAsset(a Asset) string {
+	if a.Token == "" {
+		return a.Chain + " native"
+	}
+	return a.Chain + " token " + a.Token
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,529 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Swap statuses in the matrix results.
+const (
+	matrixSuccess   = "SUCCESS"
+	matrixFail      = "FAIL"
+	matrixError     = "ERROR"
+	matrixTimeout   = "TIMEOUT"
+	matrixGenerated = "GENERATED"
+)
+
+const matrixPollInterval = 15 * time.Second
+
+func newPolicyMatrixCmd() *cobra.Command {
+	var assetsFile, route, outDir, password string
+	var concurrency int
+	var timeout time.Duration
+	var dryRun bool
+	var acct Account
+
+	cmd := &cobra.Command{
+		Use:   "matrix",
+		Short: "Run bidirectional swaps between a hub asset and a list of assets",
+		Long: `Run the swap matrix: for every asset in --assets that supports --route,
+swap the hub asset into it (forward phase), then swap it back (reverse
+phase, started once the forward phase has finished so the funds exist).
+
+Each swap is a one-time DCA policy generated like 'policy generate', added
+like 'policy add' (up to --concurrency at a time), then monitored until its
+transaction reaches a terminal on-chain status in tx_indexer or --timeout
+passes.
+
+The assets file (YAML or JSON):
+  hub: eth              # Asset every other asset is swapped against
+  amount: "0.01"        # Hub amount per forward swap
+  assets:
+    - asset: usdc
+      amount: "30"      # Amount per reverse swap
+      routes: [thorchain, mayachain]   # Default: all routes
+    - asset: btc
+      amount: "0.0003"
+
+Results are written to --out-dir:
+  policies/    Generated policy files
+  results.md   Markdown table with tx hashes, explorer links, errors, durations
+  results.json The same as JSON
+
+Examples:
+  vcli policy matrix --assets local/swap-matrix-assets.yaml --route thorchain
+  vcli policy matrix --assets local/swap-matrix-assets.yaml --route mayachain --concurrency 2
+  vcli policy matrix --assets local/swap-matrix-assets.yaml --route thorchain --dry-run
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if route != "thorchain" && route != "mayachain" {
+				return fmt.Errorf("invalid --route %q: expected thorchain or mayachain", route)
+			}
+			if concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1")
+			}
+			actualPassword := password
+			if envPass := os.Getenv("VAULT_PASSWORD"); envPass != "" {
+				actualPassword = envPass
+			}
+			if actualPassword == "" && !dryRun {
+				var err error
+				actualPassword, err = promptPassword("", "Enter Fast Vault password: ")
+				if err != nil {
+					return err
+				}
+			}
+			if outDir == "" {
+				outDir = fmt.Sprintf("swap-matrix-%s-%s", route, time.Now().Format("20060102-150405"))
+			}
+			return runPolicyMatrix(matrixOptions{
+				assetsFile:  assetsFile,
+				route:       route,
+				outDir:      outDir,
+				password:    actualPassword,
+				concurrency: concurrency,
+				timeout:     timeout,
+				dryRun:      dryRun,
+				account:     acct,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&assetsFile, "assets", "", "Assets file (YAML or JSON, required)")
+	cmd.Flags().StringVar(&route, "route", "", "Route: thorchain or mayachain (required)")
+	cmd.Flags().StringVar(&outDir, "out-dir", "", "Directory for policies and results (default: swap-matrix-<route>-<time>)")
+	cmd.Flags().StringVar(&password, "password", "", "Fast Vault password (or set VAULT_PASSWORD env var)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Policies added and monitored at a time")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "Time to wait for each swap's transaction to reach a terminal status")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Generate the policy files only; don't add them")
+	addAccountFlags(cmd, &acct)
+	cmd.MarkFlagRequired("assets")
+	cmd.MarkFlagRequired("route")
+
+	return withStructuredOutput(cmd)
+}
+
+// matrixAssets is the --assets file.
+type matrixAssets struct {
+	Hub    string        `yaml:"hub" json:"hub"`
+	Amount string        `yaml:"amount" json:"amount"`
+	Assets []matrixAsset `yaml:"assets" json:"assets"`
+}
+
+type matrixAsset struct {
+	Asset  string   `yaml:"asset" json:"asset"`
+	Amount string   `yaml:"amount" json:"amount"`
+	Routes []string `yaml:"routes" json:"routes"`
+}
+
+func (a matrixAsset) supports(route string) bool {
+	if len(a.Routes) == 0 {
+		return true
+	}
+	for _, r := range a.Routes {
+		if strings.EqualFold(r, route) {
+			return true
+		}
+	}
+	return false
+}
+
+type matrixOptions struct {
+	assetsFile  string
+	route       string
+	outDir      string
+	password    string
+	concurrency int
+	timeout     time.Duration
+	dryRun      bool
+	account     Account
+}
+
+// MatrixSwapResult is one swap of the matrix.
+type MatrixSwapResult struct {
+	Index         int    `json:"index"`
+	Phase         string `json:"phase"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Amount        string `json:"amount"`
+	PolicyFile    string `json:"policy_file,omitempty"`
+	PolicyID      string `json:"policy_id,omitempty"`
+	Status        string `json:"status"`
+	TxHash        string `json:"tx_hash,omitempty"`
+	TxStatus      string `json:"tx_status,omitempty"`
+	OnChainStatus string `json:"status_onchain,omitempty"`
+	ExplorerURL   string `json:"explorer_url,omitempty"`
+	Error         string `json:"error,omitempty"`
+	DurationMs    int64  `json:"duration_ms"`
+}
+
+// PolicyMatrixResult is the result of 'policy matrix'.
+type PolicyMatrixResult struct {
+	Route        string             `json:"route"`
+	Hub          string             `json:"hub"`
+	Vault        string             `json:"vault"`
+	DryRun       bool               `json:"dry_run"`
+	StartedAt    string             `json:"started_at"`
+	FinishedAt   string             `json:"finished_at"`
+	Total        int                `json:"total"`
+	Succeeded    int                `json:"succeeded"`
+	Failed       int                `json:"failed"`
+	MarkdownFile string             `json:"markdown_file"`
+	JSONFile     string             `json:"json_file"`
+	Swaps        []MatrixSwapResult `json:"swaps"`
+}
+
+func loadMatrixAssets(path string) (*matrixAssets, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read assets file: %w", err)
+	}
+	var assets matrixAssets
+	err = yaml.Unmarshal(data, &assets)
+	if err != nil {
+		return nil, fmt.Errorf("parse assets file: %w", err)
+	}
+	if assets.Hub == "" {
+		assets.Hub = "eth"
+	}
+	if assets.Amount == "" {
+		return nil, fmt.Errorf("assets file: missing hub amount")
+	}
+	for i, a := range assets.Assets {
+		if a.Asset == "" || a.Amount == "" {
+			return nil, fmt.Errorf("assets file: entry %d needs asset and amount", i+1)
+		}
+	}
+	return &assets, nil
+}
+
+// matrixSwaps lists the forward swaps (hub to asset) and the reverse swaps
+// (asset to hub) of the assets that support route.
+func matrixSwaps(assets *matrixAssets, route string) (forward, reverse []MatrixSwapResult) {
+	for _, a := range assets.Assets {
+		if !a.supports(route) {
+			continue
+		}
+		forward = append(forward, MatrixSwapResult{Phase: "forward", From: assets.Hub, To: a.Asset, Amount: assets.Amount})
+		reverse = append(reverse, MatrixSwapResult{Phase: "reverse", From: a.Asset, To: assets.Hub, Amount: a.Amount})
+	}
+	for i := range forward {
+		forward[i].Index = i + 1
+	}
+	for i := range reverse {
+		reverse[i].Index = len(forward) + i + 1
+	}
+	return forward, reverse
+}
+
+func runPolicyMatrix(opts matrixOptions) error {
+	startTime := time.Now()
+
+	assets, err := loadMatrixAssets(opts.assetsFile)
+	if err != nil {
+		return err
+	}
+
+	vault, err := ActiveVault()
+	if err != nil {
+		return err
+	}
+	if !opts.dryRun {
+		rememberVaultPassword(opts.password)
+		err = requireKeyshares(vault)
+		if err != nil {
+			return err
+		}
+		_, err = ensureAuthHeader(vault)
+		if err != nil {
+			return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import --password xxx' to authenticate first", err)
+		}
+	}
+
+	forward, reverse := matrixSwaps(assets, opts.route)
+	total := len(forward) + len(reverse)
+	if total == 0 {
+		return fmt.Errorf("no assets in %s support route %s", opts.assetsFile, opts.route)
+	}
+
+	err = os.MkdirAll(filepath.Join(opts.outDir, "policies"), 0755)
+	if err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+
+	fmt.Printf("Swap matrix: %d swaps via %s (hub %s, vault %s)\n", total, opts.route, assets.Hub, vault.Name)
+	fmt.Printf("  Concurrency: %d, timeout per swap: %s\n", opts.concurrency, opts.timeout)
+	fmt.Printf("  Output: %s\n", opts.outDir)
+
+	r := &matrixRunner{opts: opts, vault: vault, total: total}
+	fmt.Printf("\nForward phase: %s -> assets (%d swaps)\n", assets.Hub, len(forward))
+	r.run(forward)
+	fmt.Printf("\nReverse phase: assets -> %s (%d swaps)\n", assets.Hub, len(reverse))
+	r.run(reverse)
+
+	result := PolicyMatrixResult{
+		Route:        opts.route,
+		Hub:          assets.Hub,
+		Vault:        vault.Name,
+		DryRun:       opts.dryRun,
+		StartedAt:    startTime.Format(time.RFC3339),
+		FinishedAt:   time.Now().Format(time.RFC3339),
+		Total:        total,
+		MarkdownFile: filepath.Join(opts.outDir, "results.md"),
+		JSONFile:     filepath.Join(opts.outDir, "results.json"),
+		Swaps:        append(forward, reverse...),
+	}
+	for _, s := range result.Swaps {
+		switch s.Status {
+		case matrixSuccess, matrixGenerated:
+			result.Succeeded++
+		default:
+			result.Failed++
+		}
+	}
+
+	err = writeMatrixResults(result)
+	if err != nil {
+		return err
+	}
+
+	return printResult(result, func() {
+		fmt.Println()
+		fmt.Println("┌──────┬────────────────────────────────┬───────────┬──────────┬────────────────────────────────┐")
+		fmt.Println("│ #    │ Swap                           │ Status    │ Duration │ TX Hash / Error                │")
+		fmt.Println("├──────┼────────────────────────────────┼───────────┼──────────┼────────────────────────────────┤")
+		for _, s := range result.Swaps {
+			detail := s.TxHash
+			if s.Error != "" {
+				detail = s.Error
+			}
+			fmt.Printf("│ %-4d │ %-30s │ %-9s │ %-8s │ %-30s │\n",
+				s.Index, truncate(fmt.Sprintf("%s -> %s (%s)", s.From, s.To, s.Amount), 30), s.Status,
+				matrixDuration(s.DurationMs), truncate(detail, 30))
+		}
+		fmt.Println("└──────┴────────────────────────────────┴───────────┴──────────┴────────────────────────────────┘")
+		fmt.Printf("\n%d/%d swaps succeeded\n", result.Succeeded, result.Total)
+		fmt.Printf("Results: %s\n", result.MarkdownFile)
+		fmt.Printf("         %s\n", result.JSONFile)
+	})
+}
+
+// matrixRunner runs the swaps of one phase with bounded concurrency.
+type matrixRunner struct {
+	opts  matrixOptions
+	vault *LocalVault
+	total int
+	mu    sync.Mutex
+}
+
+func (r *matrixRunner) run(swaps []MatrixSwapResult) {
+	sem := make(chan struct{}, r.opts.concurrency)
+	var wg sync.WaitGroup
+	for i := range swaps {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(s *MatrixSwapResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r.runSwap(s)
+		}(&swaps[i])
+	}
+	wg.Wait()
+}
+
+func (r *matrixRunner) logf(s *MatrixSwapResult, format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fmt.Printf("[%02d/%02d] %s -> %s: %s\n", s.Index, r.total, s.From, s.To, fmt.Sprintf(format, args...))
+}
+
+func (r *matrixRunner) runSwap(s *MatrixSwapResult) {
+	start := time.Now()
+	defer func() {
+		s.DurationMs = time.Since(start).Milliseconds()
+	}()
+
+	fail := func(status string, err error) {
+		s.Status = status
+		s.Error = err.Error()
+		r.logf(s, "%s: %v", status, err)
+	}
+
+	policyFile, err := r.generate(s)
+	if err != nil {
+		fail(matrixError, err)
+		return
+	}
+	s.PolicyFile = policyFile
+	if r.opts.dryRun {
+		s.Status = matrixGenerated
+		r.logf(s, "generated %s", policyFile)
+		return
+	}
+
+	pf, err := loadPolicyFile(policyFile, r.vault, nil)
+	if err != nil {
+		fail(matrixError, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	created, _, err := createPolicy(ctx, r.vault, dcaGenerator.PluginID, pf, r.opts.password)
+	cancel()
+	if err != nil {
+		fail(matrixError, err)
+		return
+	}
+	s.PolicyID = created.ID
+	r.logf(s, "policy %s added, waiting for transaction", s.PolicyID)
+
+	tx, err := waitForMatrixTx(dcaGenerator.PluginID, s.PolicyID, r.opts.timeout)
+	if tx != nil {
+		s.TxHash = tx.TxHash
+		s.TxStatus = tx.Status
+		s.OnChainStatus = tx.OnChainStatus
+		s.ExplorerURL = getExplorerURLForChain(ResolveAsset(s.From).Chain, tx.TxHash)
+	}
+	switch {
+	case err != nil:
+		fail(matrixTimeout, err)
+	case tx.OnChainStatus == "SUCCESS":
+		s.Status = matrixSuccess
+		r.logf(s, "SUCCESS %s", s.TxHash)
+	default:
+		fail(matrixFail, fmt.Errorf("transaction %s failed on-chain", s.TxHash))
+	}
+}
+
+// generate writes the swap's policy file and returns its path.
+func (r *matrixRunner) generate(s *MatrixSwapResult) (string, error) {
+	flags := dcaGenerator.flagSet()
+	for name, value := range map[string]string{
+		"from":   s.From,
+		"to":     s.To,
+		"amount": s.Amount,
+		"route":  r.opts.route,
+	} {
+		err := flags.Set(name, value)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	policy, _, err := generatePolicy(dcaGenerator, dcaGenerator.PluginID, r.vault, r.opts.account, flags)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal policy: %w", err)
+	}
+
+	name := fmt.Sprintf("%02d-%s-to-%s.json", s.Index, matrixFileName(s.From), matrixFileName(s.To))
+	path := filepath.Join(r.opts.outDir, "policies", name)
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return "", fmt.Errorf("write policy file: %w", err)
+	}
+	return path, nil
+}
+
+// waitForMatrixTx polls tx_indexer until the policy's latest transaction is
+// confirmed or failed on-chain. On timeout it returns the last transaction
+// seen, if any, with the error.
+func waitForMatrixTx(pluginID, policyID string, timeout time.Duration) (*TxRecord, error) {
+	deadline := time.Now().Add(timeout)
+	var last *TxRecord
+	var lastErr error
+	for {
+		txs, err := pluginTransactions(pluginID, policyID, 1)
+		if err != nil {
+			lastErr = err
+		}
+		if len(txs) > 0 {
+			last = &txs[0]
+			switch last.OnChainStatus {
+			case "SUCCESS", "FAIL":
+				return last, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			if last == nil && lastErr != nil {
+				return nil, fmt.Errorf("no transaction after %s: %w", timeout, lastErr)
+			}
+			if last == nil {
+				return nil, fmt.Errorf("no transaction after %s", timeout)
+			}
+			return last, fmt.Errorf("transaction still %s/%s after %s", last.Status, last.OnChainStatus, timeout)
+		}
+		time.Sleep(matrixPollInterval)
+	}
+}
+
+func matrixFileName(asset string) string {
+	return strings.NewReplacer(":", "-", ".", "-", "/", "-").Replace(strings.ToLower(asset))
+}
+
+func matrixDuration(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).Round(time.Second).String()
+}
+
+func writeMatrixResults(result PolicyMatrixResult) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal results: %w", err)
+	}
+	err = os.WriteFile(result.JSONFile, data, 0644)
+	if err != nil {
+		return fmt.Errorf("write results: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Swap Matrix Results (%s)\n\n", result.Route)
+	fmt.Fprintf(&b, "- Vault: %s\n", result.Vault)
+	fmt.Fprintf(&b, "- Started: %s\n", result.StartedAt)
+	fmt.Fprintf(&b, "- Finished: %s\n", result.FinishedAt)
+	fmt.Fprintf(&b, "- Succeeded: %d/%d\n", result.Succeeded, result.Total)
+	if result.DryRun {
+		b.WriteString("- Dry run: policies generated, not added\n")
+	}
+
+	phases := []struct{ name, title string }{
+		{"forward", fmt.Sprintf("Forward: %s → Assets", strings.ToUpper(result.Hub))},
+		{"reverse", fmt.Sprintf("Reverse: Assets → %s", strings.ToUpper(result.Hub))},
+	}
+	for _, phase := range phases {
+		fmt.Fprintf(&b, "\n## %s\n\n", phase.title)
+		b.WriteString("| # | From | To | Amount | Status | Duration | Policy ID | TX Hash | Error |\n")
+		b.WriteString("|---|------|-----|--------|--------|----------|-----------|---------|-------|\n")
+		for _, s := range result.Swaps {
+			if s.Phase != phase.name {
+				continue
+			}
+			tx := ""
+			if s.TxHash != "" {
+				tx = "`" + s.TxHash + "`"
+				if s.ExplorerURL != "" {
+					tx = fmt.Sprintf("[`%s`](%s)", s.TxHash, s.ExplorerURL)
+				}
+			}
+			fmt.Fprintf(&b, "| %d | %s | %s | %s | %s | %s | %s | %s | %s |\n",
+				s.Index, strings.ToUpper(s.From), strings.ToUpper(s.To), s.Amount, s.Status,
+				matrixDuration(s.DurationMs), s.PolicyID, tx, strings.NewReplacer("|", "\\|", "\n", " ").Replace(s.Error))
+		}
+	}
+
+	err = os.WriteFile(result.MarkdownFile, []byte(b.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("write results: %w", err)
+	}
+	return nil
+}
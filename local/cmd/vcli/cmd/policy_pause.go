@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
+)
+
+// deactivationReasonUser marks a policy the user paused; the verifier only
+// reactivates policies paused by the user or by a plugin safety pause.
+const deactivationReasonUser = "user"
+
+func newPolicyPauseCmd() *cobra.Command {
+	return newPolicyToggleCmd(false)
+}
+
+func newPolicyResumeCmd() *cobra.Command {
+	return newPolicyToggleCmd(true)
+}
+
+func newPolicyToggleCmd(active bool) *cobra.Command {
+	var password string
+
+	use, short, long := "pause [policy-id]", "Pause a policy (keeps its ID and history)", `Deactivate a policy without deleting it.
+
+The verifier is sent a signed update with active=false. The plugin drops the
+policy from its scheduler, so 'vcli policy status' shows it as not scheduled.
+Resume it later with 'vcli policy resume'.
+
+Examples:
+  vcli policy pause <policy-id> --password xxx
+`
+	if active {
+		use, short, long = "resume [policy-id]", "Resume a paused policy", `Reactivate a policy paused with 'vcli policy pause'.
+
+The verifier is sent a signed update with active=true and the plugin puts the
+policy back on its scheduler. Policies that expired or completed cannot be
+resumed.
+
+Examples:
+  vcli policy resume <policy-id> --password xxx
+`
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: long + `
+Environment variables:
+  VAULT_PASSWORD  - Fast Vault password (or use --password flag)
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			actualPassword := password
+			if envPass := os.Getenv("VAULT_PASSWORD"); envPass != "" {
+				actualPassword = envPass
+			}
+			if actualPassword == "" {
+				var err error
+				actualPassword, err = promptPassword("", "Enter Fast Vault password: ")
+				if err != nil {
+					return err
+				}
+			}
+			return runPolicySetActive(args[0], active, actualPassword)
+		},
+	}
+
+	cmd.Flags().StringVar(&password, "password", "", "Fast Vault password (or set VAULT_PASSWORD env var)")
+
+	return withStructuredOutput(cmd)
+}
+
+type PolicyToggleResult struct {
+	PolicyID      string `json:"policy_id"`
+	PluginID      string `json:"plugin_id"`
+	Active        bool   `json:"active"`
+	Scheduled     bool   `json:"scheduled"`
+	NextExecution string `json:"next_execution,omitempty"`
+	DurationMs    int64  `json:"duration_ms"`
+}
+
+func runPolicySetActive(policyID string, active bool, password string) error {
+	startTime := time.Now()
+	rememberVaultPassword(password)
+
+	action := "Pausing"
+	if active {
+		action = "Resuming"
+	}
+
+	vault, err := ActiveVault()
+	if err != nil {
+		return err
+	}
+	err = requireKeyshares(vault)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureAuthHeader(vault)
+	if err != nil {
+		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import' first", err)
+	}
+
+	client, err := newVerifierClient(vault)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	fmt.Printf("%s policy %s...\n", action, policyID)
+	policy, err := client.GetPolicy(ctx, policyID)
+	if err != nil {
+		return fmt.Errorf("fetch policy: %w", err)
+	}
+	if policy.PublicKey != vault.PublicKeyECDSA {
+		return fmt.Errorf("policy %s does not belong to the active vault %s...", policyID, vault.PublicKeyECDSA[:16])
+	}
+	if policy.Active == active {
+		if active {
+			return fmt.Errorf("policy %s is already active", policyID)
+		}
+		return fmt.Errorf("policy %s is already paused", policyID)
+	}
+	if active && policy.DeactivationReason != nil {
+		switch *policy.DeactivationReason {
+		case "expiry", "completed":
+			return fmt.Errorf("policy %s was deactivated (%s) and cannot be resumed; create a new policy instead",
+				policyID, *policy.DeactivationReason)
+		}
+	}
+
+	fmt.Printf("  Plugin: %s\n", policy.PluginID)
+	fmt.Printf("  Policy Version: %d\n", policy.PolicyVersion)
+	fmt.Printf("  Plugin Version: %s\n", policy.PluginVersion)
+
+	// The signed message covers the unchanged recipe and versions; active is
+	// carried alongside it
+	signature, err := signPolicy(ctx, vault, policy.Recipe, policy.PolicyVersion, policy.PluginVersion, password)
+	if err != nil {
+		return err
+	}
+
+	update := verifier.Policy{
+		ID:            policy.ID,
+		PluginID:      policy.PluginID,
+		PublicKey:     policy.PublicKey,
+		PluginVersion: policy.PluginVersion,
+		PolicyVersion: policy.PolicyVersion,
+		Signature:     signature,
+		Recipe:        policy.Recipe,
+		Billing:       policy.Billing,
+		Active:        active,
+	}
+	if !active {
+		reason := deactivationReasonUser
+		update.DeactivationReason = &reason
+	}
+
+	fmt.Println("\nSubmitting update to verifier...")
+	_, err = client.UpdatePolicy(ctx, update)
+	if err != nil {
+		return fmt.Errorf("update policy: %w", err)
+	}
+	updateLocalPolicy(policyID, func(p *LocalPolicy) {
+		p.Active = active
+		p.Signature = signature
+	})
+
+	fmt.Println("Waiting for the plugin scheduler...")
+	result := PolicyToggleResult{
+		PolicyID: policyID,
+		PluginID: policy.PluginID,
+		Active:   active,
+	}
+	result.NextExecution = waitForScheduler(policy.PluginID, policyID, active, 10*time.Second)
+	result.Scheduled = result.NextExecution != ""
+
+	totalDuration := time.Since(startTime)
+	result.DurationMs = totalDuration.Milliseconds()
+
+	return printResult(result, func() {
+		title := "POLICY PAUSED"
+		if active {
+			title = "POLICY RESUMED"
+		}
+		scheduler := "not scheduled"
+		if result.Scheduled {
+			scheduler = "next run " + result.NextExecution
+		}
+
+		fmt.Println()
+		fmt.Println("┌─────────────────────────────────────────────────────────────────┐")
+		fmt.Printf("│ %-63s │\n", title)
+		fmt.Println("├─────────────────────────────────────────────────────────────────┤")
+		fmt.Println("│                                                                 │")
+		fmt.Printf("│  Policy ID:      %-47s │\n", policyID)
+		fmt.Printf("│  Plugin:         %-47s │\n", policy.PluginID)
+		fmt.Printf("│  Active:         %-47v │\n", active)
+		fmt.Printf("│  Scheduler:      %-47s │\n", scheduler)
+		fmt.Println("│                                                                 │")
+		fmt.Printf("│  Duration:       %-47s │\n", totalDuration.Round(time.Millisecond).String())
+		fmt.Println("│                                                                 │")
+		fmt.Println("└─────────────────────────────────────────────────────────────────┘")
+		fmt.Println()
+		if active && !result.Scheduled {
+			fmt.Println("The plugin has not rescheduled the policy yet; check 'vcli policy status'.")
+		}
+		if !active && result.Scheduled {
+			fmt.Println("The plugin still has a scheduler entry; check 'vcli policy status'.")
+		}
+		if !active {
+			fmt.Printf("Resume it: vcli policy resume %s\n", policyID)
+		}
+	})
+}
+
+// waitForScheduler polls the plugin scheduler until the policy's row appears
+// (scheduled) or disappears, and returns the last next_execution seen.
+func waitForScheduler(pluginID, policyID string, scheduled bool, timeout time.Duration) string {
+	deadline := time.Now().Add(timeout)
+	for {
+		next, err := pluginNextExecution(pluginID, policyID)
+		if err == nil && (next != "") == scheduled || time.Now().After(deadline) {
+			return next
+		}
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,452 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
+)
+
+// Verifier-side state of a locally recorded policy.
+const (
+	localActive  = "active"
+	localPaused  = "paused"
+	localDeleted = "deleted" // deleted through vcli
+	localMissing = "missing" // gone from the verifier without a vcli delete
+	localUnknown = "unknown" // verifier unreachable or not authenticated
+)
+
+// LocalPolicy is vcli's record of a policy it submitted, kept in
+// ~/.vultisig/policies/<policy-id>.json and written by policy add, update,
+// pause/resume and delete.
+type LocalPolicy struct {
+	ID              string             `json:"id"`
+	PluginID        string             `json:"plugin_id"`
+	VaultName       string             `json:"vault_name"`
+	PublicKey       string             `json:"public_key"`
+	VerifierURL     string             `json:"verifier_url"`
+	PolicyVersion   int                `json:"policy_version"`
+	PluginVersion   string             `json:"plugin_version"`
+	Active          bool               `json:"active"`
+	SourceFile      string             `json:"source_file"`
+	Source          json.RawMessage    `json:"source"`
+	Recipe          map[string]any     `json:"recipe"`
+	Account         Account            `json:"account"`
+	ProtobufRecipe  string             `json:"protobuf_recipe"`
+	RecipeSHA256    string             `json:"recipe_sha256"`
+	Signature       string             `json:"signature"`
+	Billing         []verifier.Billing `json:"billing"`
+	ResubmittedFrom string             `json:"resubmitted_from,omitempty"`
+	ResubmittedAs   string             `json:"resubmitted_as,omitempty"`
+	CreatedAt       string             `json:"created_at"`
+	UpdatedAt       string             `json:"updated_at"`
+	DeletedAt       string             `json:"deleted_at,omitempty"`
+}
+
+// LocalPolicyView is a registry entry with its state on the verifier.
+type LocalPolicyView struct {
+	*LocalPolicy
+	VerifierStatus string `json:"verifier_status"`
+}
+
+func PolicyRegistryPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "policies")
+}
+
+// newLocalPolicy builds the registry entry for a signed policy.
+func newLocalPolicy(id string, vault *LocalVault, pluginID string, pf *policyFile, recipe *policyRecipe, signature string, policyVersion int, pluginVersion string) *LocalPolicy {
+	now := time.Now().UTC().Format(time.RFC3339)
+	entry := &LocalPolicy{
+		ID:             id,
+		PluginID:       pluginID,
+		VaultName:      vault.Name,
+		PublicKey:      vault.PublicKeyECDSA,
+		PolicyVersion:  policyVersion,
+		PluginVersion:  pluginVersion,
+		Active:         true,
+		SourceFile:     pf.path,
+		Source:         json.RawMessage(pf.source),
+		Recipe:         pf.recipe,
+		Account:        pf.account,
+		ProtobufRecipe: recipe.base64,
+		Signature:      signature,
+		Billing:        recipe.billing,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if abs, err := filepath.Abs(pf.path); err == nil {
+		entry.SourceFile = abs
+	}
+	if cfg, err := LoadConfig(); err == nil {
+		entry.VerifierURL = cfg.Verifier
+	}
+	if raw, err := base64.StdEncoding.DecodeString(recipe.base64); err == nil {
+		sum := sha256.Sum256(raw)
+		entry.RecipeSHA256 = hex.EncodeToString(sum[:])
+	}
+	return entry
+}
+
+// recordPolicy saves entry, keeping the creation time and resubmission links
+// of an existing record. A failure only warns: the policy already exists on
+// the verifier.
+func recordPolicy(entry *LocalPolicy) {
+	if prev, err := loadLocalPolicy(entry.ID); err == nil {
+		entry.CreatedAt = prev.CreatedAt
+		entry.ResubmittedFrom = prev.ResubmittedFrom
+		entry.ResubmittedAs = prev.ResubmittedAs
+	}
+	err := saveLocalPolicy(entry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: policy not recorded locally: %v\n", err)
+	}
+}
+
+// updateLocalPolicy applies update to the record of policyID, if there is one.
+func updateLocalPolicy(policyID string, update func(*LocalPolicy)) {
+	entry, err := loadLocalPolicy(policyID)
+	if err != nil {
+		return
+	}
+	update(entry)
+	entry.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	err = saveLocalPolicy(entry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: local policy record not updated: %v\n", err)
+	}
+}
+
+func saveLocalPolicy(entry *LocalPolicy) error {
+	dir := PolicyRegistryPath()
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("create policy registry dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal policy record: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, entry.ID+".json"), data, 0644)
+	if err != nil {
+		return fmt.Errorf("write policy record: %w", err)
+	}
+	return nil
+}
+
+func loadLocalPolicy(policyID string) (*LocalPolicy, error) {
+	data, err := os.ReadFile(filepath.Join(PolicyRegistryPath(), policyID+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("policy %s is not in the local registry (%s)", policyID, PolicyRegistryPath())
+		}
+		return nil, fmt.Errorf("read policy record: %w", err)
+	}
+
+	var entry LocalPolicy
+	err = json.Unmarshal(data, &entry)
+	if err != nil {
+		return nil, fmt.Errorf("parse policy record %s: %w", policyID, err)
+	}
+	return &entry, nil
+}
+
+// listLocalPolicies returns all records, oldest first.
+func listLocalPolicies() ([]*LocalPolicy, error) {
+	files, err := os.ReadDir(PolicyRegistryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read policy registry: %w", err)
+	}
+
+	var entries []*LocalPolicy
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		entry, err := loadLocalPolicy(strings.TrimSuffix(f.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt < entries[j].CreatedAt
+	})
+	return entries, nil
+}
+
+// verifierStatus looks entry up on the verifier with the stored auth token of
+// its vault. Clients are cached per vault.
+func verifierStatus(entry *LocalPolicy, clients map[string]*verifier.Client) string {
+	if entry.DeletedAt != "" {
+		return localDeleted
+	}
+
+	client, ok := clients[entry.PublicKey]
+	if !ok {
+		vault, err := LoadVault(entry.PublicKey[:min(16, len(entry.PublicKey))])
+		if err == nil {
+			client, _ = newStoredAuthClient(vault, verifier.WithTimeout(5*time.Second), verifier.WithRetries(0, 0))
+		}
+		clients[entry.PublicKey] = client
+	}
+	if client == nil {
+		return localUnknown
+	}
+
+	policy, err := client.GetPolicy(context.Background(), entry.ID)
+	switch {
+	case verifier.StatusCode(err) == 404:
+		return localMissing
+	case err != nil:
+		return localUnknown
+	case policy.Active:
+		return localActive
+	default:
+		return localPaused
+	}
+}
+
+func runPolicyListLocal(pluginID string) error {
+	entries, err := listLocalPolicies()
+	if err != nil {
+		return err
+	}
+
+	views := []LocalPolicyView{}
+	clients := map[string]*verifier.Client{}
+	for _, entry := range entries {
+		if pluginID != "" && entry.PluginID != pluginID {
+			continue
+		}
+		views = append(views, LocalPolicyView{LocalPolicy: entry, VerifierStatus: verifierStatus(entry, clients)})
+	}
+
+	return printResult(views, func() {
+		if len(views) == 0 {
+			fmt.Printf("No policies recorded in %s\n", PolicyRegistryPath())
+			return
+		}
+
+		fmt.Printf("Found %d locally recorded policies:\n\n", len(views))
+		for i, v := range views {
+			fmt.Printf("  %d. Policy ID: %s\n", i+1, v.ID)
+			fmt.Printf("     Plugin: %s\n", v.PluginID)
+			fmt.Printf("     Vault: %s (%s...)\n", v.VaultName, v.PublicKey[:min(16, len(v.PublicKey))])
+			fmt.Printf("     Version: %d (plugin %s)\n", v.PolicyVersion, v.PluginVersion)
+			fmt.Printf("     Source: %s\n", v.SourceFile)
+			fmt.Printf("     Verifier: %s\n", v.VerifierStatus)
+			if v.VerifierStatus == localMissing {
+				fmt.Printf("     (resubmit with: vcli policy resubmit %s)\n", v.ID)
+			}
+			fmt.Println()
+		}
+	})
+}
+
+func newPolicyShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [policy-id]",
+		Short: "Show the local record of a policy submitted by vcli",
+		Long: `Show what vcli recorded when it submitted a policy: the source file and
+its contents, the resolved recipe, the protobuf recipe and its SHA-256, the
+signature, vault, plugin and verifier URL. The policy is also looked up on
+the verifier to tell whether it still exists.
+
+Records are kept in ~/.vultisig/policies/ by policy add, update, pause,
+resume and delete.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPolicyShow(args[0])
+		},
+	}
+	return withStructuredOutput(cmd)
+}
+
+func runPolicyShow(policyID string) error {
+	entry, err := loadLocalPolicy(policyID)
+	if err != nil {
+		return err
+	}
+	view := LocalPolicyView{LocalPolicy: entry, VerifierStatus: verifierStatus(entry, map[string]*verifier.Client{})}
+
+	return printResult(view, func() {
+		fmt.Printf("Policy: %s\n", entry.ID)
+		fmt.Println(strings.Repeat("=", 60))
+		fmt.Printf("  Plugin:         %s\n", entry.PluginID)
+		fmt.Printf("  Vault:          %s (%s...)\n", entry.VaultName, entry.PublicKey[:min(16, len(entry.PublicKey))])
+		if !entry.Account.IsDefault() {
+			fmt.Printf("  Derivation:     %s (%s)\n", entry.Account, entry.Account.EVMDerivePath())
+		}
+		fmt.Printf("  Verifier:       %s (%s)\n", entry.VerifierURL, view.VerifierStatus)
+		fmt.Printf("  Policy Version: %d\n", entry.PolicyVersion)
+		fmt.Printf("  Plugin Version: %s\n", entry.PluginVersion)
+		fmt.Printf("  Recipe SHA-256: %s\n", entry.RecipeSHA256)
+		fmt.Printf("  Signature:      %s\n", entry.Signature)
+		fmt.Printf("  Created:        %s\n", entry.CreatedAt)
+		fmt.Printf("  Updated:        %s\n", entry.UpdatedAt)
+		if entry.DeletedAt != "" {
+			fmt.Printf("  Deleted:        %s\n", entry.DeletedAt)
+		}
+		if entry.ResubmittedFrom != "" {
+			fmt.Printf("  Resubmitted from: %s\n", entry.ResubmittedFrom)
+		}
+		if entry.ResubmittedAs != "" {
+			fmt.Printf("  Resubmitted as:   %s\n", entry.ResubmittedAs)
+		}
+
+		fmt.Printf("\nSource (%s):\n", entry.SourceFile)
+		printJSON(entry.Source)
+		fmt.Println("\nResolved recipe:")
+		printJSON(entry.Recipe)
+
+		switch view.VerifierStatus {
+		case localMissing:
+			fmt.Printf("\n⚠ The verifier no longer has this policy. Resubmit with: vcli policy resubmit %s\n", entry.ID)
+		case localUnknown:
+			fmt.Println("\nVerifier state unknown (unreachable or not authenticated).")
+		}
+	})
+}
+
+func newPolicyResubmitCmd() *cobra.Command {
+	var password string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "resubmit [policy-id]",
+		Short: "Create a policy again from its local record",
+		Long: `Rebuild, sign and submit a policy from the source recorded when vcli first
+submitted it, e.g. after the verifier database was reset. The recipe is
+rebuilt through the plugin (vault addresses and account as recorded), so the
+new policy gets a new ID; both records link to each other.
+
+A policy the verifier still has is only resubmitted with --force.
+
+Environment variables:
+  VAULT_PASSWORD  - Fast Vault password (or use --password flag)
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			actualPassword := password
+			if envPass := os.Getenv("VAULT_PASSWORD"); envPass != "" {
+				actualPassword = envPass
+			}
+			if actualPassword == "" {
+				var err error
+				actualPassword, err = promptPassword("", "Enter Fast Vault password: ")
+				if err != nil {
+					return err
+				}
+			}
+			return runPolicyResubmit(args[0], actualPassword, force)
+		},
+	}
+
+	cmd.Flags().StringVar(&password, "password", "", "Fast Vault password (or set VAULT_PASSWORD env var)")
+	cmd.Flags().BoolVar(&force, "force", false, "Resubmit even if the verifier still has the policy")
+
+	return withStructuredOutput(cmd)
+}
+
+// PolicyResubmitResult is the result of 'policy resubmit'.
+type PolicyResubmitResult struct {
+	PolicyID      string `json:"policy_id"`
+	PreviousID    string `json:"previous_id"`
+	PluginID      string `json:"plugin_id"`
+	PublicKey     string `json:"public_key"`
+	PolicyVersion int    `json:"policy_version"`
+	PluginVersion string `json:"plugin_version"`
+	DurationMs    int64  `json:"duration_ms"`
+}
+
+func runPolicyResubmit(policyID, password string, force bool) error {
+	startTime := time.Now()
+	rememberVaultPassword(password)
+
+	entry, err := loadLocalPolicy(policyID)
+	if err != nil {
+		return err
+	}
+
+	status := verifierStatus(entry, map[string]*verifier.Client{})
+	if (status == localActive || status == localPaused) && !force {
+		return fmt.Errorf("the verifier still has policy %s (%s); delete it first or pass --force", policyID, status)
+	}
+
+	vault, err := LoadVault(entry.PublicKey[:min(16, len(entry.PublicKey))])
+	if err != nil {
+		return fmt.Errorf("vault %s of policy %s: %w", entry.VaultName, policyID, err)
+	}
+	err = requireKeyshares(vault)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureAuthHeader(vault)
+	if err != nil {
+		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import' first", err)
+	}
+
+	pf, err := parsePolicyFile(entry.SourceFile, entry.Source, vault, &entry.Account)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Resubmitting policy %s (%s)...\n", policyID, status)
+	fmt.Printf("  Plugin: %s\n", entry.PluginID)
+	fmt.Printf("  Vault: %s (%s...)\n", vault.Name, vault.PublicKeyECDSA[:16])
+	fmt.Printf("  Source: %s\n", entry.SourceFile)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	created, _, err := createPolicy(ctx, vault, entry.PluginID, pf, password)
+	if err != nil {
+		return err
+	}
+	updateLocalPolicy(created.ID, func(p *LocalPolicy) { p.ResubmittedFrom = policyID })
+	updateLocalPolicy(policyID, func(p *LocalPolicy) { p.ResubmittedAs = created.ID })
+
+	result := PolicyResubmitResult{
+		PolicyID:      created.ID,
+		PreviousID:    policyID,
+		PluginID:      entry.PluginID,
+		PublicKey:     vault.PublicKeyECDSA,
+		PolicyVersion: created.PolicyVersion,
+		PluginVersion: created.PluginVersion,
+		DurationMs:    time.Since(startTime).Milliseconds(),
+	}
+
+	return printResult(result, func() {
+		fmt.Println()
+		fmt.Println("┌─────────────────────────────────────────────────────────────────┐")
+		fmt.Println("│ POLICY RESUBMITTED SUCCESSFULLY                                 │")
+		fmt.Println("├─────────────────────────────────────────────────────────────────┤")
+		fmt.Println("│                                                                 │")
+		fmt.Printf("│  Policy ID:      %-47s │\n", result.PolicyID)
+		fmt.Printf("│  Replaces:       %-47s │\n", policyID)
+		fmt.Printf("│  Plugin:         %-47s │\n", entry.PluginID)
+		fmt.Println("│                                                                 │")
+		fmt.Println("└─────────────────────────────────────────────────────────────────┘")
+		fmt.Println()
+		fmt.Printf("Check it: vcli policy status %s\n", result.PolicyID)
+	})
+}
@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultisig/recipes/engine"
+	"github.com/vultisig/recipes/metarule"
+	rtypes "github.com/vultisig/recipes/types"
+	"github.com/vultisig/recipes/util"
+	"github.com/vultisig/vultisig-go/common"
+
+	"github.com/vultisig/vcli/local/pkg/devdb"
+)
+
+// Rule outcomes in a simulation.
+const (
+	simMatched = "matched"
+	simFailed  = "failed"
+	simSkipped = "skipped"
+)
+
+func newPolicySimulateCmd() *cobra.Command {
+	var pluginID, configFile, policyID, txArg, chainName string
+	var acct Account
+
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Check a transaction against a policy's rules locally",
+		Long: `Evaluate an unsigned transaction against a policy with the recipes rule
+engine the verifier uses, without signing or submitting anything.
+
+The policy is either an existing one (--policy-id, the recipe stored on the
+verifier) or a policy file (--policy-file). A policy file is turned into
+the same protobuf recipe 'policy add' signs, which asks the plugin server
+for its rules; files with a base64 "recipe" field are used as-is.
+
+--tx is a file (or literal) with the unsigned transaction as hex or base64,
+in the form a plugin sends to the verifier: the unsigned payload for EVM
+chains, a PSBT for UTXO chains. --chain defaults to the policy's chain.
+
+The report shows which rule matched, or for each rule which constraint
+failed, plus the policy's rate limit and fee policies.
+
+Examples:
+  vcli policy simulate --policy-id <policy-id> --tx tx.hex
+  vcli policy simulate --plugin dca --policy-file policy.json --tx tx.hex --chain Ethereum
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (configFile == "") == (policyID == "") {
+				return fmt.Errorf("set exactly one of --policy-file and --policy-id")
+			}
+			cmd.SilenceUsage = true
+			var acctOverride *Account
+			if cmd.Flags().Changed("account") || cmd.Flags().Changed("index") {
+				acctOverride = &acct
+			}
+			return runPolicySimulate(ResolvePluginID(pluginID), configFile, policyID, txArg, chainName, acctOverride)
+		},
+	}
+
+	cmd.Flags().StringVar(&pluginID, "plugin", "", "Plugin ID or alias (required with a --policy-file that is not encoded)")
+	cmd.Flags().StringVarP(&configFile, "policy-file", "c", "", "Policy file")
+	cmd.Flags().StringVar(&policyID, "policy-id", "", "Existing policy ID")
+	cmd.Flags().StringVar(&txArg, "tx", "", "Unsigned transaction file or literal, hex or base64 (required)")
+	cmd.Flags().StringVar(&chainName, "chain", "", "Chain of the transaction (default: the policy's chain)")
+	addAccountFlags(cmd, &acct)
+	cmd.MarkFlagRequired("tx")
+
+	return withStructuredOutput(cmd)
+}
+
+// PolicySimulation is the result of 'policy simulate'.
+type PolicySimulation struct {
+	Source      string            `json:"source"`
+	Chain       string            `json:"chain"`
+	Allowed     bool              `json:"allowed"`
+	MatchedRule string            `json:"matched_rule,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	Rules       []SimulatedRule   `json:"rules"`
+	RateLimit   *SimulatedLimit   `json:"rate_limit,omitempty"`
+	FeePolicies []SimulatedFee    `json:"fee_policies"`
+	Notes       []string          `json:"notes,omitempty"`
+	Constraints map[string]string `json:"matched_constraints,omitempty"`
+}
+
+// SimulatedRule is one rule as the engine evaluates it. Meta rules (e.g.
+// "ethereum.send") expand to several chain-specific rules.
+type SimulatedRule struct {
+	Rule     int    `json:"rule"`
+	Resource string `json:"resource"`
+	Expanded string `json:"expanded_from,omitempty"`
+	Status   string `json:"status"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type SimulatedLimit struct {
+	WindowSeconds uint32 `json:"window_seconds"`
+	MaxTxs        uint32 `json:"max_txs"`
+	TxsInWindow   *int   `json:"txs_in_window,omitempty"`
+	Exceeded      bool   `json:"exceeded"`
+}
+
+type SimulatedFee struct {
+	Type    string `json:"type"`
+	Amount  int64  `json:"amount"`
+	Applies string `json:"applies"`
+}
+
+func runPolicySimulate(pluginID, configFile, policyID, txArg, chainName string, acctOverride *Account) error {
+	policy, source, err := loadSimulationPolicy(&pluginID, configFile, policyID, acctOverride)
+	if err != nil {
+		return err
+	}
+
+	if chainName == "" {
+		chainName = policyChain(policy)
+		if chainName == "" {
+			return fmt.Errorf("cannot tell the policy's chain; set --chain")
+		}
+	}
+	chain, err := common.FromString(chainName)
+	if err != nil {
+		return fmt.Errorf("unknown chain: %s", chainName)
+	}
+
+	txData, err := readSimulationTx(txArg)
+	if err != nil {
+		return err
+	}
+
+	ngn, err := engine.NewEngine()
+	if err != nil {
+		return fmt.Errorf("create engine: %w", err)
+	}
+	txBytes, err := ngn.ExtractTxBytes(chain, txData)
+	if err != nil {
+		return fmt.Errorf("extract transaction bytes for %s: %w", chain, err)
+	}
+
+	sim := PolicySimulation{
+		Source:      source,
+		Chain:       chain.String(),
+		Rules:       evaluateRules(policy, chain, txBytes),
+		FeePolicies: simulateFees(policy),
+	}
+
+	matched, err := ngn.Evaluate(policy, chain, txBytes)
+	if err != nil {
+		sim.Error = err.Error()
+	} else {
+		sim.Allowed = true
+		sim.MatchedRule = matched.GetResource()
+		sim.Constraints = map[string]string{}
+		for _, pc := range matched.GetParameterConstraints() {
+			c := decodeConstraint(pc.GetParameterName(), pc.GetConstraint())
+			sim.Constraints[c.Parameter] = strings.TrimSpace(c.Type + " " + c.Value)
+		}
+	}
+
+	if policy.RateLimitWindow != nil && policy.MaxTxsPerWindow != nil {
+		limit := &SimulatedLimit{
+			WindowSeconds: policy.GetRateLimitWindow(),
+			MaxTxs:        policy.GetMaxTxsPerWindow(),
+		}
+		if policyID != "" {
+			if n, err := countTxsInWindow(policyID, limit.WindowSeconds); err == nil {
+				limit.TxsInWindow = &n
+				limit.Exceeded = uint32(n) >= limit.MaxTxs
+			} else {
+				sim.Notes = append(sim.Notes, "rate limit not checked: "+err.Error())
+			}
+		} else {
+			sim.Notes = append(sim.Notes, "rate limit not checked: a policy file has no transaction history")
+		}
+		if limit.Exceeded {
+			sim.Allowed = false
+		}
+		sim.RateLimit = limit
+	}
+	if pluginID == "vultisig-fees-feee" {
+		sim.Notes = append(sim.Notes, "the verifier evaluates fee plugin transactions against its built-in fee policy, not this recipe")
+	}
+
+	err = printResult(sim, func() { printSimulation(sim) })
+	if err != nil {
+		return err
+	}
+	if !sim.Allowed {
+		return fmt.Errorf("the verifier would reject this transaction")
+	}
+	return nil
+}
+
+// loadSimulationPolicy returns the protobuf policy to evaluate and a label
+// for where it came from. For an existing policy pluginID is set to the
+// policy's plugin.
+func loadSimulationPolicy(pluginID *string, configFile, policyID string, acctOverride *Account) (*rtypes.Policy, string, error) {
+	if policyID != "" {
+		stored, err := fetchPolicy(policyID)
+		if err != nil {
+			return nil, "", err
+		}
+		*pluginID = stored.PluginID
+		policy, err := decodeRecipe(stored.Recipe)
+		return policy, "policy " + policyID, err
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, "", fmt.Errorf("read policy file: %w", err)
+	}
+	if recipe, err := recipeFromFile(data); err == nil {
+		policy, err := decodeRecipe(recipe)
+		return policy, configFile, err
+	}
+
+	if *pluginID == "" {
+		return nil, "", fmt.Errorf("--plugin is required to build the recipe of %s", configFile)
+	}
+	vault, err := ActiveVault()
+	if err != nil {
+		return nil, "", err
+	}
+	pf, err := loadPolicyFile(configFile, vault, acctOverride)
+	if err != nil {
+		return nil, "", err
+	}
+	recipe, err := buildPolicyRecipe(*pluginID, pf)
+	if err != nil {
+		return nil, "", err
+	}
+	return recipe.policy, configFile, nil
+}
+
+// readSimulationTx reads --tx (a file, or the data itself) and returns it in
+// the base64 form plugins send to the verifier.
+func readSimulationTx(arg string) (string, error) {
+	data := arg
+	if content, err := os.ReadFile(arg); err == nil {
+		data = string(content)
+	}
+	data = strings.Join(strings.Fields(data), "")
+	if data == "" {
+		return "", fmt.Errorf("--tx is empty")
+	}
+
+	hexData := strings.TrimPrefix(strings.TrimPrefix(data, "0x"), "0X")
+	if raw, err := hex.DecodeString(hexData); err == nil {
+		return base64.StdEncoding.EncodeToString(raw), nil
+	}
+	if _, err := base64.StdEncoding.DecodeString(data); err != nil {
+		return "", fmt.Errorf("--tx is neither hex nor base64")
+	}
+	return data, nil
+}
+
+// evaluateRules runs every rule through the chain engine the way
+// engine.Evaluate does, but records the outcome of each one instead of
+// stopping at the first match.
+func evaluateRules(policy *rtypes.Policy, chain common.Chain, txBytes []byte) []SimulatedRule {
+	results := []SimulatedRule{}
+	var chainEngine engine.ChainEngine
+	registry, engErr := engine.NewChainEngineRegistry()
+	if engErr == nil {
+		chainEngine, engErr = registry.GetEngine(chain)
+	}
+	chainID := strings.ToLower(chain.String())
+	matched := false
+
+	for i, raw := range policy.GetRules() {
+		if raw == nil {
+			continue
+		}
+		rules, err := metarule.NewMetaRule().TryFormat(raw)
+		if err != nil {
+			results = append(results, SimulatedRule{Rule: i + 1, Resource: raw.GetResource(), Status: simFailed, Reason: "format rule: " + err.Error()})
+			continue
+		}
+		for _, rule := range rules {
+			r := SimulatedRule{Rule: i + 1, Resource: rule.GetResource()}
+			if rule.GetResource() != raw.GetResource() {
+				r.Expanded = raw.GetResource()
+			}
+
+			path, err := util.ParseResource(rule.GetResource())
+			switch {
+			case err != nil:
+				r.Status, r.Reason = simSkipped, "invalid resource: "+err.Error()
+			case path.ChainId != chainID:
+				r.Status, r.Reason = simSkipped, fmt.Sprintf("rule is for %s", path.ChainId)
+			case engErr != nil:
+				r.Status, r.Reason = simSkipped, fmt.Sprintf("no engine for %s: %v", chain, engErr)
+			default:
+				err = chainEngine.Evaluate(rule, txBytes)
+				if err != nil {
+					r.Status, r.Reason = simFailed, err.Error()
+				} else {
+					r.Status = simMatched
+					if matched {
+						r.Reason = "an earlier rule already matched"
+					}
+					matched = true
+				}
+			}
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+// simulateFees describes when each of the policy's fees is charged.
+func simulateFees(policy *rtypes.Policy) []SimulatedFee {
+	fees := []SimulatedFee{}
+	for _, f := range policy.GetFeePolicies() {
+		fee := SimulatedFee{Type: f.GetType().String(), Amount: f.GetAmount()}
+		switch f.GetType() {
+		case rtypes.FeeType_TRANSACTION:
+			fee.Applies = "charged for this transaction"
+		case rtypes.FeeType_ONCE:
+			fee.Applies = "charged once, when the policy is created"
+		case rtypes.FeeType_RECURRING:
+			fee.Applies = "charged " + strings.ToLower(f.GetFrequency().String()) + ", not per transaction"
+		default:
+			fee.Applies = "unspecified fee type"
+		}
+		fees = append(fees, fee)
+	}
+	return fees
+}
+
+// countTxsInWindow counts the policy's transactions in the verifier's
+// tx_indexer over the last window seconds, as the verifier does before
+// signing.
+func countTxsInWindow(policyID string, window uint32) (int, error) {
+	var n int
+	err := withDB(openVerifierDB, func(ctx context.Context, db *devdb.DB) error {
+		var err error
+		n, err = db.CountTxsSince(ctx, policyID, time.Duration(window)*time.Second)
+		return err
+	})
+	return n, err
+}
+
+func printSimulation(sim PolicySimulation) {
+	fmt.Printf("Policy Simulation: %s\n", sim.Source)
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("  Chain: %s\n", sim.Chain)
+
+	fmt.Printf("\nRules (%d evaluated):\n", len(sim.Rules))
+	for _, r := range sim.Rules {
+		icon := map[string]string{simMatched: "✓", simFailed: "✗", simSkipped: "-"}[r.Status]
+		fmt.Printf("  %s %d. %s", icon, r.Rule, r.Resource)
+		if r.Expanded != "" {
+			fmt.Printf(" (from %s)", r.Expanded)
+		}
+		fmt.Printf(": %s\n", r.Status)
+		if r.Reason != "" {
+			fmt.Printf("      %s\n", r.Reason)
+		}
+	}
+
+	if sim.RateLimit != nil {
+		l := sim.RateLimit
+		fmt.Printf("\nRate Limit: %d tx(s) per %ds\n", l.MaxTxs, l.WindowSeconds)
+		if l.TxsInWindow != nil {
+			fmt.Printf("  In current window: %d", *l.TxsInWindow)
+			if l.Exceeded {
+				fmt.Printf(" (limit reached)")
+			}
+			fmt.Println()
+		}
+	}
+
+	if len(sim.FeePolicies) > 0 {
+		fmt.Println("\nFee Policies:")
+		for _, f := range sim.FeePolicies {
+			fmt.Printf("  - %s %d: %s\n", f.Type, f.Amount, f.Applies)
+		}
+	}
+
+	for _, n := range sim.Notes {
+		fmt.Printf("\nNote: %s\n", n)
+	}
+
+	fmt.Println()
+	if sim.Allowed {
+		fmt.Printf("✓ ALLOWED by rule %s\n", sim.MatchedRule)
+		for param, c := range sim.Constraints {
+			fmt.Printf("    %s: %s\n", param, c)
+		}
+		return
+	}
+	switch {
+	case sim.Error != "":
+		fmt.Printf("✗ REJECTED: %s\n", sim.Error)
+	case sim.RateLimit != nil && sim.RateLimit.Exceeded:
+		fmt.Printf("✗ REJECTED: rate limit reached (rule %s matched)\n", sim.MatchedRule)
+	}
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
+)
+
+func newPolicyUpdateCmd() *cobra.Command {
+	var configFile string
+	var password string
+	var dryRun bool
+	var acct Account
+
+	cmd := &cobra.Command{
+		Use:   "update [policy-id]",
+		Short: "Update an existing policy (same ID and history)",
+		Long: `Replace the configuration of an existing policy without losing its ID or
+transaction history.
+
+The policy file has the same format as for 'policy add'. The recipe is rebuilt
+through the plugin's suggest endpoint and signed with the current policy and
+plugin versions: the verifier's update replaces the recipe and signature but
+keeps both versions, so the signature stays valid for the stored policy. A
+diff of the current and new configuration is shown before signing. Once the
+verifier accepts the update the local policy registry is updated; versions
+that differ from the signed ones are reported as a warning.
+
+Examples:
+  vcli policy update <policy-id> --policy-file $(pwd)/local/policies/dca.json
+  vcli policy update <policy-id> --policy-file dca.json --dry-run
+
+Environment variables:
+  VAULT_PASSWORD  - Fast Vault password (or use --password flag)
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			actualPassword := password
+			if envPass := os.Getenv("VAULT_PASSWORD"); envPass != "" {
+				actualPassword = envPass
+			}
+			if actualPassword == "" && !dryRun {
+				var err error
+				actualPassword, err = promptPassword("", "Enter Fast Vault password: ")
+				if err != nil {
+					return err
+				}
+			}
+			var acctOverride *Account
+			if cmd.Flags().Changed("account") || cmd.Flags().Changed("index") {
+				acctOverride = &acct
+			}
+			return runPolicyUpdate(args[0], configFile, actualPassword, acctOverride, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&configFile, "policy-file", "", "Policy configuration JSON file (required)")
+	cmd.Flags().StringVar(&password, "password", "", "Fast Vault password (or set VAULT_PASSWORD env var)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the diff without signing or submitting")
+	addAccountFlags(cmd, &acct)
+	cmd.MarkFlagRequired("policy-file")
+
+	return withStructuredOutput(cmd)
+}
+
+type PolicyUpdateResult struct {
+	PolicyID        string `json:"policy_id"`
+	PluginID        string `json:"plugin_id"`
+	PublicKey       string `json:"public_key"`
+	PreviousVersion int    `json:"previous_version"`
+	PolicyVersion   int    `json:"policy_version"`
+	PluginVersion   string `json:"plugin_version"`
+	Diff            string `json:"diff"`
+	Updated         bool   `json:"updated"`
+	DurationMs      int64  `json:"duration_ms"`
+}
+
+func runPolicyUpdate(policyID, configFile, password string, acctOverride *Account, dryRun bool) error {
+	startTime := time.Now()
+	rememberVaultPassword(password)
+
+	vault, err := ActiveVault()
+	if err != nil {
+		return err
+	}
+	if !dryRun {
+		err = requireKeyshares(vault)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = ensureAuthHeader(vault)
+	if err != nil {
+		return fmt.Errorf("authentication required: %w\n\nRun 'vcli vault import' first", err)
+	}
+
+	client, err := newVerifierClient(vault)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	fmt.Printf("Updating policy %s...\n", policyID)
+	current, err := client.GetPolicy(ctx, policyID)
+	if err != nil {
+		return fmt.Errorf("fetch policy: %w", err)
+	}
+	if current.PublicKey != vault.PublicKeyECDSA {
+		return fmt.Errorf("policy %s belongs to vault %s..., not the active vault %s...",
+			policyID, current.PublicKey[:min(16, len(current.PublicKey))], vault.PublicKeyECDSA[:16])
+	}
+
+	pf, err := loadPolicyFile(configFile, vault, acctOverride)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("  Plugin: %s\n", current.PluginID)
+	fmt.Printf("  Vault: %s (%s...)\n", vault.Name, vault.PublicKeyECDSA[:16])
+	fmt.Printf("  Config: %s\n", configFile)
+	if !pf.account.IsDefault() {
+		fmt.Printf("  Derivation: %s (%s)\n", pf.account, pf.account.EVMDerivePath())
+	}
+
+	recipe, err := buildPolicyRecipe(current.PluginID, pf)
+	if err != nil {
+		return err
+	}
+
+	pluginVersion := current.PluginVersion
+
+	result := PolicyUpdateResult{
+		PolicyID:        policyID,
+		PluginID:        current.PluginID,
+		PublicKey:       vault.PublicKeyECDSA,
+		PreviousVersion: current.PolicyVersion,
+		PolicyVersion:   current.PolicyVersion,
+		PluginVersion:   pluginVersion,
+	}
+
+	result.Diff, err = policyDiff(current, recipe.base64, pluginVersion, recipe.billing)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nPolicy version: %d (kept by the verifier on update)\n", result.PolicyVersion)
+	if result.Diff == "" {
+		fmt.Println("No configuration changes; nothing to update.")
+		return printResult(result, func() {})
+	}
+	fmt.Println()
+	fmt.Print(result.Diff)
+
+	if dryRun {
+		fmt.Println("\nDry run: policy not updated.")
+		result.DurationMs = time.Since(startTime).Milliseconds()
+		return printResult(result, func() {})
+	}
+
+	signature, err := signPolicy(ctx, vault, recipe.base64, result.PolicyVersion, pluginVersion, password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\nSubmitting update to verifier...")
+	updated, err := client.UpdatePolicy(ctx, verifier.Policy{
+		ID:            policyID,
+		PluginID:      current.PluginID,
+		PublicKey:     vault.PublicKeyECDSA,
+		PluginVersion: pluginVersion,
+		PolicyVersion: result.PolicyVersion,
+		Signature:     signature,
+		Recipe:        recipe.base64,
+		Billing:       recipe.billing,
+		Active:        current.Active,
+	})
+	if err != nil {
+		return fmt.Errorf("update policy: %w", err)
+	}
+	// The update is applied; record what the verifier stored even if it is
+	// not what was signed, so the local registry matches the server.
+	if updated.PolicyVersion != result.PolicyVersion || updated.PluginVersion != pluginVersion {
+		fmt.Fprintf(os.Stderr, "Warning: verifier stored policy version %d (plugin version %s), signed was %d (plugin version %s)\n",
+			updated.PolicyVersion, updated.PluginVersion, result.PolicyVersion, pluginVersion)
+		if updated.PolicyVersion != 0 {
+			result.PolicyVersion = updated.PolicyVersion
+		}
+		if updated.PluginVersion != "" {
+			result.PluginVersion = updated.PluginVersion
+		}
+	}
+
+	entry := newLocalPolicy(policyID, vault, current.PluginID, pf, recipe, signature, result.PolicyVersion, result.PluginVersion)
+	entry.Active = current.Active
+	recordPolicy(entry)
+
+	result.Updated = true
+	totalDuration := time.Since(startTime)
+	result.DurationMs = totalDuration.Milliseconds()
+
+	return printResult(result, func() {
+		fmt.Println()
+		fmt.Println("┌─────────────────────────────────────────────────────────────────┐")
+		fmt.Println("│ POLICY UPDATED SUCCESSFULLY                                     │")
+		fmt.Println("├─────────────────────────────────────────────────────────────────┤")
+		fmt.Println("│                                                                 │")
+		fmt.Printf("│  Policy ID:      %-47s │\n", policyID)
+		fmt.Printf("│  Plugin:         %-47s │\n", current.PluginID)
+		fmt.Printf("│  Policy Version: %-47d │\n", result.PolicyVersion)
+		fmt.Printf("│  Plugin Version: %-47s │\n", result.PluginVersion)
+		fmt.Println("│                                                                 │")
+		fmt.Printf("│  Duration:       %-47s │\n", totalDuration.Round(time.Millisecond).String())
+		fmt.Println("│                                                                 │")
+		fmt.Println("└─────────────────────────────────────────────────────────────────┘")
+		fmt.Println()
+		fmt.Printf("Check it: vcli policy status %s\n", policyID)
+	})
+}
+
+// policyDiff returns a unified diff of the current policy and the new recipe,
+// plugin version and billing, or "" if nothing changed.
+func policyDiff(current *verifier.Policy, newRecipe, newPluginVersion string, newBilling []verifier.Billing) (string, error) {
+	before, err := policyDocument(current.Recipe, current.PluginVersion, current.Billing)
+	if err != nil {
+		return "", fmt.Errorf("decode current policy: %w", err)
+	}
+	after, err := policyDocument(newRecipe, newPluginVersion, newBilling)
+	if err != nil {
+		return "", fmt.Errorf("decode new policy: %w", err)
+	}
+	if before == after {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(before),
+		B:        difflib.SplitLines(after),
+		FromFile: fmt.Sprintf("current (v%d)", current.PolicyVersion),
+		ToFile:   "new",
+		Context:  3,
+	})
+}
+
+// policyDocument renders the updatable parts of a policy as indented JSON
+// with sorted keys, so two versions diff line by line.
+func policyDocument(recipe, pluginVersion string, billing []verifier.Billing) (string, error) {
+	policy, err := decodeRecipe(recipe)
+	if err != nil {
+		return "", err
+	}
+	// protojson output is not byte-stable; normalise through encoding/json
+	recipeJSON, err := protojson.Marshal(policy)
+	if err != nil {
+		return "", fmt.Errorf("marshal recipe: %w", err)
+	}
+	var recipeDoc any
+	err = json.Unmarshal(recipeJSON, &recipeDoc)
+	if err != nil {
+		return "", fmt.Errorf("normalise recipe: %w", err)
+	}
+
+	// Server-assigned billing fields differ between stored and new policies
+	type billingEntry struct {
+		Type      string  `json:"type"`
+		Frequency *string `json:"frequency,omitempty"`
+		Amount    uint64  `json:"amount"`
+		Asset     string  `json:"asset,omitempty"`
+	}
+	entries := []billingEntry{}
+	for _, b := range billing {
+		entries = append(entries, billingEntry{Type: b.Type, Frequency: b.Frequency, Amount: b.Amount, Asset: b.Asset})
+	}
+
+	doc, err := json.MarshalIndent(map[string]any{
+		"plugin_version": pluginVersion,
+		"recipe":         recipeDoc,
+		"billing":        entries,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(doc) + "\n", nil
+}
@@ -0,0 +1,614 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	rtypes "github.com/vultisig/recipes/types"
+	"github.com/vultisig/vultisig-go/common"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/vultisig/vcli/local/pkg/schema"
+	"github.com/vultisig/vcli/local/pkg/verifier"
+)
+
+func newPolicyValidateCmd() *cobra.Command {
+	var pluginID string
+	var configFile string
+	var offline bool
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a policy file without submitting it",
+		Long: `Validate a policy file locally against the plugin's recipe specification.
+
+Checks, each reported with the JSON path of the offending value:
+  - the recipe against the specification's configuration JSON schema
+  - chains against the plugin's supported chains
+  - addresses and token contracts (format for the chain; chains without a
+    format check, e.g. XRP or Zcash, are listed as not checked)
+  - amounts (positive integers in the smallest unit)
+  - billing entries against the plugin's pricing
+
+The specification and pricing are fetched from the verifier and cached in
+~/.vultisig/cache/plugins/. If the verifier is unreachable, or with --offline,
+the cached copy is used.
+
+Examples:
+  vcli policy validate --plugin dca --policy-file $(pwd)/local/policies/dca.json
+  vcli policy validate --plugin dca --policy-file dca.json --offline -o json
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Violations are the result, not a usage error
+			cmd.SilenceUsage = true
+			return runPolicyValidate(ResolvePluginID(pluginID), configFile, offline)
+		},
+	}
+
+	cmd.Flags().StringVar(&pluginID, "plugin", "", "Plugin ID or alias (required)")
+	cmd.Flags().StringVar(&configFile, "policy-file", "", "Policy configuration JSON file (required)")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Use only the cached specification and pricing")
+	cmd.MarkFlagRequired("plugin")
+	cmd.MarkFlagRequired("policy-file")
+
+	return withStructuredOutput(cmd)
+}
+
+type PolicyValidation struct {
+	PluginID   string             `json:"plugin_id"`
+	PolicyFile string             `json:"policy_file"`
+	SpecSource string             `json:"spec_source"`
+	FetchedAt  time.Time          `json:"fetched_at"`
+	Valid      bool               `json:"valid"`
+	Violations []schema.Violation `json:"violations"`
+	Unchecked  []schema.Violation `json:"unchecked"`
+}
+
+// pluginSpecCache is the cached recipe specification and pricing of a plugin.
+type pluginSpecCache struct {
+	PluginID            string                       `json:"plugin_id"`
+	FetchedAt           time.Time                    `json:"fetched_at"`
+	RecipeSpecification verifier.RecipeSpecification `json:"recipe_specification"`
+	Pricing             []verifier.Pricing           `json:"pricing"`
+}
+
+func runPolicyValidate(pluginID, configFile string, offline bool) error {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+	var policyConfig map[string]any
+	err = json.Unmarshal(data, &policyConfig)
+	if err != nil {
+		return fmt.Errorf("parse config file: %w", err)
+	}
+
+	fmt.Printf("Validating %s for plugin %s...\n", configFile, pluginID)
+	spec, source, err := loadPluginSpec(pluginID, offline)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  Specification: %s (fetched %s)\n", source, spec.FetchedAt.Format(time.RFC3339))
+
+	configSchema, supportedChains, err := parseRecipeSpec(spec.RecipeSpecification)
+	if err != nil {
+		return err
+	}
+
+	result := PolicyValidation{
+		PluginID:   pluginID,
+		PolicyFile: configFile,
+		SpecSource: source,
+		FetchedAt:  spec.FetchedAt,
+		Violations: []schema.Violation{},
+		Unchecked:  []schema.Violation{},
+	}
+
+	recipe, ok := policyConfig["recipe"].(map[string]any)
+	if !ok {
+		result.Violations = append(result.Violations, schema.Violation{Path: "$.recipe", Message: "is required and must be an object"})
+	} else {
+		if configSchema != nil {
+			result.Violations = append(result.Violations, schema.Validate(configSchema, recipe, "$.recipe")...)
+		}
+		violations, unchecked := validateRecipeValues(recipe, supportedChains)
+		result.Violations = append(result.Violations, violations...)
+		result.Unchecked = append(result.Unchecked, unchecked...)
+	}
+	result.Violations = append(result.Violations, validateBilling(policyConfig["billing"], spec.Pricing)...)
+	result.Valid = len(result.Violations) == 0
+
+	err = printResult(result, func() {
+		fmt.Println()
+		if result.Valid {
+			fmt.Println("✓ Policy is valid")
+		} else {
+			fmt.Printf("✗ %d violation(s):\n", len(result.Violations))
+			for _, v := range result.Violations {
+				fmt.Printf("  %s\n", v)
+			}
+		}
+		if len(result.Unchecked) > 0 {
+			fmt.Printf("! %d value(s) not checked:\n", len(result.Unchecked))
+			for _, v := range result.Unchecked {
+				fmt.Printf("  %s\n", v)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		return fmt.Errorf("policy is invalid: %d violation(s)", len(result.Violations))
+	}
+	return nil
+}
+
+func pluginSpecCachePath(pluginID string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir: %w", err)
+	}
+	return filepath.Join(home, ".vultisig", "cache", "plugins", pluginID+".json"), nil
+}
+
+// loadPluginSpec fetches the plugin's recipe specification and pricing and
+// refreshes the cache, falling back to the cache when the verifier is
+// unreachable. It returns the spec and where it came from.
+func loadPluginSpec(pluginID string, offline bool) (*pluginSpecCache, string, error) {
+	path, err := pluginSpecCachePath(pluginID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !offline {
+		spec, err := fetchPluginSpec(pluginID)
+		if err == nil {
+			data, err := json.MarshalIndent(spec, "", "  ")
+			if err == nil {
+				err = os.MkdirAll(filepath.Dir(path), 0755)
+			}
+			if err == nil {
+				err = os.WriteFile(path, data, 0644)
+			}
+			if err != nil {
+				fmt.Printf("  Warning: could not cache specification: %v\n", err)
+			}
+			return spec, "verifier", nil
+		}
+		fmt.Printf("  Verifier unavailable (%v), using cache\n", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, "", fmt.Errorf("no cached specification for %s; run without --offline while the verifier is up", pluginID)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("read cached specification: %w", err)
+	}
+	var spec pluginSpecCache
+	err = json.Unmarshal(data, &spec)
+	if err != nil {
+		return nil, "", fmt.Errorf("parse cached specification %s: %w", path, err)
+	}
+	return &spec, "cache", nil
+}
+
+func fetchPluginSpec(pluginID string) (*pluginSpecCache, error) {
+	client, err := newVerifierClient(nil)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	raw, err := client.RecipeSpecification(ctx, pluginID)
+	if err != nil {
+		return nil, fmt.Errorf("get recipe specification: %w", err)
+	}
+	pricing, err := fetchPluginPricing(pluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pluginSpecCache{
+		PluginID:            pluginID,
+		FetchedAt:           time.Now().UTC(),
+		RecipeSpecification: raw,
+		Pricing:             pricing,
+	}, nil
+}
+
+// parseRecipeSpec extracts the configuration JSON schema and supported chains
+// from a recipe specification. The verifier may encode the embedded
+// structpb.Struct with encoding/json, so that form is unwrapped too.
+func parseRecipeSpec(raw json.RawMessage) (map[string]any, []string, error) {
+	var spec rtypes.RecipeSchema
+	err := protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(raw, &spec)
+	if err == nil {
+		var configSchema map[string]any
+		if spec.GetConfiguration() != nil {
+			// protojson also accepts the wrapped form, as a plain object
+			configSchema, _ = unwrapStructpb(spec.GetConfiguration().AsMap()).(map[string]any)
+		}
+		return configSchema, spec.GetRequirements().GetSupportedChains(), nil
+	}
+
+	var doc struct {
+		Configuration any `json:"configuration"`
+		Requirements  struct {
+			SupportedChains []string `json:"supported_chains"`
+		} `json:"requirements"`
+	}
+	err = json.Unmarshal(raw, &doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse recipe specification: %w", err)
+	}
+	configSchema, _ := unwrapStructpb(doc.Configuration).(map[string]any)
+	return configSchema, doc.Requirements.SupportedChains, nil
+}
+
+// unwrapStructpb converts the encoding/json form of a structpb value
+// ({"fields": {...}}, {"Kind": {"StringValue": ...}}) to plain JSON values.
+func unwrapStructpb(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		if kind, ok := x["Kind"].(map[string]any); ok && len(x) == 1 {
+			for name, inner := range kind {
+				switch name {
+				case "StructValue":
+					return unwrapStructpb(inner)
+				case "ListValue":
+					m, _ := inner.(map[string]any)
+					values, _ := m["values"].([]any)
+					out := make([]any, 0, len(values))
+					for _, item := range values {
+						out = append(out, unwrapStructpb(item))
+					}
+					return out
+				case "NullValue":
+					return nil
+				default:
+					return inner
+				}
+			}
+		}
+		if fields, ok := x["fields"].(map[string]any); ok && len(x) == 1 {
+			out := make(map[string]any, len(fields))
+			for k, item := range fields {
+				out[k] = unwrapStructpb(item)
+			}
+			return out
+		}
+		out := make(map[string]any, len(x))
+		for k, item := range x {
+			out[k] = unwrapStructpb(item)
+		}
+		return out
+	case []any:
+		out := make([]any, 0, len(x))
+		for _, item := range x {
+			out = append(out, unwrapStructpb(item))
+		}
+		return out
+	}
+	return v
+}
+
+// validateRecipeValues checks chains, addresses and amounts anywhere in the
+// recipe. Addresses are checked against the chain of their own object, or
+// the recipe's source chain ("from"/"asset") for objects without one;
+// addresses on chains without a format check are returned as unchecked.
+func validateRecipeValues(recipe map[string]any, supportedChains []string) (violations, unchecked []schema.Violation) {
+
+	defaultChain := ""
+	for _, key := range []string{"from", "asset"} {
+		if m, ok := recipe[key].(map[string]any); ok {
+			if c, ok := m["chain"].(string); ok {
+				defaultChain = c
+				break
+			}
+		}
+	}
+
+	var walk func(v any, path, chain string)
+	walk = func(v any, path, chain string) {
+		switch x := v.(type) {
+		case []any:
+			for i, item := range x {
+				walk(item, fmt.Sprintf("%s[%d]", path, i), chain)
+			}
+		case map[string]any:
+			if c, ok := x["chain"].(string); ok {
+				chain = c
+				violations = append(violations, validateChain(c, schema.Child(path, "chain"), supportedChains)...)
+			}
+			keys := make([]string, 0, len(x))
+			for k := range x {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				child := schema.Child(path, k)
+				lower := strings.ToLower(k)
+				switch {
+				case strings.HasSuffix(lower, "address"), lower == "token":
+					s, ok := x[k].(string)
+					if !ok || (lower == "token" && !tokenIsAddress(chain)) {
+						continue
+					}
+					problems, checked := validateAddress(s, chain, child)
+					violations = append(violations, problems...)
+					if !checked {
+						unchecked = append(unchecked, schema.Violation{Path: child, Message: uncheckedAddress(chain)})
+					}
+				case strings.HasSuffix(lower, "amount"):
+					violations = append(violations, validateAmount(x[k], child)...)
+				default:
+					walk(x[k], child, chain)
+				}
+			}
+		}
+	}
+	walk(recipe, "$.recipe", defaultChain)
+
+	return violations, unchecked
+}
+
+func uncheckedAddress(chain string) string {
+	if chain == "" {
+		return "address format not checked (no chain)"
+	}
+	return fmt.Sprintf("address format not checked for %s", chain)
+}
+
+func validateChain(name, path string, supported []string) []schema.Violation {
+	if _, err := common.FromString(name); err != nil {
+		return []schema.Violation{{Path: path, Message: fmt.Sprintf("unknown chain %q", name)}}
+	}
+	if len(supported) == 0 {
+		return nil
+	}
+	for _, s := range supported {
+		if strings.EqualFold(s, name) {
+			return nil
+		}
+	}
+	return []schema.Violation{{Path: path, Message: fmt.Sprintf("chain %q is not supported by the plugin (supported: %s)", name, strings.Join(supported, ", "))}}
+}
+
+// validateAddress checks the format of a non-empty address or token contract.
+// Empty addresses are filled from the vault by 'policy add'; an empty token
+// means the chain's native asset. checked is false when vcli has no format
+// check for the chain, so the caller can report the value as unchecked.
+func validateAddress(addr, chainName, path string) (violations []schema.Violation, checked bool) {
+	if addr == "" {
+		return nil, true
+	}
+	if strings.TrimSpace(addr) != addr {
+		return []schema.Violation{{Path: path, Message: "has leading or trailing whitespace"}}, true
+	}
+	if chainName == "" {
+		return nil, false
+	}
+	chain, err := common.FromString(chainName)
+	if err != nil {
+		// Reported by validateChain
+		return nil, true
+	}
+	valid, known := addressFormat(chain, addr)
+	if !known {
+		return nil, false
+	}
+	if !valid {
+		return []schema.Violation{{Path: path, Message: fmt.Sprintf("%q is not a valid %s address", addr, chainName)}}, true
+	}
+	return nil, true
+}
+
+// bech32Prefixes are the address prefixes of Cosmos SDK chains.
+var bech32Prefixes = map[common.Chain]string{
+	common.THORChain:    "thor",
+	common.MayaChain:    "maya",
+	common.GaiaChain:    "cosmos",
+	common.Kujira:       "kujira",
+	common.Dydx:         "dydx",
+	common.Terra:        "terra",
+	common.TerraClassic: "terra",
+	common.Osmosis:      "osmo",
+	common.Noble:        "noble",
+}
+
+// addressFormat reports whether addr is well-formed for chain; known is false
+// for chains without a check (Bitcoin Cash, Zcash, XRP, Polkadot, TON).
+func addressFormat(chain common.Chain, addr string) (valid, known bool) {
+	if chain.IsEvm() {
+		return ethcommon.IsHexAddress(addr) && strings.HasPrefix(addr, "0x"), true
+	}
+	if hrp, ok := bech32Prefixes[chain]; ok {
+		return isBech32Account(addr, hrp), true
+	}
+	switch chain {
+	case common.Bitcoin:
+		return isSegwitAddress(addr, "bc") || isBase58Address(addr, 0x00, 0x05), true
+	case common.Litecoin:
+		return isSegwitAddress(addr, "ltc") || isBase58Address(addr, 0x30, 0x32, 0x05), true
+	case common.Dogecoin:
+		return isBase58Address(addr, 0x1e, 0x16), true
+	case common.Dash:
+		return isBase58Address(addr, 0x4c, 0x10), true
+	case common.Tron:
+		return isBase58Address(addr, 0x41), true
+	case common.Solana:
+		return len(base58.Decode(addr)) == 32, true
+	case common.Sui:
+		raw, err := hex.DecodeString(strings.TrimPrefix(addr, "0x"))
+		return strings.HasPrefix(addr, "0x") && err == nil && len(raw) == 32, true
+	}
+	return false, false
+}
+
+// tokenIsAddress reports whether "token" values on chain are contract or mint
+// addresses; elsewhere they are denoms or coin types.
+func tokenIsAddress(chainName string) bool {
+	chain, err := common.FromString(chainName)
+	return err == nil && (chain.IsEvm() || chain == common.Solana || chain == common.Tron)
+}
+
+// isBase58Address checks a base58check hash160 address with one of versions.
+func isBase58Address(addr string, versions ...byte) bool {
+	payload, version, err := base58.CheckDecode(addr)
+	return err == nil && len(payload) == 20 && slices.Contains(versions, version)
+}
+
+// isSegwitAddress checks a bech32 (v0) or bech32m (v1+) witness address.
+func isSegwitAddress(addr, hrp string) bool {
+	prefix, data, encoding, err := bech32.DecodeGeneric(addr)
+	if err != nil || !strings.EqualFold(prefix, hrp) || len(data) == 0 {
+		return false
+	}
+	program, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return false
+	}
+	switch witnessVersion := data[0]; {
+	case witnessVersion == 0:
+		return encoding == bech32.Version0 && (len(program) == 20 || len(program) == 32)
+	case witnessVersion <= 16:
+		return encoding == bech32.VersionM && len(program) >= 2 && len(program) <= 40
+	}
+	return false
+}
+
+// isBech32Account checks a Cosmos SDK account (20 bytes) or contract (32
+// bytes) address.
+func isBech32Account(addr, hrp string) bool {
+	prefix, data, err := bech32.Decode(addr)
+	if err != nil || prefix != hrp {
+		return false
+	}
+	raw, err := bech32.ConvertBits(data, 5, 8, false)
+	return err == nil && (len(raw) == 20 || len(raw) == 32)
+}
+
+// validateAmount requires a positive integer in the asset's smallest unit,
+// given as a string or a JSON number.
+func validateAmount(v any, path string) []schema.Violation {
+	var s string
+	switch x := v.(type) {
+	case string:
+		s = x
+	case float64:
+		s = big.NewFloat(x).Text('f', -1)
+	default:
+		return []schema.Violation{{Path: path, Message: "must be a string or number"}}
+	}
+
+	if strings.ContainsAny(s, ".eE") {
+		return []schema.Violation{{Path: path, Message: fmt.Sprintf("%q must be an integer in the smallest unit (e.g. wei)", s)}}
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return []schema.Violation{{Path: path, Message: fmt.Sprintf("%q is not a number", s)}}
+	}
+	if n.Sign() <= 0 {
+		return []schema.Violation{{Path: path, Message: "must be greater than zero"}}
+	}
+	return nil
+}
+
+// validateBilling matches billing entries to the plugin's pricing by type and
+// frequency; the verifier rejects policies whose billing doesn't match.
+func validateBilling(billingConfig any, pricing []verifier.Pricing) []schema.Violation {
+	var violations []schema.Violation
+
+	var entries []any
+	switch b := billingConfig.(type) {
+	case nil:
+	case []any:
+		entries = b
+	case map[string]any:
+		entries = []any{b}
+	default:
+		return []schema.Violation{{Path: "$.billing", Message: "must be an array of billing entries"}}
+	}
+
+	if len(entries) != len(pricing) {
+		violations = append(violations, schema.Violation{
+			Path:    "$.billing",
+			Message: fmt.Sprintf("has %d entries, plugin pricing has %d", len(entries), len(pricing)),
+		})
+	}
+
+	used := make([]bool, len(pricing))
+	for i, e := range entries {
+		path := fmt.Sprintf("$.billing[%d]", i)
+		entry, ok := e.(map[string]any)
+		if !ok {
+			violations = append(violations, schema.Violation{Path: path, Message: "must be an object"})
+			continue
+		}
+		typ, _ := entry["type"].(string)
+		freq, _ := entry["frequency"].(string)
+		amount, hasAmount := entry["amount"].(float64)
+		if !hasAmount {
+			violations = append(violations, schema.Violation{Path: path + ".amount", Message: "must be a number"})
+		}
+
+		match := -1
+		for j, p := range pricing {
+			if used[j] || !strings.EqualFold(p.Type, typ) {
+				continue
+			}
+			if derefOr(p.Frequency, "") != freq {
+				continue
+			}
+			match = j
+			break
+		}
+		if match < 0 {
+			violations = append(violations, schema.Violation{
+				Path:    path,
+				Message: fmt.Sprintf("no plugin pricing for type %q frequency %q (plugin prices: %s)", typ, freq, pricingSummary(pricing)),
+			})
+			continue
+		}
+		used[match] = true
+		if hasAmount && uint64(amount) != pricing[match].Amount {
+			violations = append(violations, schema.Violation{
+				Path:    path + ".amount",
+				Message: fmt.Sprintf("is %v, plugin charges %d", amount, pricing[match].Amount),
+			})
+		}
+	}
+
+	return violations
+}
+
+func pricingSummary(pricing []verifier.Pricing) string {
+	if len(pricing) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(pricing))
+	for _, p := range pricing {
+		s := p.Type
+		if f := derefOr(p.Frequency, ""); f != "" {
+			s += "/" + f
+		}
+		parts = append(parts, fmt.Sprintf("%s=%d", s, p.Amount))
+	}
+	return strings.Join(parts, ", ")
+}
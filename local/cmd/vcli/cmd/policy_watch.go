@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
+)
+
+// Watch events, in the order a transaction goes through them.
+const (
+	watchScheduled = "scheduled"
+	watchPickedUp  = "picked_up"
+	watchSigned    = "signed"
+	watchBroadcast = "broadcast"
+	watchConfirmed = "confirmed"
+	watchFailed    = "failed"
+	watchTimeout   = "timeout"
+)
+
+// Exit codes of 'policy watch'. Other errors exit 1 as usual.
+const (
+	watchExitFailed  = 1
+	watchExitTimeout = 2
+)
+
+var watchStages = []string{watchPickedUp, watchSigned, watchBroadcast, watchConfirmed}
+
+func newPolicyWatchCmd() *cobra.Command {
+	var untilTx int
+	var timeout, interval time.Duration
+	var ndjson bool
+
+	cmd := &cobra.Command{
+		Use:   "watch [policy-id]",
+		Short: "Follow a policy's executions until they confirm or fail",
+		Long: `Poll the scheduler, the plugin's tx_indexer and the verifier's transaction
+history, and print each state change of the policy's transactions as it
+happens: scheduled, picked_up, signed, broadcast, then confirmed or failed.
+
+The command exits once --until-tx transactions are confirmed on-chain.
+Transactions that had already confirmed or failed when it started are
+ignored; ones still in flight are followed. Exit codes:
+
+  0  --until-tx transactions confirmed
+  1  a transaction failed on-chain (or any other error)
+  2  --timeout reached
+
+With --ndjson each event is written to stdout as one JSON object per line.
+
+Examples:
+  # Block CI until the first execution is confirmed
+  vcli policy watch <policy-id> --timeout 20m
+
+  vcli policy watch <policy-id> --until-tx 3 --ndjson > events.ndjson
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if untilTx < 1 {
+				return fmt.Errorf("--until-tx must be at least 1")
+			}
+			cmd.SilenceUsage = true
+			return runPolicyWatch(args[0], untilTx, timeout, interval, ndjson)
+		},
+	}
+
+	cmd.Flags().IntVar(&untilTx, "until-tx", 1, "Exit after this many transactions are confirmed")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "Give up after this long")
+	cmd.Flags().DurationVar(&interval, "interval", 10*time.Second, "Polling interval")
+	cmd.Flags().BoolVar(&ndjson, "ndjson", false, "Write events as newline-delimited JSON")
+
+	return cmd
+}
+
+// WatchEvent is one state change reported by 'policy watch'.
+type WatchEvent struct {
+	Time          string `json:"time"`
+	Event         string `json:"event"`
+	PolicyID      string `json:"policy_id"`
+	TxID          string `json:"tx_id,omitempty"`
+	TxHash        string `json:"tx_hash,omitempty"`
+	Status        string `json:"status,omitempty"`
+	OnChainStatus string `json:"status_onchain,omitempty"`
+	NextExecution string `json:"next_execution,omitempty"`
+	ExplorerURL   string `json:"explorer_url,omitempty"`
+	Source        string `json:"source,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+// watchedTx is a transaction seen in tx_indexer or the verifier history.
+// Both sources describe the same transaction, so entries are matched by ID
+// and, once known, by hash.
+type watchedTx struct {
+	id    string
+	hash  string
+	stage int // index into watchStages of the last reported stage, -1 for none
+	done  bool
+}
+
+type policyWatcher struct {
+	policyID string
+	pluginID string // resolved on the first poll that can read the verifier DB
+	ndjson   bool
+	chain    string
+	client   *verifier.Client
+
+	nextExecution string
+	dbError       string
+	snapshot      bool // recording transactions that finished before the watch
+	txs           []*watchedTx
+	confirmed     int
+	failed        *watchedTx
+}
+
+func runPolicyWatch(policyID string, untilTx int, timeout, interval time.Duration, ndjson bool) error {
+	w := &policyWatcher{policyID: policyID, ndjson: ndjson, chain: getPolicyChain(policyID)}
+
+	// A watch runs unattended, so it never prompts to re-authenticate
+	vault, err := ActiveVault()
+	if err == nil {
+		w.client, err = newStoredAuthClient(vault)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Verifier history unavailable (%v); watching the databases only\n", err)
+	}
+
+	if !ndjson {
+		fmt.Printf("Watching policy %s (until %d confirmed, timeout %s)\n\n", policyID, untilTx, timeout)
+	}
+
+	// Like the scheduler round, only count what happens from here on: an old
+	// failure must not end the watch and old confirmations must not count.
+	w.snapshot = true
+	w.poll()
+	w.snapshot = false
+
+	deadline := time.Now().Add(timeout)
+	for {
+		w.poll()
+
+		if w.failed != nil {
+			return &ExitError{Code: watchExitFailed, Err: fmt.Errorf("transaction %s failed on-chain", w.failed.label())}
+		}
+		if w.confirmed >= untilTx {
+			if !ndjson {
+				fmt.Printf("\n✓ %d transaction(s) confirmed\n", w.confirmed)
+			}
+			return nil
+		}
+		if time.Now().After(deadline) {
+			w.emit(WatchEvent{Event: watchTimeout, Message: fmt.Sprintf("%d of %d transaction(s) confirmed after %s", w.confirmed, untilTx, timeout)})
+			return &ExitError{Code: watchExitTimeout, Err: fmt.Errorf("timed out after %s with %d of %d transaction(s) confirmed", timeout, w.confirmed, untilTx)}
+		}
+		time.Sleep(interval)
+	}
+}
+
+// poll reads every source once and reports what changed since the last poll.
+func (w *policyWatcher) poll() {
+	if w.pluginID == "" {
+		pluginID, err := policyPluginID(w.policyID)
+		w.reportDBError(err)
+		w.pluginID = pluginID
+	}
+	if w.pluginID != "" {
+		w.pollPluginDB()
+	}
+
+	if w.client == nil {
+		return
+	}
+	history, err := w.client.PolicyHistory(context.Background(), w.policyID, verifier.Page{Take: 50})
+	if err != nil {
+		return
+	}
+	for i := len(history.History) - 1; i >= 0; i-- {
+		t := history.History[i]
+		w.update(t.ID, derefOr(t.TxHash, ""), t.Status, derefOr(t.StatusOnChain, ""), t.BroadcastedAt != nil, "verifier")
+	}
+}
+
+// pollPluginDB reads the scheduler and tx_indexer of the policy's plugin.
+func (w *policyWatcher) pollPluginDB() {
+	next, schedErr := pluginNextExecution(w.pluginID, w.policyID)
+	if schedErr == nil && !w.snapshot {
+		if next != "" && next != w.nextExecution {
+			w.emit(WatchEvent{Event: watchScheduled, NextExecution: next, Source: "scheduler"})
+		}
+		w.nextExecution = next
+	}
+
+	// Oldest first, so events come out in execution order
+	records, txErr := pluginTransactions(w.pluginID, w.policyID, 50)
+	w.reportDBError(errors.Join(schedErr, txErr))
+	for i := len(records) - 1; i >= 0; i-- {
+		r := records[i]
+		w.update(r.ID, r.TxHash, r.Status, r.OnChainStatus, false, "tx_indexer")
+	}
+}
+
+// reportDBError warns on stderr when database reads start failing, once per
+// distinct error, without ending the watch.
+func (w *policyWatcher) reportDBError(err error) {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	if msg != "" && msg != w.dbError {
+		fmt.Fprintf(os.Stderr, "Warning: database read failed: %s\n", msg)
+	}
+	w.dbError = msg
+}
+
+// update reports the stages a transaction reached since it was last seen.
+// During the startup snapshot it only records finished transactions, which
+// are then skipped.
+func (w *policyWatcher) update(id, hash, status, onChain string, broadcast bool, source string) {
+	if w.snapshot {
+		if onChain == "SUCCESS" || onChain == "FAIL" {
+			tx := w.lookup(id, hash)
+			if tx.hash == "" {
+				tx.hash = hash
+			}
+			tx.stage = len(watchStages) - 1
+			tx.done = true
+		}
+		return
+	}
+
+	tx := w.lookup(id, hash)
+	if tx.hash == "" {
+		tx.hash = hash
+	}
+	if tx.done {
+		return
+	}
+
+	reached := 0 // picked_up: the transaction exists
+	switch {
+	case onChain == "SUCCESS" || onChain == "FAIL":
+		reached = 3
+	case hash != "" || broadcast || (status == "SIGNED" && onChain == "PENDING"):
+		reached = 2
+	case status == "SIGNED":
+		reached = 1
+	}
+
+	for tx.stage < reached {
+		tx.stage++
+		event := watchStages[tx.stage]
+		if tx.stage == 3 && onChain == "FAIL" {
+			event = watchFailed
+		}
+		ev := WatchEvent{
+			Event:         event,
+			TxID:          tx.id,
+			TxHash:        tx.hash,
+			Status:        status,
+			OnChainStatus: onChain,
+			Source:        source,
+		}
+		if tx.hash != "" && tx.stage >= 2 {
+			ev.ExplorerURL = getExplorerURLForChain(w.chain, tx.hash)
+		}
+		w.emit(ev)
+	}
+
+	if tx.stage == 3 {
+		tx.done = true
+		if onChain == "FAIL" {
+			if w.failed == nil {
+				w.failed = tx
+			}
+		} else {
+			w.confirmed++
+		}
+	}
+}
+
+func (w *policyWatcher) lookup(id, hash string) *watchedTx {
+	for _, tx := range w.txs {
+		if (id != "" && tx.id == id) || (hash != "" && tx.hash == hash) {
+			return tx
+		}
+	}
+	tx := &watchedTx{id: id, hash: hash, stage: -1}
+	w.txs = append(w.txs, tx)
+	return tx
+}
+
+func (tx *watchedTx) label() string {
+	if tx.hash != "" {
+		return tx.hash
+	}
+	return tx.id
+}
+
+func (w *policyWatcher) emit(ev WatchEvent) {
+	ev.Time = time.Now().UTC().Format(time.RFC3339)
+	ev.PolicyID = w.policyID
+
+	if w.ndjson {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	line := fmt.Sprintf("[%s] %-10s", time.Now().Format("15:04:05"), ev.Event)
+	switch ev.Event {
+	case watchScheduled:
+		line += " next execution " + ev.NextExecution
+	case watchTimeout:
+		line += " " + ev.Message
+	default:
+		line += " tx " + truncate(ev.TxID, 12)
+		if ev.TxHash != "" {
+			line += " " + ev.TxHash
+		}
+		if ev.OnChainStatus != "" {
+			line += " (" + ev.Status + "/" + ev.OnChainStatus + ")"
+		} else if ev.Status != "" {
+			line += " (" + ev.Status + ")"
+		}
+	}
+	fmt.Println(line)
+	if ev.ExplorerURL != "" && (ev.Event == watchConfirmed || ev.Event == watchFailed) {
+		fmt.Printf("           %s\n", ev.ExplorerURL)
+	}
+}
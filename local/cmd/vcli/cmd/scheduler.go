@@ -0,0 +1,542 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vcli/local/pkg/devdb"
+)
+
+func NewSchedulerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scheduler",
+		Short: "Time-travel controls for recurring policies",
+		Long: `Inspect and move the plugin scheduler's next_execution times, so daily,
+weekly or monthly policies can be exercised in minutes.
+
+All subcommands select policies the same way:
+  --policy <id>     one policy
+  --plugin <id>     every active policy of a plugin (alias or full ID)
+  (neither)         every scheduled policy
+
+Each plugin keeps its scheduler in its own database (see the plugin's
+descriptor). Without --policy or --plugin every known plugin database is
+read, and ones that cannot be reached are skipped with a warning.
+`,
+	}
+
+	cmd.AddCommand(newSchedulerListCmd())
+	cmd.AddCommand(newSchedulerAdvanceCmd())
+	cmd.AddCommand(newSchedulerRunNextCmd())
+
+	return cmd
+}
+
+// addSchedulerTargetFlags registers the --policy/--plugin selection shared by
+// the scheduler subcommands.
+func addSchedulerTargetFlags(cmd *cobra.Command, policyID, pluginID *string) {
+	cmd.Flags().StringVar(policyID, "policy", "", "Policy ID")
+	cmd.Flags().StringVar(pluginID, "plugin", "", "Plugin ID or alias (all of its active policies)")
+	cmd.MarkFlagsMutuallyExclusive("policy", "plugin")
+}
+
+// schedulerTargets returns the policy IDs selected by --policy or --plugin,
+// keyed by the plugin whose database holds their scheduler entries. Without
+// either flag every plugin database is selected with a nil ID list, meaning
+// all of its scheduled policies.
+func schedulerTargets(policyID, pluginID string) (map[string][]string, error) {
+	if policyID != "" {
+		pluginID, err := policyPluginID(policyID)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]string{pluginID: {policyID}}, nil
+	}
+	if pluginID == "" {
+		return schedulerDatabases()
+	}
+
+	pluginID = ResolvePluginID(pluginID)
+	var ids []string
+	err := withDB(openVerifierDB, func(ctx context.Context, db *devdb.DB) error {
+		var err error
+		ids, err = db.PolicyIDs(ctx, pluginID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list policies of %s: %w", pluginID, err)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("plugin %s has no active policies", pluginID)
+	}
+	return map[string][]string{pluginID: ids}, nil
+}
+
+// schedulerDatabases selects every known plugin database once; plugins that
+// share a database (DCA and sends) are read through the first of them.
+func schedulerDatabases() (map[string][]string, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	targets := map[string][]string{}
+	dsns := map[string]bool{}
+	for _, p := range loadPluginRegistry(pluginSyncNever).plugins {
+		dsn, err := pluginDatabaseDSN(cfg, p.Descriptor)
+		if err != nil || dsns[dsn] {
+			continue
+		}
+		dsns[dsn] = true
+		targets[p.ID] = nil
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no plugin databases configured")
+	}
+	return targets, nil
+}
+
+// withSchedulerDBs runs fn against the database of each plugin in targets.
+// A database selected as a whole (nil IDs) that cannot be read is skipped
+// with a warning, since not every plugin runs locally, unless none can be.
+func withSchedulerDBs(targets map[string][]string, fn func(ctx context.Context, db *devdb.DB, pluginID string, ids []string) error) error {
+	var skipped error
+	read := 0
+	for _, pluginID := range sortedKeys(targets) {
+		ids := targets[pluginID]
+		label := pluginDescriptor(pluginID).Label
+		err := withDB(pluginDB(pluginID), func(ctx context.Context, db *devdb.DB) error {
+			return fn(ctx, db, pluginID, ids)
+		})
+		if err != nil && ids == nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping the %s scheduler: %v\n", label, err)
+			skipped = fmt.Errorf("%s: %w", label, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", label, err)
+		}
+		read++
+	}
+	if read == 0 && skipped != nil {
+		return skipped
+	}
+	return nil
+}
+
+// scheduledPolicy is a scheduler entry and the plugin whose database holds it.
+type scheduledPolicy struct {
+	devdb.ScheduledPolicy
+	pluginID string
+}
+
+// scheduledPolicies returns the scheduler entries of the targets, soonest
+// first.
+func scheduledPolicies(targets map[string][]string) ([]scheduledPolicy, error) {
+	var entries []scheduledPolicy
+	err := withSchedulerDBs(targets, func(ctx context.Context, db *devdb.DB, pluginID string, ids []string) error {
+		scheduled, err := db.Scheduled(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, e := range scheduled {
+			entries = append(entries, scheduledPolicy{ScheduledPolicy: e, pluginID: pluginID})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read scheduler: %w", err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].NextExecution.Before(entries[j].NextExecution)
+	})
+	return entries, nil
+}
+
+// ScheduledEntry is one policy in the result of 'scheduler list' and
+// 'scheduler advance'. Before is only set by advance.
+type ScheduledEntry struct {
+	PolicyID      string `json:"policy_id"`
+	Before        string `json:"before,omitempty"`
+	NextExecution string `json:"next_execution"`
+	Due           bool   `json:"due"`
+}
+
+func scheduledEntry(e scheduledPolicy, now time.Time) ScheduledEntry {
+	return ScheduledEntry{
+		PolicyID:      e.PolicyID,
+		NextExecution: e.NextExecution.Format(dbTimeFormat),
+		Due:           !e.NextExecution.After(now),
+	}
+}
+
+func printScheduledEntries(entries []ScheduledEntry) {
+	for _, e := range entries {
+		due := ""
+		if e.Due {
+			due = "  (due)"
+		}
+		if e.Before != "" {
+			fmt.Printf("  %s  %s -> %s%s\n", e.PolicyID, e.Before, e.NextExecution, due)
+		} else {
+			fmt.Printf("  %s  %s%s\n", e.PolicyID, e.NextExecution, due)
+		}
+	}
+}
+
+func newSchedulerListCmd() *cobra.Command {
+	var policyID, pluginID string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Show the next execution of scheduled policies",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return runSchedulerList(policyID, pluginID)
+		},
+	}
+
+	addSchedulerTargetFlags(cmd, &policyID, &pluginID)
+
+	return withStructuredOutput(cmd)
+}
+
+func runSchedulerList(policyID, pluginID string) error {
+	targets, err := schedulerTargets(policyID, pluginID)
+	if err != nil {
+		return err
+	}
+	scheduled, err := scheduledPolicies(targets)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entries := []ScheduledEntry{}
+	for _, e := range scheduled {
+		entries = append(entries, scheduledEntry(e, now))
+	}
+
+	return printResult(entries, func() {
+		if len(entries) == 0 {
+			fmt.Println("No scheduled policies found.")
+			return
+		}
+		fmt.Printf("Scheduled policies (%d):\n\n", len(entries))
+		printScheduledEntries(entries)
+	})
+}
+
+// SchedulerAdvance is the result of 'scheduler advance'.
+type SchedulerAdvance struct {
+	By       string           `json:"by"`
+	Policies []ScheduledEntry `json:"policies"`
+}
+
+func newSchedulerAdvanceCmd() *cobra.Command {
+	var policyID, pluginID string
+	var by time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "advance",
+		Short: "Move next executions earlier, as if time had passed",
+		Long: `Subtract --by from the next_execution of the selected policies, as if that
+much time had passed. Policies whose next execution is now in the past are
+picked up on the scheduler's next poll (within about 30 seconds).
+
+Examples:
+  vcli scheduler advance --by 24h --policy <policy-id>
+  vcli scheduler advance --by 168h --plugin dca    # one week, every DCA policy
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if by <= 0 {
+				return fmt.Errorf("--by must be a positive duration (e.g. 24h)")
+			}
+			cmd.SilenceUsage = true
+			return runSchedulerAdvance(policyID, pluginID, by)
+		},
+	}
+
+	addSchedulerTargetFlags(cmd, &policyID, &pluginID)
+	cmd.Flags().DurationVar(&by, "by", 0, "How far to advance (e.g. 24h, 168h)")
+	cmd.MarkFlagRequired("by")
+
+	return withStructuredOutput(cmd)
+}
+
+func runSchedulerAdvance(policyID, pluginID string, by time.Duration) error {
+	targets, err := schedulerTargets(policyID, pluginID)
+	if err != nil {
+		return err
+	}
+
+	var before, after []scheduledPolicy
+	err = withSchedulerDBs(targets, func(ctx context.Context, db *devdb.DB, pluginID string, ids []string) error {
+		scheduled, err := db.Scheduled(ctx, ids)
+		if err != nil || len(scheduled) == 0 {
+			return err
+		}
+
+		ids = make([]string, len(scheduled))
+		for i, e := range scheduled {
+			ids[i] = e.PolicyID
+			before = append(before, scheduledPolicy{ScheduledPolicy: e, pluginID: pluginID})
+		}
+		_, err = db.ShiftNextExecution(ctx, ids, by)
+		if err != nil {
+			return err
+		}
+		scheduled, err = db.Scheduled(ctx, ids)
+		for _, e := range scheduled {
+			after = append(after, scheduledPolicy{ScheduledPolicy: e, pluginID: pluginID})
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("advance scheduler: %w", err)
+	}
+	if len(before) == 0 {
+		return fmt.Errorf("no scheduled policies found")
+	}
+
+	prev := make(map[string]time.Time, len(before))
+	for _, e := range before {
+		prev[e.PolicyID] = e.NextExecution
+	}
+
+	now := time.Now()
+	result := SchedulerAdvance{By: by.String(), Policies: []ScheduledEntry{}}
+	for _, e := range after {
+		entry := scheduledEntry(e, now)
+		entry.Before = prev[e.PolicyID].Format(dbTimeFormat)
+		result.Policies = append(result.Policies, entry)
+	}
+
+	return printResult(result, func() {
+		fmt.Printf("✓ Advanced %d policies by %s:\n\n", len(result.Policies), result.By)
+		printScheduledEntries(result.Policies)
+		fmt.Println("\nDue policies are picked up within 30 seconds. Follow them with:")
+		fmt.Println("  vcli policy watch <policy-id>")
+	})
+}
+
+// SchedulerExecution is one execution fired by 'scheduler run-next'.
+// NextExecution is when the scheduler rescheduled the policy afterwards,
+// empty if it dropped the policy (e.g. its last execution).
+type SchedulerExecution struct {
+	Round         int        `json:"round"`
+	PolicyID      string     `json:"policy_id"`
+	Transactions  []TxRecord `json:"transactions"`
+	NextExecution string     `json:"next_execution,omitempty"`
+}
+
+// SchedulerRun is the result of 'scheduler run-next'.
+type SchedulerRun struct {
+	Rounds     int                  `json:"rounds"`
+	Executions []SchedulerExecution `json:"executions"`
+	Error      string               `json:"error,omitempty"`
+}
+
+func newSchedulerRunNextCmd() *cobra.Command {
+	var policyID, pluginID string
+	var timeout, interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "run-next [N]",
+		Short: "Fire the next N executions, one after another",
+		Long: `Fire the selected policies N times in a row. Each round sets next_execution
+to now and waits until every policy has a new transaction in the plugin's
+tx_indexer before starting the next round, so a monthly policy can be run
+through N months in minutes.
+
+A policy the scheduler no longer holds after an execution (for example one
+that reached its end date) is dropped from later rounds.
+
+Examples:
+  vcli scheduler run-next 3 --policy <policy-id>
+  vcli scheduler run-next 2 --plugin dca --timeout 10m
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rounds int
+			_, err := fmt.Sscanf(args[0], "%d", &rounds)
+			if err != nil || rounds < 1 {
+				return fmt.Errorf("N must be a positive number, got %q", args[0])
+			}
+			cmd.SilenceUsage = true
+			return runSchedulerRunNext(policyID, pluginID, rounds, timeout, interval)
+		},
+	}
+
+	addSchedulerTargetFlags(cmd, &policyID, &pluginID)
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "How long to wait for each round's transactions")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Polling interval")
+
+	return withStructuredOutput(cmd)
+}
+
+func runSchedulerRunNext(policyID, pluginID string, rounds int, timeout, interval time.Duration) error {
+	targets, err := schedulerTargets(policyID, pluginID)
+	if err != nil {
+		return err
+	}
+	scheduled, err := scheduledPolicies(targets)
+	if err != nil {
+		return err
+	}
+	if len(scheduled) == 0 {
+		return fmt.Errorf("no scheduled policies found")
+	}
+
+	pending := make([]string, len(scheduled))
+	pluginOf := make(map[string]string, len(scheduled))
+	for i, e := range scheduled {
+		pending[i] = e.PolicyID
+		pluginOf[e.PolicyID] = e.pluginID
+	}
+
+	result := SchedulerRun{Executions: []SchedulerExecution{}}
+	for round := 1; round <= rounds && len(pending) > 0; round++ {
+		fmt.Printf("Round %d/%d: firing %d policies\n", round, rounds, len(pending))
+
+		executions, err := runSchedulerRound(round, pending, pluginOf, timeout, interval)
+		result.Executions = append(result.Executions, executions...)
+		if err != nil {
+			result.Error = err.Error()
+			break
+		}
+		result.Rounds = round
+
+		pending = pending[:0]
+		for _, e := range executions {
+			if e.NextExecution != "" {
+				pending = append(pending, e.PolicyID)
+			}
+		}
+	}
+
+	err = printResult(result, func() {
+		fmt.Printf("\nExecutions (%d rounds):\n\n", result.Rounds)
+		for _, e := range result.Executions {
+			fmt.Printf("  Round %d  %s\n", e.Round, e.PolicyID)
+			for _, tx := range e.Transactions {
+				onChain := tx.OnChainStatus
+				if onChain == "" {
+					onChain = "-"
+				}
+				fmt.Printf("    tx %s  %s  %s", truncate(tx.ID, 12), tx.Status, onChain)
+				if tx.TxHash != "" {
+					fmt.Printf("  %s", tx.TxHash)
+				}
+				fmt.Println()
+			}
+			if e.NextExecution != "" {
+				fmt.Printf("    next execution: %s\n", e.NextExecution)
+			} else {
+				fmt.Println("    no longer scheduled")
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if result.Error != "" {
+		return errors.New(result.Error)
+	}
+	return nil
+}
+
+// runSchedulerRound fires every policy once and waits until each has a new
+// transaction in its plugin's tx_indexer. pluginOf maps each policy to its
+// plugin. It returns the executions seen so far even when it fails.
+func runSchedulerRound(round int, policyIDs []string, pluginOf map[string]string, timeout, interval time.Duration) ([]SchedulerExecution, error) {
+	// Transactions that exist before firing are not this round's
+	seen := make(map[string]map[string]bool, len(policyIDs))
+	byPlugin := map[string][]string{}
+	for _, id := range policyIDs {
+		byPlugin[pluginOf[id]] = append(byPlugin[pluginOf[id]], id)
+		txs, err := pluginTransactions(pluginOf[id], id, 50)
+		if err != nil {
+			return nil, fmt.Errorf("read transactions of %s: %w", id, err)
+		}
+		seen[id] = map[string]bool{}
+		for _, tx := range txs {
+			seen[id][tx.ID] = true
+		}
+	}
+
+	err := withSchedulerDBs(byPlugin, func(ctx context.Context, db *devdb.DB, pluginID string, ids []string) error {
+		for _, id := range ids {
+			updated, err := db.TriggerNow(ctx, id)
+			if err != nil {
+				return err
+			}
+			if updated == 0 {
+				return fmt.Errorf("policy %s is not in the scheduler", id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("trigger policies: %w", err)
+	}
+
+	var executions []SchedulerExecution
+	waiting := append([]string(nil), policyIDs...)
+	deadline := time.Now().Add(timeout)
+	var lastErr error
+	for len(waiting) > 0 {
+		if time.Now().After(deadline) {
+			msg := fmt.Sprintf("round %d: timed out after %s waiting for %s", round, timeout, strings.Join(waiting, ", "))
+			if lastErr != nil {
+				msg += fmt.Sprintf(" (last error: %v)", lastErr)
+			}
+			return executions, errors.New(msg)
+		}
+		time.Sleep(interval)
+
+		var still []string
+		for _, id := range waiting {
+			txs, err := pluginTransactions(pluginOf[id], id, 50)
+			if err != nil {
+				lastErr = err
+				still = append(still, id)
+				continue
+			}
+
+			var fresh []TxRecord
+			for i := len(txs) - 1; i >= 0; i-- {
+				if !seen[id][txs[i].ID] {
+					fresh = append(fresh, txs[i])
+				}
+			}
+			if len(fresh) == 0 {
+				still = append(still, id)
+				continue
+			}
+
+			next, err := pluginNextExecution(pluginOf[id], id)
+			if err != nil {
+				lastErr = err
+				still = append(still, id)
+				continue
+			}
+			fmt.Printf("  ✓ %s: %d new transaction(s)\n", id, len(fresh))
+			executions = append(executions, SchedulerExecution{
+				Round:         round,
+				PolicyID:      id,
+				Transactions:  fresh,
+				NextExecution: next,
+			})
+		}
+		waiting = still
+	}
+	return executions, nil
+}
@@ -10,13 +10,31 @@ import (
 )
 
 func NewStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	return withStructuredOutput(&cobra.Command{
 		Use:   "status",
 		Short: "Check status of all services",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStatus()
 		},
-	}
+	})
+}
+
+type ServiceStatus struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+}
+
+type StatusVault struct {
+	Name           string `json:"name"`
+	PublicKeyECDSA string `json:"public_key_ecdsa"`
+}
+
+type StatusResult struct {
+	Services       []ServiceStatus `json:"services"`
+	Infrastructure []ServiceStatus `json:"infrastructure"`
+	Vault          *StatusVault    `json:"vault"`
+	ConfigPath     string          `json:"config_path"`
 }
 
 func runStatus() error {
@@ -25,8 +43,7 @@ func runStatus() error {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	fmt.Println("Service Status")
-	fmt.Println("==============")
+	result := StatusResult{ConfigPath: ConfigPath()}
 
 	services := []struct {
 		name string
@@ -38,16 +55,13 @@ func runStatus() error {
 	}
 
 	for _, svc := range services {
-		status := checkHealth(svc.url)
-		if status {
-			fmt.Printf("  %-15s %s\n", svc.name+":", "OK")
-		} else {
-			fmt.Printf("  %-15s %s\n", svc.name+":", "DOWN")
-		}
+		result.Services = append(result.Services, ServiceStatus{
+			Name:   svc.name,
+			URL:    svc.url,
+			Status: upOrDown(checkHealth(svc.url)),
+		})
 	}
 
-	fmt.Println("\nInfrastructure:")
-
 	infraServices := []struct {
 		name string
 		url  string
@@ -64,24 +78,46 @@ func runStatus() error {
 		} else {
 			status = checkPort(svc.url)
 		}
-		if status {
-			fmt.Printf("  %-15s %s\n", svc.name+":", "OK")
-		} else {
-			fmt.Printf("  %-15s %s\n", svc.name+":", "DOWN")
-		}
+		result.Infrastructure = append(result.Infrastructure, ServiceStatus{
+			Name:   svc.name,
+			URL:    svc.url,
+			Status: upOrDown(status),
+		})
 	}
 
-	fmt.Println("\nVault:")
 	if cfg.PublicKeyECDSA != "" {
-		fmt.Printf("  Name: %s\n", cfg.VaultName)
-		fmt.Printf("  Public Key (ECDSA): %s...\n", cfg.PublicKeyECDSA[:32])
-	} else {
-		fmt.Println("  No vault configured")
+		result.Vault = &StatusVault{Name: cfg.VaultName, PublicKeyECDSA: cfg.PublicKeyECDSA}
 	}
 
-	fmt.Println("\nConfig file:", ConfigPath())
+	return printResult(result, func() {
+		fmt.Println("Service Status")
+		fmt.Println("==============")
+		for _, svc := range result.Services {
+			fmt.Printf("  %-15s %s\n", svc.Name+":", svc.Status)
+		}
+
+		fmt.Println("\nInfrastructure:")
+		for _, svc := range result.Infrastructure {
+			fmt.Printf("  %-15s %s\n", svc.Name+":", svc.Status)
+		}
+
+		fmt.Println("\nVault:")
+		if result.Vault != nil {
+			fmt.Printf("  Name: %s\n", result.Vault.Name)
+			fmt.Printf("  Public Key (ECDSA): %s...\n", result.Vault.PublicKeyECDSA[:32])
+		} else {
+			fmt.Println("  No vault configured")
+		}
 
-	return nil
+		fmt.Println("\nConfig file:", result.ConfigPath)
+	})
+}
+
+func upOrDown(ok bool) string {
+	if ok {
+		return "OK"
+	}
+	return "DOWN"
 }
 
 func checkHealth(url string) bool {
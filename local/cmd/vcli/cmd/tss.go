@@ -3,8 +3,6 @@ package cmd
 import (
 	"bytes"
 	"context"
-	"crypto/rand"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,11 +12,12 @@ import (
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	"github.com/vultisig/vultisig-go/relay"
 	vgtypes "github.com/vultisig/vultisig-go/types"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
 )
 
 const (
@@ -45,6 +44,7 @@ type LocalVault struct {
 	ResharePrefix  string      `json:"resharePrefix,omitempty"`
 	CreatedAt      string      `json:"createdAt"`
 	LibType        int         `json:"libType"` // 0 = GG20, 1 = DKLS
+	WatchOnly      bool        `json:"watchOnly,omitempty"` // public keys only, cannot sign
 }
 
 type BackupVault struct {
@@ -59,87 +59,11 @@ type TSSService struct {
 }
 
 func NewTSSService(localPartyID string) *TSSService {
-	logger := logrus.New()
-	logger.SetLevel(logrus.DebugLevel)
-	logger.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp: true,
-	})
-
 	return &TSSService{
 		relayClient:  relay.NewRelayClient(RelayServer),
 		localPartyID: localPartyID,
-		logger:       logger.WithField("component", "tss"),
-	}
-}
-
-func (t *TSSService) Keygen(ctx context.Context, vaultName string) (*LocalVault, error) {
-	sessionID := uuid.New().String()
-
-	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("generate encryption key: %w", err)
-	}
-	hexEncryptionKey := hex.EncodeToString(encryptionKey)
-
-	chainCode := make([]byte, 32)
-	_, err = rand.Read(chainCode)
-	if err != nil {
-		return nil, fmt.Errorf("generate chain code: %w", err)
-	}
-	hexChainCode := hex.EncodeToString(chainCode)
-
-	t.logger.WithFields(logrus.Fields{
-		"session_id":  sessionID,
-		"local_party": t.localPartyID,
-		"vault_name":  vaultName,
-	}).Info("Starting keygen session")
-
-	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
-	if err != nil {
-		return nil, fmt.Errorf("register session: %w", err)
-	}
-
-	t.logger.Info("Requesting Fast Vault Server to join keygen...")
-	err = t.requestFastVaultKeygen(ctx, vaultName, sessionID, hexEncryptionKey, hexChainCode)
-	if err != nil {
-		return nil, fmt.Errorf("request fast vault keygen: %w", err)
-	}
-
-	t.logger.Info("Waiting for Fast Vault Server to join...")
-	parties, err := t.waitForParties(ctx, sessionID, 2)
-	if err != nil {
-		return nil, fmt.Errorf("wait for parties: %w", err)
-	}
-
-	t.logger.WithField("parties", parties).Info("All parties joined, starting keygen")
-
-	err = t.relayClient.StartSession(sessionID, parties)
-	if err != nil {
-		return nil, fmt.Errorf("start session: %w", err)
-	}
-
-	t.logger.Info("Running DKLS keygen protocol...")
-	t.logger.Info("[NOTE: This requires go-wrappers CGO library]")
-	t.logger.Info("The keygen protocol would execute here with the DKLS library")
-
-	t.logger.Info("go-wrappers CGO libraries are automatically downloaded on first run")
-
-	err = t.relayClient.CompleteSession(sessionID, t.localPartyID)
-	if err != nil {
-		t.logger.WithError(err).Warn("Failed to complete session")
-	}
-
-	vault := &LocalVault{
-		Name:         vaultName,
-		HexChainCode: hexChainCode,
-		LocalPartyID: t.localPartyID,
-		Signers:      parties,
-		CreatedAt:    time.Now().UTC().Format(time.RFC3339),
-		LibType:      1,
+		logger:       componentLogger("tss"),
 	}
-
-	return vault, nil
 }
 
 func generateServerPartyID(sessionID string) string {
@@ -225,62 +149,6 @@ func (t *TSSService) waitForParties(ctx context.Context, sessionID string, expec
 	}
 }
 
-func (t *TSSService) Reshare(ctx context.Context, vault *LocalVault, pluginID, verifierURL, authHeader, vaultPassword string) (*LocalVault, error) {
-	sessionID := uuid.New().String()
-
-	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("generate encryption key: %w", err)
-	}
-	hexEncryptionKey := hex.EncodeToString(encryptionKey)
-
-	t.logger.WithFields(logrus.Fields{
-		"session_id":   sessionID,
-		"old_parties":  vault.Signers,
-		"plugin_id":    pluginID,
-		"verifier_url": verifierURL,
-	}).Info("Starting reshare session")
-
-	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
-	if err != nil {
-		return nil, fmt.Errorf("register session: %w", err)
-	}
-
-	t.logger.Info("Requesting Fast Vault Server to join reshare...")
-	err = t.requestFastVaultReshare(ctx, vault, sessionID, hexEncryptionKey, vaultPassword)
-	if err != nil {
-		t.logger.WithError(err).Warn("Failed to request Fast Vault Server - continuing anyway")
-	}
-
-	t.logger.Info("Requesting Verifier to join reshare...")
-	err = t.requestVerifierReshare(ctx, vault, sessionID, hexEncryptionKey, pluginID, verifierURL, authHeader)
-	if err != nil {
-		return nil, fmt.Errorf("request verifier reshare: %w", err)
-	}
-
-	expectedParties := len(vault.Signers) + 2
-	t.logger.WithField("expected", expectedParties).Info("Waiting for all parties to join...")
-
-	parties, err := t.waitForParties(ctx, sessionID, expectedParties)
-	if err != nil {
-		return nil, fmt.Errorf("wait for parties: %w", err)
-	}
-
-	t.logger.WithField("parties", parties).Info("All parties joined, starting reshare")
-
-	err = t.relayClient.StartSession(sessionID, parties)
-	if err != nil {
-		return nil, fmt.Errorf("start session: %w", err)
-	}
-
-	t.logger.Info("Running DKLS QC (reshare) protocol...")
-
-	vault.Signers = parties
-
-	return vault, nil
-}
-
 func (t *TSSService) requestFastVaultReshare(ctx context.Context, vault *LocalVault, sessionID, hexEncKey, password string) error {
 	serverPartyID := generateServerPartyID(sessionID)
 
@@ -343,132 +211,24 @@ func (t *TSSService) requestFastVaultReshare(ctx context.Context, vault *LocalVa
 }
 
 func (t *TSSService) requestVerifierReshare(ctx context.Context, vault *LocalVault, sessionID, hexEncKey, pluginID, verifierURL, authHeader string) error {
-	type VerifierReshareRequest struct {
-		Name             string   `json:"name"`
-		PublicKey        string   `json:"public_key"`
-		SessionID        string   `json:"session_id"`
-		HexEncryptionKey string   `json:"hex_encryption_key"`
-		HexChainCode     string   `json:"hex_chain_code"`
-		LocalPartyId     string   `json:"local_party_id"`
-		OldParties       []string `json:"old_parties"`
-		Email            string   `json:"email"`
-		PluginID         string   `json:"plugin_id"`
-	}
-
-	req := VerifierReshareRequest{
+	client := verifier.New(verifierURL, verifier.WithAuth(verifier.StaticAuth(authHeader)))
+	err := client.Reshare(ctx, verifier.ReshareRequest{
 		Name:             vault.Name,
 		PublicKey:        vault.PublicKeyECDSA,
 		SessionID:        sessionID,
 		HexEncryptionKey: hexEncKey,
 		HexChainCode:     vault.HexChainCode,
-		LocalPartyId:     "verifier-" + sessionID[:8],
+		LocalPartyID:     "verifier-" + sessionID[:8],
 		OldParties:       vault.Signers,
-		Email:            "",
 		PluginID:         pluginID,
-	}
-
-	reqJSON, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("marshal request: %w", err)
-	}
-
-	url := verifierURL + "/vault/reshare"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-	if authHeader != "" {
-		httpReq.Header.Set("Authorization", authHeader)
-	}
-
-	resp, err := http.DefaultClient.Do(httpReq)
+	})
 	if err != nil {
-		return fmt.Errorf("send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("verifier returned %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("verifier reshare: %w", err)
 	}
 
 	return nil
 }
 
-func (t *TSSService) ReshareWithPlugin(ctx context.Context, vault *LocalVault, pluginID, verifierURL, authHeader, vaultPassword string) (*LocalVault, error) {
-	sessionID := uuid.New().String()
-
-	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("generate encryption key: %w", err)
-	}
-	hexEncryptionKey := hex.EncodeToString(encryptionKey)
-
-	t.logger.WithFields(logrus.Fields{
-		"session_id":   sessionID,
-		"old_parties":  vault.Signers,
-		"plugin_id":    pluginID,
-		"verifier_url": verifierURL,
-	}).Info("Starting plugin reshare session")
-
-	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
-	if err != nil {
-		return nil, fmt.Errorf("register session: %w", err)
-	}
-
-	t.logger.Info("Requesting Fast Vault Server to join reshare...")
-	err = t.requestFastVaultReshare(ctx, vault, sessionID, hexEncryptionKey, vaultPassword)
-	if err != nil {
-		t.logger.WithError(err).Warn("Failed to request Fast Vault Server - continuing anyway")
-	}
-
-	t.logger.Info("Requesting Verifier to join reshare (with plugin)...")
-	err = t.requestVerifierReshare(ctx, vault, sessionID, hexEncryptionKey, pluginID, verifierURL, authHeader)
-	if err != nil {
-		return nil, fmt.Errorf("request verifier reshare: %w", err)
-	}
-
-	expectedParties := len(vault.Signers) + 2
-	t.logger.WithField("expected", expectedParties).Info("Waiting for all parties to join...")
-
-	parties, err := t.waitForParties(ctx, sessionID, expectedParties)
-	if err != nil {
-		return nil, fmt.Errorf("wait for parties: %w", err)
-	}
-
-	t.logger.WithField("parties", parties).Info("All parties joined, starting reshare")
-
-	err = t.relayClient.StartSession(sessionID, parties)
-	if err != nil {
-		return nil, fmt.Errorf("start session: %w", err)
-	}
-
-	t.logger.Info("Running DKLS QC (reshare) protocol...")
-	t.logger.Info("[NOTE: This requires go-wrappers CGO library for actual reshare]")
-
-	err = t.relayClient.CompleteSession(sessionID, t.localPartyID)
-	if err != nil {
-		t.logger.WithError(err).Warn("Failed to complete session")
-	}
-
-	newVault := &LocalVault{
-		Name:           vault.Name,
-		PublicKeyECDSA: vault.PublicKeyECDSA,
-		PublicKeyEdDSA: vault.PublicKeyEdDSA,
-		HexChainCode:   vault.HexChainCode,
-		LocalPartyID:   vault.LocalPartyID,
-		Signers:        parties,
-		KeyShares:      vault.KeyShares,
-		ResharePrefix:  sessionID[:8],
-		CreatedAt:      vault.CreatedAt,
-		LibType:        vault.LibType,
-	}
-
-	return newVault, nil
-}
-
 type KeysignResult struct {
 	R            string `json:"r"`
 	S            string `json:"s"`
@@ -476,240 +236,6 @@ type KeysignResult struct {
 	DerSignature string `json:"der_signature"`
 }
 
-func (t *TSSService) KeysignWithVerifier(ctx context.Context, vault *LocalVault, messages []string, derivePath, verifierURL, pluginID, authHeader string) ([]KeysignResult, error) {
-	sessionID := uuid.New().String()
-
-	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("generate encryption key: %w", err)
-	}
-	hexEncryptionKey := hex.EncodeToString(encryptionKey)
-
-	t.logger.WithFields(logrus.Fields{
-		"session_id":   sessionID,
-		"public_key":   vault.PublicKeyECDSA[:16] + "...",
-		"messages":     len(messages),
-		"derive_path":  derivePath,
-		"plugin_id":    pluginID,
-		"verifier_url": verifierURL,
-	}).Info("Starting keysign with verifier")
-
-	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
-	if err != nil {
-		return nil, fmt.Errorf("register session: %w", err)
-	}
-
-	t.logger.Info("Requesting Verifier to join keysign for policy...")
-	err = t.requestVerifierKeysign(ctx, vault, sessionID, hexEncryptionKey, messages, derivePath, pluginID, verifierURL, authHeader)
-	if err != nil {
-		return nil, fmt.Errorf("request verifier keysign: %w", err)
-	}
-
-	t.logger.Info("Waiting for Verifier to join...")
-	parties, err := t.waitForParties(ctx, sessionID, 2)
-	if err != nil {
-		return nil, fmt.Errorf("wait for parties: %w", err)
-	}
-
-	t.logger.WithField("parties", parties).Info("All parties joined, starting keysign")
-
-	err = t.relayClient.StartSession(sessionID, parties)
-	if err != nil {
-		return nil, fmt.Errorf("start session: %w", err)
-	}
-
-	t.logger.Info("Running DKLS keysign protocol with Verifier...")
-	t.logger.Info("[NOTE: This requires go-wrappers CGO library for actual signing]")
-
-	err = t.relayClient.CompleteSession(sessionID, t.localPartyID)
-	if err != nil {
-		t.logger.WithError(err).Warn("Failed to complete session")
-	}
-
-	results := make([]KeysignResult, len(messages))
-	for i := range messages {
-		results[i] = KeysignResult{
-			R:            "placeholder_r",
-			S:            "placeholder_s",
-			RecoveryID:   "1b",
-			DerSignature: "placeholder_der",
-		}
-	}
-
-	return results, nil
-}
-
-func (t *TSSService) requestVerifierKeysign(ctx context.Context, vault *LocalVault, sessionID, hexEncKey string, messages []string, derivePath, pluginID, verifierURL, authHeader string) error {
-	type VerifierKeysignRequest struct {
-		PublicKey        string   `json:"public_key"`
-		Messages         []string `json:"messages"`
-		Session          string   `json:"session"`
-		HexEncryptionKey string   `json:"hex_encryption_key"`
-		DerivePath       string   `json:"derive_path"`
-		PluginID         string   `json:"plugin_id"`
-		IsECDSA          bool     `json:"is_ecdsa"`
-	}
-
-	req := VerifierKeysignRequest{
-		PublicKey:        vault.PublicKeyECDSA,
-		Messages:         messages,
-		Session:          sessionID,
-		HexEncryptionKey: hexEncKey,
-		DerivePath:       derivePath,
-		PluginID:         pluginID,
-		IsECDSA:          true,
-	}
-
-	reqJSON, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("marshal request: %w", err)
-	}
-
-	url := verifierURL + "/vault/keysign"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-	if authHeader != "" {
-		httpReq.Header.Set("Authorization", authHeader)
-	}
-
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("verifier keysign returned %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
-}
-
-func (t *TSSService) Keysign(ctx context.Context, vault *LocalVault, messages []string, derivePath string, isEdDSA bool, vaultPassword string) ([]KeysignResult, error) {
-	sessionID := uuid.New().String()
-
-	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("generate encryption key: %w", err)
-	}
-	hexEncryptionKey := hex.EncodeToString(encryptionKey)
-
-	publicKey := vault.PublicKeyECDSA
-	if isEdDSA {
-		publicKey = vault.PublicKeyEdDSA
-	}
-
-	t.logger.WithFields(logrus.Fields{
-		"session_id":  sessionID,
-		"public_key":  publicKey[:16] + "...",
-		"messages":    len(messages),
-		"derive_path": derivePath,
-		"is_eddsa":    isEdDSA,
-	}).Info("Starting keysign session")
-
-	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
-	if err != nil {
-		return nil, fmt.Errorf("register session: %w", err)
-	}
-
-	t.logger.Info("Requesting Fast Vault Server to join keysign...")
-	err = t.requestFastVaultKeysign(ctx, vault, sessionID, hexEncryptionKey, messages, derivePath, isEdDSA, vaultPassword)
-	if err != nil {
-		return nil, fmt.Errorf("request fast vault keysign: %w", err)
-	}
-
-	t.logger.Info("Waiting for Fast Vault Server to join...")
-	parties, err := t.waitForParties(ctx, sessionID, 2)
-	if err != nil {
-		return nil, fmt.Errorf("wait for parties: %w", err)
-	}
-
-	t.logger.WithField("parties", parties).Info("All parties joined, starting keysign")
-
-	err = t.relayClient.StartSession(sessionID, parties)
-	if err != nil {
-		return nil, fmt.Errorf("start session: %w", err)
-	}
-
-	t.logger.Info("Running DKLS keysign protocol...")
-	t.logger.Info("[NOTE: This requires go-wrappers CGO library for actual signing]")
-
-	err = t.relayClient.CompleteSession(sessionID, t.localPartyID)
-	if err != nil {
-		t.logger.WithError(err).Warn("Failed to complete session")
-	}
-
-	results := make([]KeysignResult, len(messages))
-	for i := range messages {
-		results[i] = KeysignResult{
-			R:            "placeholder",
-			S:            "placeholder",
-			RecoveryID:   "00",
-			DerSignature: "placeholder",
-		}
-	}
-
-	return results, nil
-}
-
-func (t *TSSService) requestFastVaultKeysign(ctx context.Context, vault *LocalVault, sessionID, hexEncKey string, messages []string, derivePath string, isEdDSA bool, vaultPassword string) error {
-	type FastVaultSignRequest struct {
-		PublicKey        string   `json:"public_key"`
-		Messages         []string `json:"messages"`
-		Session          string   `json:"session"`
-		HexEncryptionKey string   `json:"hex_encryption_key"`
-		DerivePath       string   `json:"derive_path"`
-		IsECDSA          bool     `json:"is_ecdsa"`
-		VaultPassword    string   `json:"vault_password"`
-	}
-
-	publicKey := vault.PublicKeyECDSA
-	if isEdDSA {
-		publicKey = vault.PublicKeyEdDSA
-	}
-
-	req := FastVaultSignRequest{
-		PublicKey:        publicKey,
-		Messages:         messages,
-		Session:          sessionID,
-		HexEncryptionKey: hexEncKey,
-		DerivePath:       derivePath,
-		IsECDSA:          !isEdDSA,
-		VaultPassword:    vaultPassword,
-	}
-
-	reqJSON, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("marshal request: %w", err)
-	}
-
-	url := FastVaultServer + "/sign"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("fast vault server returned %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
-}
-
 func VaultStoragePath() string {
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, ".vultisig", "vaults")
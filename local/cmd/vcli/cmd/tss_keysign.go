@@ -30,10 +30,15 @@ func (t *TSSService) KeysignWithDKLS(ctx context.Context, v *LocalVault, message
 }
 
 func (t *TSSService) KeysignWithFastVault(ctx context.Context, v *LocalVault, messages []string, derivePath, vaultPassword string) ([]KeysignResult, error) {
+	err := requireKeyshares(v)
+	if err != nil {
+		return nil, err
+	}
+
 	sessionID := uuid.New().String()
 
 	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
+	_, err = rand.Read(encryptionKey)
 	if err != nil {
 		return nil, fmt.Errorf("generate encryption key: %w", err)
 	}
@@ -22,10 +22,15 @@ import (
 )
 
 func (t *TSSService) ReshareWithDKLS(ctx context.Context, v *LocalVault, pluginID, verifierURL, authHeader, vaultPassword string) (*LocalVault, error) {
+	err := requireKeyshares(v)
+	if err != nil {
+		return nil, err
+	}
+
 	sessionID := uuid.New().String()
 
 	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
+	_, err = rand.Read(encryptionKey)
 	if err != nil {
 		return nil, fmt.Errorf("generate encryption key: %w", err)
 	}
@@ -0,0 +1,539 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/vultisig/recipes/chain/evm/ethereum"
+	"github.com/vultisig/vultisig-go/common"
+)
+
+// Decoded transaction actions.
+const (
+	txActionNativeTransfer = "native_transfer"
+	txActionERC20Transfer  = "erc20_transfer"
+	txActionERC20Approve   = "erc20_approve"
+	txActionRouterDeposit  = "router_deposit"
+	txActionContractCall   = "contract_call"
+)
+
+// Results of checking a decoded amount against the policy.
+const (
+	amountCheckOK       = "ok"
+	amountCheckMismatch = "mismatch"
+)
+
+// DecodedTx is a readable view of a policy transaction, built from the
+// payload stored in tx_indexer (or fetched from the chain) and, once it is
+// mined, the receipt. Amounts are in the token's smallest unit; an empty
+// Token means the chain's native asset.
+type DecodedTx struct {
+	Chain          string    `json:"chain"`
+	Action         string    `json:"action,omitempty"`
+	Method         string    `json:"method,omitempty"`
+	Contract       string    `json:"contract,omitempty"`
+	Token          string    `json:"token,omitempty"`
+	AmountIn       string    `json:"amount_in,omitempty"`
+	AmountOut      string    `json:"amount_out,omitempty"`
+	TokenOut       string    `json:"token_out,omitempty"`
+	Recipient      string    `json:"recipient,omitempty"`
+	Memo           *SwapMemo `json:"memo,omitempty"`
+	GasLimit       uint64    `json:"gas_limit,omitempty"`
+	GasUsed        uint64    `json:"gas_used,omitempty"`
+	Fee            string    `json:"fee,omitempty"`
+	ExpectedAmount string    `json:"expected_amount,omitempty"`
+	AmountCheck    string    `json:"amount_check,omitempty"`
+	Sources        []string  `json:"sources,omitempty"`
+	Errors         []string  `json:"errors,omitempty"`
+}
+
+// SwapMemo is a parsed THORChain/MAYAChain memo. Limit is in the
+// protocol's 1e8 units.
+type SwapMemo struct {
+	Raw          string `json:"raw"`
+	Action       string `json:"action"`
+	Asset        string `json:"asset,omitempty"`
+	Destination  string `json:"destination,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+	Interval     string `json:"interval,omitempty"`
+	Quantity     string `json:"quantity,omitempty"`
+	Affiliate    string `json:"affiliate,omitempty"`
+	AffiliateFee string `json:"affiliate_fee,omitempty"`
+}
+
+// txDecodeABI covers the calls DCA and sends policies make: ERC20
+// transfer/approve and the THORChain/MAYAChain router deposits.
+const txDecodeABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}]},
+	{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}]},
+	{"type":"function","name":"deposit","inputs":[{"name":"vault","type":"address"},{"name":"asset","type":"address"},{"name":"amount","type":"uint256"},{"name":"memo","type":"string"}]},
+	{"type":"function","name":"depositWithExpiry","inputs":[{"name":"vault","type":"address"},{"name":"asset","type":"address"},{"name":"amount","type":"uint256"},{"name":"memo","type":"string"},{"name":"expiration","type":"uint256"}]}
+]`
+
+var (
+	txDecoder      = mustParseABI(txDecodeABI)
+	erc20Transfer  = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	evmZeroAddress = ethcommon.Address{}
+)
+
+func mustParseABI(def string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// expectedAmounts is what a policy says one execution should move:
+// fromAmount for swaps, the per-recipient amount for sends.
+type expectedAmounts struct {
+	fromAmount string
+	recipients map[string]string // lower-cased address -> amount
+}
+
+// getExpectedAmounts reads the amounts from the policy's recipe; it returns
+// nil when the policy can't be fetched.
+func getExpectedAmounts(policyID string) *expectedAmounts {
+	vault, err := ActiveVault()
+	if err != nil {
+		return nil
+	}
+	client, err := newStoredAuthClient(vault)
+	if err != nil {
+		return nil
+	}
+	policy, err := client.GetPolicy(context.Background(), policyID)
+	if err != nil {
+		return nil
+	}
+	recipe, err := decodeRecipe(policy.Recipe)
+	if err != nil {
+		return nil
+	}
+
+	config := recipe.GetConfiguration().AsMap()
+	exp := &expectedAmounts{recipients: map[string]string{}}
+	if amt, ok := config["fromAmount"].(string); ok {
+		exp.fromAmount = amt
+	}
+	if list, ok := config["recipients"].([]any); ok {
+		for _, item := range list {
+			r, _ := item.(map[string]any)
+			addr, _ := r["toAddress"].(string)
+			amt, _ := r["amount"].(string)
+			if addr != "" && amt != "" {
+				exp.recipients[strings.ToLower(addr)] = amt
+			}
+		}
+	}
+	return exp
+}
+
+// decodeTransactions fills in Decoded for each transaction.
+func decodeTransactions(policyID string, txs []TxRecord) {
+	exp := getExpectedAmounts(policyID)
+	for i := range txs {
+		txs[i].Decoded = decodeTx(txs[i], exp)
+	}
+}
+
+func decodeTx(rec TxRecord, exp *expectedAmounts) *DecodedTx {
+	chain := common.Chain(rec.chainID)
+	d := &DecodedTx{Chain: chain.String()}
+	if !chain.IsEvm() {
+		d.Errors = append(d.Errors, fmt.Sprintf("decoding is not supported for %s", d.Chain))
+		return d
+	}
+
+	tx, err := decodeProposedEVMTx(rec.proposedTx)
+	if err == nil {
+		d.Sources = append(d.Sources, "tx_indexer")
+	} else if hasTxHash(rec.TxHash) {
+		tx, err = fetchEVMTx(d.Chain, rec.TxHash)
+		if err == nil {
+			d.Sources = append(d.Sources, "rpc")
+		}
+	}
+	if err != nil {
+		d.Errors = append(d.Errors, fmt.Sprintf("read transaction: %v", err))
+		return d
+	}
+	decodeEVMCall(d, tx)
+
+	if hasTxHash(rec.TxHash) {
+		receipt, err := fetchEVMReceipt(d.Chain, rec.TxHash)
+		if err != nil {
+			d.Errors = append(d.Errors, fmt.Sprintf("read receipt: %v", err))
+		} else if receipt != nil {
+			applyEVMReceipt(d, receipt)
+			d.Sources = append(d.Sources, "receipt")
+		}
+	}
+
+	checkDecodedAmount(d, exp)
+	return d
+}
+
+func hasTxHash(hash string) bool {
+	return hash != "" && hash != "<nil>" && hash != "NULL"
+}
+
+// decodeProposedEVMTx parses the unsigned payload stored in
+// tx_indexer.proposed_tx_hex: base64 despite the column name, hex as a
+// fallback.
+func decodeProposedEVMTx(payload string) (*types.Transaction, error) {
+	if payload == "" {
+		return nil, fmt.Errorf("no stored payload")
+	}
+
+	var err error
+	for _, decode := range []func(string) ([]byte, error){
+		base64.StdEncoding.DecodeString,
+		func(s string) ([]byte, error) { return hex.DecodeString(strings.TrimPrefix(s, "0x")) },
+	} {
+		raw, decErr := decode(payload)
+		if decErr != nil {
+			continue
+		}
+		var data types.TxData
+		data, err = ethereum.DecodeUnsignedPayload(raw)
+		if err == nil {
+			return types.NewTx(data), nil
+		}
+	}
+	if err == nil {
+		return nil, fmt.Errorf("stored payload is neither base64 nor hex")
+	}
+	return nil, fmt.Errorf("decode unsigned payload: %w", err)
+}
+
+// decodeEVMCall fills in the action, amounts and recipient from the
+// transaction's value and calldata.
+func decodeEVMCall(d *DecodedTx, tx *types.Transaction) {
+	d.GasLimit = tx.Gas()
+	if tx.To() == nil {
+		d.Action = txActionContractCall
+		d.Method = "create"
+		return
+	}
+	to := tx.To().Hex()
+	value := tx.Value()
+
+	data := tx.Data()
+	if len(data) == 0 {
+		d.Action = txActionNativeTransfer
+		d.Recipient = to
+		d.AmountIn = value.String()
+		return
+	}
+
+	d.Contract = to
+	if value.Sign() > 0 {
+		d.AmountIn = value.String()
+	}
+	if len(data) < 4 {
+		d.Action = txActionContractCall
+		return
+	}
+	method, err := txDecoder.MethodById(data[:4])
+	if err != nil {
+		d.Action = txActionContractCall
+		d.Method = "0x" + hex.EncodeToString(data[:4])
+		return
+	}
+	args := map[string]any{}
+	if err := method.Inputs.UnpackIntoMap(args, data[4:]); err != nil {
+		d.Action = txActionContractCall
+		d.Method = method.Name
+		d.Errors = append(d.Errors, fmt.Sprintf("unpack %s: %v", method.Name, err))
+		return
+	}
+
+	d.Method = method.Name
+	switch method.Name {
+	case "transfer":
+		d.Action = txActionERC20Transfer
+		d.Token = to
+		d.Recipient = args["to"].(ethcommon.Address).Hex()
+		d.AmountIn = args["amount"].(*big.Int).String()
+	case "approve":
+		d.Action = txActionERC20Approve
+		d.Token = to
+		d.Recipient = args["spender"].(ethcommon.Address).Hex()
+		d.AmountIn = args["amount"].(*big.Int).String()
+	case "deposit", "depositWithExpiry":
+		d.Action = txActionRouterDeposit
+		if asset := args["asset"].(ethcommon.Address); asset != evmZeroAddress {
+			d.Token = asset.Hex()
+		}
+		d.AmountIn = args["amount"].(*big.Int).String()
+		d.Memo = parseSwapMemo(args["memo"].(string))
+		if d.Memo.Destination != "" {
+			d.Recipient = d.Memo.Destination
+		}
+	}
+}
+
+// parseSwapMemo splits a THORChain/MAYAChain memo,
+// SWAP:ASSET:DEST:LIM[/INTERVAL/QUANTITY]:AFFILIATE:FEE. Other actions keep
+// only Raw and Action.
+func parseSwapMemo(memo string) *SwapMemo {
+	m := &SwapMemo{Raw: memo}
+	parts := strings.Split(memo, ":")
+	m.Action = parts[0]
+	switch strings.ToLower(m.Action) {
+	case "swap", "s", "=":
+		m.Action = "swap"
+	default:
+		return m
+	}
+
+	field := func(i int) string {
+		if i < len(parts) {
+			return parts[i]
+		}
+		return ""
+	}
+	m.Asset = field(1)
+	m.Destination = field(2)
+	limit := strings.Split(field(3), "/")
+	m.Limit = limit[0]
+	if len(limit) > 1 {
+		m.Interval = limit[1]
+	}
+	if len(limit) > 2 {
+		m.Quantity = limit[2]
+	}
+	m.Affiliate = field(4)
+	m.AffiliateFee = field(5)
+	return m
+}
+
+type evmReceipt struct {
+	From              string `json:"from"`
+	Status            string `json:"status"`
+	GasUsed           string `json:"gasUsed"`
+	EffectiveGasPrice string `json:"effectiveGasPrice"`
+	Logs              []struct {
+		Address string   `json:"address"`
+		Topics  []string `json:"topics"`
+		Data    string   `json:"data"`
+	} `json:"logs"`
+}
+
+// applyEVMReceipt adds gas used, the fee and the ERC20 amounts that moved
+// in or out of the sender.
+func applyEVMReceipt(d *DecodedTx, r *evmReceipt) {
+	gasUsed := hexBig(r.GasUsed)
+	d.GasUsed = gasUsed.Uint64()
+	if price := hexBig(r.EffectiveGasPrice); price.Sign() > 0 {
+		d.Fee = new(big.Int).Mul(gasUsed, price).String()
+	}
+	if r.Status == "0x0" {
+		d.Errors = append(d.Errors, "transaction reverted")
+		return
+	}
+
+	sender := ethcommon.HexToAddress(r.From)
+	for _, l := range r.Logs {
+		if len(l.Topics) != 3 || ethcommon.HexToHash(l.Topics[0]) != erc20Transfer {
+			continue
+		}
+		from := ethcommon.HexToAddress(l.Topics[1])
+		to := ethcommon.HexToAddress(l.Topics[2])
+		amount := hexBig(l.Data).String()
+		token := ethcommon.HexToAddress(l.Address).Hex()
+		switch {
+		case from == sender && d.AmountIn == "":
+			d.AmountIn = amount
+			d.Token = token
+		case to == sender && from != sender:
+			d.AmountOut = amount
+			d.TokenOut = token
+		}
+	}
+}
+
+// checkDecodedAmount compares the amount sent with what the policy
+// specifies: a recipient's amount for sends, fromAmount otherwise.
+// Approvals are not checked.
+func checkDecodedAmount(d *DecodedTx, exp *expectedAmounts) {
+	if exp == nil || d.AmountIn == "" || d.Action == txActionERC20Approve {
+		return
+	}
+
+	expected := exp.fromAmount
+	if amt, ok := exp.recipients[strings.ToLower(d.Recipient)]; ok {
+		expected = amt
+	}
+	if expected == "" {
+		return
+	}
+
+	d.ExpectedAmount = expected
+	d.AmountCheck = amountCheckOK
+	if d.AmountIn != expected {
+		d.AmountCheck = amountCheckMismatch
+	}
+}
+
+func fetchEVMTx(chain, hash string) (*types.Transaction, error) {
+	var tx *types.Transaction
+	err := callEVMRPC(chain, "eth_getTransactionByHash", []any{hash}, &tx)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %s not found", hash)
+	}
+	return tx, nil
+}
+
+// fetchEVMReceipt returns nil while the transaction is not mined.
+func fetchEVMReceipt(chain, hash string) (*evmReceipt, error) {
+	var receipt *evmReceipt
+	err := callEVMRPC(chain, "eth_getTransactionReceipt", []any{hash}, &receipt)
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// callEVMRPC makes a JSON-RPC call to the chain's RPC (see getChainRPCURL)
+// and decodes the result into out.
+func callEVMRPC(chain, method string, params []any, out any) error {
+	rpcURL, ok := getChainRPCURL(chain)
+	if !ok {
+		return fmt.Errorf("no RPC URL for chain: %s", chain)
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", rpcURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	var result struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("%s: RPC error: %s", method, result.Error.Message)
+	}
+	if len(result.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(result.Result, out)
+}
+
+func hexBig(s string) *big.Int {
+	n, ok := new(big.Int).SetString(strings.TrimPrefix(s, "0x"), 16)
+	if !ok {
+		return new(big.Int)
+	}
+	return n
+}
+
+func printDecodedTx(d *DecodedTx) {
+	fmt.Printf("│  Decoded (%s):\n", strings.Join(d.Sources, ", "))
+	if d.Action != "" {
+		action := d.Action
+		if d.Method != "" && d.Action != txActionERC20Transfer && d.Action != txActionERC20Approve {
+			action += " " + d.Method
+		}
+		fmt.Printf("│    Action:     %s\n", action)
+	}
+	if d.Contract != "" {
+		fmt.Printf("│    Contract:   %s\n", d.Contract)
+	}
+	if d.AmountIn != "" {
+		fmt.Printf("│    Amount in:  %s %s\n", d.AmountIn, tokenLabel(d.Token))
+	}
+	if d.AmountOut != "" {
+		fmt.Printf("│    Amount out: %s %s\n", d.AmountOut, tokenLabel(d.TokenOut))
+	}
+	if d.Recipient != "" {
+		fmt.Printf("│    Recipient:  %s\n", d.Recipient)
+	}
+	if m := d.Memo; m != nil {
+		fmt.Printf("│    Memo:       %s\n", m.Raw)
+		if m.Action == "swap" {
+			limit := m.Limit
+			if limit == "" {
+				limit = "0"
+			}
+			fmt.Printf("│      Swap to %s, min out %s (1e8 units)", m.Asset, limit)
+			if m.Interval != "" && m.Interval != "0" {
+				fmt.Printf(", streaming every %s blocks", m.Interval)
+				if m.Quantity != "" && m.Quantity != "0" {
+					fmt.Printf(" in %s sub-swaps", m.Quantity)
+				}
+			}
+			fmt.Println()
+		}
+	}
+	if d.GasUsed > 0 {
+		fmt.Printf("│    Gas used:   %d of %d (fee %s wei)\n", d.GasUsed, d.GasLimit, d.Fee)
+	} else if d.GasLimit > 0 {
+		fmt.Printf("│    Gas limit:  %d\n", d.GasLimit)
+	}
+	switch d.AmountCheck {
+	case amountCheckOK:
+		fmt.Printf("│    ✓ Amount matches policy (%s)\n", d.ExpectedAmount)
+	case amountCheckMismatch:
+		fmt.Printf("│    ✗ Amount %s does not match policy (%s)\n", d.AmountIn, d.ExpectedAmount)
+	}
+	for _, e := range d.Errors {
+		fmt.Printf("│    ⚠ %s\n", e)
+	}
+}
+
+func tokenLabel(token string) string {
+	if token == "" {
+		return "(native)"
+	}
+	return "(" + token + ")"
+}
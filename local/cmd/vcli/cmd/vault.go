@@ -1,9 +1,7 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
-	"crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -16,7 +14,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/vultisig/commondata/go/vultisig/vault/v1"
@@ -125,6 +122,7 @@ func newVaultKeysignCmd() *cobra.Command {
 	var derivePath string
 	var isEdDSA bool
 	var vaultPassword string
+	var acct Account
 
 	cmd := &cobra.Command{
 		Use:   "keysign",
@@ -134,8 +132,10 @@ func newVaultKeysignCmd() *cobra.Command {
 This performs a TSS keysign operation with your vault share and the Fast Vault Server.
 The message should be hex-encoded (the hash to sign).
 
-For ECDSA signing (default), provide a derive path like "m/44'/60'/0'/0/0" for Ethereum.
-For EdDSA signing, use --eddsa flag (no derive path needed).
+For ECDSA signing (default), provide a derive path like "m/44'/60'/0'/0/0" for Ethereum,
+or use --account/--index to sign with a non-default EVM account.
+EdDSA signing (--eddsa) is not supported yet: the Fast Vault DKLS keysign
+path only signs with the ECDSA key.
 
 Environment variables:
   VAULT_PASSWORD  - Fast Vault password (or use --password flag)
@@ -144,8 +144,8 @@ Example:
   # Sign an Ethereum transaction hash (ECDSA)
   vcli vault keysign --message "abcd1234..." --derive "m/44'/60'/0'/0/0" --password "vault-password"
 
-  # Sign a Solana message (EdDSA)
-  vcli vault keysign --message "abcd1234..." --eddsa --password "vault-password"
+  # Sign with EVM account 1 (m/44'/60'/1'/0/0)
+  vcli vault keysign --message "abcd1234..." --account 1 --password "vault-password"
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			actualPassword := vaultPassword
@@ -155,42 +155,54 @@ Example:
 			if actualPassword == "" {
 				return fmt.Errorf("password required: use --password or set VAULT_PASSWORD")
 			}
-			return runVaultKeysign(message, derivePath, isEdDSA, actualPassword)
+			if isEdDSA {
+				return fmt.Errorf("EdDSA keysign is not supported yet (Fast Vault DKLS keysign is ECDSA-only)")
+			}
+			if cmd.Flags().Changed("account") || cmd.Flags().Changed("index") {
+				if cmd.Flags().Changed("derive") {
+					return fmt.Errorf("--derive cannot be combined with --account/--index")
+				}
+				derivePath = acct.EVMDerivePath()
+			}
+			return runVaultKeysign(message, derivePath, actualPassword)
 		},
 	}
 
 	cmd.Flags().StringVarP(&message, "message", "m", "", "Hex-encoded message hash to sign (required)")
 	cmd.Flags().StringVarP(&derivePath, "derive", "d", "m/44'/60'/0'/0/0", "BIP44 derivation path (for ECDSA)")
-	cmd.Flags().BoolVar(&isEdDSA, "eddsa", false, "Use EdDSA signing (for Solana, etc.)")
+	cmd.Flags().BoolVar(&isEdDSA, "eddsa", false, "Use EdDSA signing (not supported yet)")
 	cmd.Flags().StringVar(&vaultPassword, "password", "", "Fast Vault password (or set VAULT_PASSWORD)")
+	addAccountFlags(cmd, &acct)
 	cmd.MarkFlagRequired("message")
 
 	return cmd
 }
 
 func newVaultInfoCmd() *cobra.Command {
-	return &cobra.Command{
+	return withStructuredOutput(&cobra.Command{
 		Use:   "info",
 		Short: "Show current vault information",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runVaultInfo()
 		},
-	}
+	})
 }
 
 func newVaultListCmd() *cobra.Command {
-	return &cobra.Command{
+	return withStructuredOutput(&cobra.Command{
 		Use:   "list",
 		Short: "List all local vaults",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runVaultList()
 		},
-	}
+	})
 }
 
 func newVaultImportCmd() *cobra.Command {
 	var file string
 	var password string
+	var watchOnly, replace bool
+	var name, ecdsaKey, eddsaKey, chainCode, policyID string
 
 	cmd := &cobra.Command{
 		Use:   "import",
@@ -201,7 +213,17 @@ The file should be a vault backup exported from the Vultisig mobile app or exten
 If the vault is encrypted, you will be prompted for the password interactively,
 or you can provide it with --password (be careful with special characters in shells).
 
-Importing always overwrites any existing vault.
+Importing a vault file always overwrites any existing vault.
+
+WATCH-ONLY IMPORT:
+  With --watch-only, a read-only vault is created from public keys alone
+  (no keyshare, no password). Address, balance and policy generation commands
+  work normally; signing commands refuse the vault.
+  Existing vaults are kept; the watch-only vault becomes the active one
+  (switch back with 'vcli vault use'). A vault with the same public key that
+  holds a keyshare is only replaced with --replace.
+  The ECDSA key can also be taken from an existing policy in the local
+  verifier database with --policy <policy-id>.
 
 DEFAULT LOCATION:
   If no --file is specified, looks for a .vult file in local/keyshares/
@@ -214,8 +236,19 @@ Environment variables (override flags):
 Example:
   vcli vault import --password "your-password"                    # Uses file from local/keyshares/
   vcli vault import --file ~/Downloads/MyVault.vult --password "your-password"
+  vcli vault import --watch-only --ecdsa 02ab... --eddsa 7f3c... --chain-code 9e1d...
+  vcli vault import --watch-only --policy <policy-id> --chain-code 9e1d...
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if watchOnly {
+				return runVaultImportWatchOnly(name, ecdsaKey, eddsaKey, chainCode, policyID, replace)
+			}
+			for _, flag := range []string{"ecdsa", "eddsa", "chain-code", "policy", "name", "replace"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--%s requires --watch-only", flag)
+				}
+			}
+
 			actualFile := file
 			if envPath := os.Getenv("VAULT_PATH"); envPath != "" {
 				actualFile = envPath
@@ -248,6 +281,13 @@ Example:
 
 	cmd.Flags().StringVar(&file, "file", "", "Vault file (default: looks in local/keyshares/)")
 	cmd.Flags().StringVar(&password, "password", "", "Decryption password (or set VAULT_PASSWORD env var)")
+	cmd.Flags().BoolVar(&watchOnly, "watch-only", false, "Import a read-only vault from public keys (no keyshare)")
+	cmd.Flags().StringVar(&name, "name", "", "Vault name for --watch-only (default: watch-<pubkey prefix>)")
+	cmd.Flags().StringVar(&ecdsaKey, "ecdsa", "", "Hex ECDSA public key for --watch-only")
+	cmd.Flags().StringVar(&eddsaKey, "eddsa", "", "Hex EdDSA public key for --watch-only (optional)")
+	cmd.Flags().StringVar(&chainCode, "chain-code", "", "Hex chain code for --watch-only")
+	cmd.Flags().StringVar(&policyID, "policy", "", "Take the ECDSA key from a plugin_policies row (--watch-only)")
+	cmd.Flags().BoolVar(&replace, "replace", false, "Replace a full vault with the same public key (--watch-only)")
 
 	return cmd
 }
@@ -402,6 +442,10 @@ func runVaultReshare(pluginID string, verifierURL string, password string) error
 	if err != nil {
 		return fmt.Errorf("load vault: %w", err)
 	}
+	err = requireKeyshares(vault)
+	if err != nil {
+		return err
+	}
 
 	fmt.Println("=== Vault Reshare ===")
 	fmt.Printf("Vault: %s\n", vault.Name)
@@ -419,17 +463,17 @@ func runVaultReshare(pluginID string, verifierURL string, password string) error
 	fmt.Println()
 	fmt.Println("Starting TSS reshare...")
 
-	authHeader, err := GetAuthHeader()
+	authHeader, err := GetAuthHeader(vault)
 	if err != nil {
 		fmt.Println("Warning: Not authenticated. Reshare may require authentication.")
 		authHeader = ""
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 	defer cancel()
 
 	tss := NewTSSService(vault.LocalPartyID)
-	newVault, err := tss.Reshare(ctx, vault, pluginID, verifierURL, authHeader, password)
+	newVault, err := tss.ReshareWithDKLS(ctx, vault, pluginID, verifierURL, authHeader, password)
 	if err != nil {
 		return fmt.Errorf("reshare failed: %w", err)
 	}
@@ -446,7 +490,7 @@ func runVaultReshare(pluginID string, verifierURL string, password string) error
 	return nil
 }
 
-func runVaultKeysign(message, derivePath string, isEdDSA bool, vaultPassword string) error {
+func runVaultKeysign(message, derivePath, vaultPassword string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
@@ -460,23 +504,19 @@ func runVaultKeysign(message, derivePath string, isEdDSA bool, vaultPassword str
 	if err != nil {
 		return fmt.Errorf("load vault: %w", err)
 	}
-
-	publicKey := vault.PublicKeyECDSA
-	if isEdDSA {
-		publicKey = vault.PublicKeyEdDSA
-		derivePath = ""
+	err = requireKeyshares(vault)
+	if err != nil {
+		return err
 	}
 
 	fmt.Println("=== Vault Keysign ===")
 	fmt.Printf("Vault: %s\n", vault.Name)
-	if len(publicKey) >= 32 {
-		fmt.Printf("Public Key: %s...\n", publicKey[:32])
+	if len(vault.PublicKeyECDSA) >= 32 {
+		fmt.Printf("Public Key: %s...\n", vault.PublicKeyECDSA[:32])
 	}
 	fmt.Printf("Message: %s\n", message)
-	if !isEdDSA {
-		fmt.Printf("Derive Path: %s\n", derivePath)
-	}
-	fmt.Printf("Signature Type: %s\n", map[bool]string{true: "EdDSA", false: "ECDSA"}[isEdDSA])
+	fmt.Printf("Derive Path: %s\n", derivePath)
+	fmt.Println("Signature Type: ECDSA")
 	fmt.Println()
 
 	fmt.Println("Starting TSS keysign with Fast Vault Server...")
@@ -485,7 +525,7 @@ func runVaultKeysign(message, derivePath string, isEdDSA bool, vaultPassword str
 	defer cancel()
 
 	tss := NewTSSService(vault.LocalPartyID)
-	results, err := tss.Keysign(ctx, vault, []string{message}, derivePath, isEdDSA, vaultPassword)
+	results, err := tss.KeysignWithFastVault(ctx, vault, []string{message}, derivePath, vaultPassword)
 	if err != nil {
 		return fmt.Errorf("keysign failed: %w", err)
 	}
@@ -503,53 +543,119 @@ func runVaultKeysign(message, derivePath string, isEdDSA bool, vaultPassword str
 	return nil
 }
 
+// VaultInfo is the result of 'vault info'. Found is false when the config
+// names a vault whose file is not stored locally.
+type VaultInfo struct {
+	Configured     bool           `json:"configured"`
+	Found          bool           `json:"found"`
+	Name           string         `json:"name,omitempty"`
+	WatchOnly      bool           `json:"watch_only"`
+	PublicKeyECDSA string         `json:"public_key_ecdsa,omitempty"`
+	PublicKeyEdDSA string         `json:"public_key_eddsa,omitempty"`
+	LocalPartyID   string         `json:"local_party_id,omitempty"`
+	Signers        []string       `json:"signers,omitempty"`
+	CreatedAt      string         `json:"created_at,omitempty"`
+	KeyShares      []KeyShareInfo `json:"keyshares,omitempty"`
+	LibType        int            `json:"lib_type"`
+	ResharePrefix  string         `json:"reshare_prefix,omitempty"`
+	Storage        string         `json:"storage"`
+}
+
+type KeyShareInfo struct {
+	PubKey string `json:"public_key"`
+	Bytes  int    `json:"bytes"`
+}
+
 func runVaultInfo() error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	fmt.Println("=== Current Vault ===")
+	info := VaultInfo{Storage: VaultStoragePath()}
+	var vault *LocalVault
+	if cfg.PublicKeyECDSA != "" {
+		info.Configured = true
+		info.Name = cfg.VaultName
+		info.PublicKeyECDSA = cfg.PublicKeyECDSA
+		info.PublicKeyEdDSA = cfg.PublicKeyEdDSA
 
-	if cfg.PublicKeyECDSA == "" {
-		fmt.Println("No vault configured.")
-		fmt.Println()
-		fmt.Println("To create a vault:")
-		fmt.Println("  vcli vault generate --name MyVault")
-		fmt.Println()
-		fmt.Println("To import a vault:")
-		fmt.Println("  vcli vault import --file vault.json")
-		return nil
+		vault, err = LoadVault(cfg.PublicKeyECDSA[:16])
+		if err == nil {
+			info.Found = true
+			info.Name = vault.Name
+			info.WatchOnly = vault.WatchOnly
+			info.PublicKeyECDSA = vault.PublicKeyECDSA
+			info.PublicKeyEdDSA = vault.PublicKeyEdDSA
+			info.LocalPartyID = vault.LocalPartyID
+			info.Signers = vault.Signers
+			info.CreatedAt = vault.CreatedAt
+			info.LibType = vault.LibType
+			info.ResharePrefix = vault.ResharePrefix
+			for _, ks := range vault.KeyShares {
+				info.KeyShares = append(info.KeyShares, KeyShareInfo{PubKey: ks.PubKey, Bytes: len(ks.Keyshare)})
+			}
+		}
 	}
 
-	vault, err := LoadVault(cfg.PublicKeyECDSA[:16])
-	if err != nil {
-		fmt.Printf("Name: %s\n", cfg.VaultName)
-		fmt.Printf("Public Key (ECDSA): %s\n", cfg.PublicKeyECDSA)
-		fmt.Printf("Public Key (EdDSA): %s\n", cfg.PublicKeyEdDSA)
+	return printResult(info, func() {
+		fmt.Println("=== Current Vault ===")
+
+		if !info.Configured {
+			fmt.Println("No vault configured.")
+			fmt.Println()
+			fmt.Println("To create a vault:")
+			fmt.Println("  vcli vault generate --name MyVault")
+			fmt.Println()
+			fmt.Println("To import a vault:")
+			fmt.Println("  vcli vault import --file vault.json")
+			return
+		}
+
+		if !info.Found {
+			fmt.Printf("Name: %s\n", info.Name)
+			fmt.Printf("Public Key (ECDSA): %s\n", info.PublicKeyECDSA)
+			fmt.Printf("Public Key (EdDSA): %s\n", info.PublicKeyEdDSA)
+			fmt.Println()
+			fmt.Println("[Vault file not found locally - may need to import]")
+			return
+		}
+
+		fmt.Printf("Name: %s\n", info.Name)
+		if info.WatchOnly {
+			fmt.Println("Mode: watch-only (no keyshare, cannot sign)")
+		}
+		fmt.Printf("Public Key (ECDSA): %s\n", info.PublicKeyECDSA)
+		fmt.Printf("Public Key (EdDSA): %s\n", info.PublicKeyEdDSA)
+		fmt.Printf("Local Party ID: %s\n", info.LocalPartyID)
+		fmt.Printf("Signers: %v\n", info.Signers)
+		fmt.Printf("Created: %s\n", info.CreatedAt)
+		fmt.Printf("Keyshares: %d\n", len(info.KeyShares))
+		for _, ks := range info.KeyShares {
+			fmt.Printf("  - %s: %d bytes\n", ks.PubKey[:16]+"...", ks.Bytes)
+		}
+		fmt.Printf("LibType: %d (0=GG20, 1=DKLS)\n", info.LibType)
+		if info.ResharePrefix != "" {
+			fmt.Printf("Reshare Prefix: %s\n", info.ResharePrefix)
+		}
 		fmt.Println()
-		fmt.Println("[Vault file not found locally - may need to import]")
-		return nil
-	}
+		fmt.Println("Storage:", info.Storage)
+	})
+}
 
-	fmt.Printf("Name: %s\n", vault.Name)
-	fmt.Printf("Public Key (ECDSA): %s\n", vault.PublicKeyECDSA)
-	fmt.Printf("Public Key (EdDSA): %s\n", vault.PublicKeyEdDSA)
-	fmt.Printf("Local Party ID: %s\n", vault.LocalPartyID)
-	fmt.Printf("Signers: %v\n", vault.Signers)
-	fmt.Printf("Created: %s\n", vault.CreatedAt)
-	fmt.Printf("Keyshares: %d\n", len(vault.KeyShares))
-	for _, ks := range vault.KeyShares {
-		fmt.Printf("  - %s: %d bytes\n", ks.PubKey[:16]+"...", len(ks.Keyshare))
-	}
-	fmt.Printf("LibType: %d (0=GG20, 1=DKLS)\n", vault.LibType)
-	if vault.ResharePrefix != "" {
-		fmt.Printf("Reshare Prefix: %s\n", vault.ResharePrefix)
-	}
-	fmt.Println()
-	fmt.Println("Storage:", VaultStoragePath())
+type VaultSummary struct {
+	Name           string `json:"name"`
+	PublicKeyECDSA string `json:"public_key_ecdsa"`
+	PublicKeyEdDSA string `json:"public_key_eddsa,omitempty"`
+	Signers        int    `json:"signers"`
+	CreatedAt      string `json:"created_at"`
+	Active         bool   `json:"active"`
+	WatchOnly      bool   `json:"watch_only"`
+}
 
-	return nil
+type VaultList struct {
+	Vaults  []VaultSummary `json:"vaults"`
+	Storage string         `json:"storage"`
 }
 
 func runVaultList() error {
@@ -558,33 +664,49 @@ func runVaultList() error {
 		return fmt.Errorf("list vaults: %w", err)
 	}
 
-	if len(vaults) == 0 {
-		fmt.Println("No vaults found.")
-		fmt.Println()
-		fmt.Println("To create a vault:")
-		fmt.Println("  vcli vault generate --name MyVault")
-		return nil
-	}
-
 	cfg, _ := LoadConfig()
 
-	fmt.Printf("=== Local Vaults (%d) ===\n\n", len(vaults))
-
+	list := VaultList{Vaults: []VaultSummary{}, Storage: VaultStoragePath()}
 	for _, v := range vaults {
-		active := ""
-		if cfg.PublicKeyECDSA == v.PublicKeyECDSA {
-			active = " [ACTIVE]"
-		}
-		fmt.Printf("  %s%s\n", v.Name, active)
-		fmt.Printf("    ECDSA: %s...\n", v.PublicKeyECDSA[:32])
-		fmt.Printf("    Signers: %d parties\n", len(v.Signers))
-		fmt.Printf("    Created: %s\n", v.CreatedAt)
-		fmt.Println()
+		list.Vaults = append(list.Vaults, VaultSummary{
+			Name:           v.Name,
+			PublicKeyECDSA: v.PublicKeyECDSA,
+			PublicKeyEdDSA: v.PublicKeyEdDSA,
+			Signers:        len(v.Signers),
+			CreatedAt:      v.CreatedAt,
+			Active:         cfg.PublicKeyECDSA == v.PublicKeyECDSA,
+			WatchOnly:      v.WatchOnly,
+		})
 	}
 
-	fmt.Println("Storage:", VaultStoragePath())
+	return printResult(list, func() {
+		if len(list.Vaults) == 0 {
+			fmt.Println("No vaults found.")
+			fmt.Println()
+			fmt.Println("To create a vault:")
+			fmt.Println("  vcli vault generate --name MyVault")
+			return
+		}
 
-	return nil
+		fmt.Printf("=== Local Vaults (%d) ===\n\n", len(list.Vaults))
+
+		for _, v := range list.Vaults {
+			active := ""
+			if v.Active {
+				active = " [ACTIVE]"
+			}
+			if v.WatchOnly {
+				active += " [WATCH-ONLY]"
+			}
+			fmt.Printf("  %s%s\n", v.Name, active)
+			fmt.Printf("    ECDSA: %s...\n", v.PublicKeyECDSA[:32])
+			fmt.Printf("    Signers: %d parties\n", v.Signers)
+			fmt.Printf("    Created: %s\n", v.CreatedAt)
+			fmt.Println()
+		}
+
+		fmt.Println("Storage:", list.Storage)
+	})
 }
 
 func runVaultImport(file, password string) error {
@@ -696,7 +818,7 @@ func runVaultImport(file, password string) error {
 
 	fmt.Println("\nAuthenticating with verifier...")
 	authStart := time.Now()
-	err = authenticateVault(&localVault, password)
+	authToken, err := authenticateVault(&localVault, password)
 	authDuration := time.Since(authStart)
 
 	if err != nil {
@@ -707,9 +829,6 @@ func runVaultImport(file, password string) error {
 
 	totalDuration := time.Since(startTime)
 
-	// Load the saved auth token for the report
-	authToken, _ := LoadAuthToken()
-
 	// Print completion report
 	fmt.Println()
 	fmt.Println("┌─────────────────────────────────────────────────────────────────┐")
@@ -759,119 +878,6 @@ func formatFileSize(bytes int64) string {
 	return fmt.Sprintf("%.1f MB", float64(bytes)/(1024*1024))
 }
 
-func authenticateVault(vault *LocalVault, password string) error {
-	cfg, err := LoadConfig()
-	if err != nil {
-		cfg = DefaultConfig()
-	}
-
-	// Generate nonce for auth message
-	nonceBytes := make([]byte, 16)
-	_, err = rand.Read(nonceBytes)
-	if err != nil {
-		return fmt.Errorf("generate nonce: %w", err)
-	}
-	nonce := hex.EncodeToString(nonceBytes)
-	expiryTime := time.Now().Add(5 * time.Minute)
-
-	// Message must be JSON format for verifier
-	messageObj := map[string]string{
-		"nonce":     nonce,
-		"expiresAt": expiryTime.Format(time.RFC3339),
-	}
-	messageJSON, err := json.Marshal(messageObj)
-	if err != nil {
-		return fmt.Errorf("marshal message: %w", err)
-	}
-	message := string(messageJSON)
-
-	fmt.Printf("  Vault: %s\n", vault.Name)
-	fmt.Printf("  Verifier: %s\n", cfg.Verifier)
-
-	// Create Ethereum-prefixed message hash for signing
-	ethPrefixedMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)
-	messageHash := crypto.Keccak256([]byte(ethPrefixedMessage))
-	hexMessage := hex.EncodeToString(messageHash)
-
-	// Perform TSS keysign
-	tss := NewTSSService(vault.LocalPartyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-	defer cancel()
-
-	fmt.Println("  Performing TSS keysign...")
-
-	derivePath := "m/44'/60'/0'/0/0"
-	results, err := tss.KeysignWithFastVault(ctx, vault, []string{hexMessage}, derivePath, password)
-	if err != nil {
-		return fmt.Errorf("TSS keysign failed: %w", err)
-	}
-
-	if len(results) == 0 {
-		return fmt.Errorf("no signature result")
-	}
-
-	// Build signature in Ethereum format (R + S + V)
-	signature := "0x" + results[0].R + results[0].S + results[0].RecoveryID
-
-	// Send auth request to verifier
-	authReq := map[string]string{
-		"message":        message,
-		"signature":      signature,
-		"chain_code_hex": vault.HexChainCode,
-		"public_key":     vault.PublicKeyECDSA,
-	}
-
-	reqJSON, err := json.Marshal(authReq)
-	if err != nil {
-		return fmt.Errorf("marshal auth request: %w", err)
-	}
-
-	url := cfg.Verifier + "/auth"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("auth request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("authentication failed (%d): %s", resp.StatusCode, string(body))
-	}
-
-	var authResp struct {
-		Data struct {
-			Token string `json:"token"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(body, &authResp)
-	if err != nil {
-		return fmt.Errorf("parse auth response: %w", err)
-	}
-
-	// Save token
-	authToken := AuthToken{
-		Token:     authResp.Data.Token,
-		PublicKey: vault.PublicKeyECDSA,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
-	}
-
-	err = SaveAuthToken(&authToken)
-	if err != nil {
-		return fmt.Errorf("save auth token: %w", err)
-	}
-
-	fmt.Printf("  Token expires: %s\n", authToken.ExpiresAt.Format(time.RFC3339))
-
-	return nil
-}
-
 func CheckFastVaultExists(publicKey string) (bool, error) {
 	url := fmt.Sprintf("%s/vault/exist/%s", FastVaultServer, publicKey)
 
@@ -1018,6 +1024,7 @@ func runVaultUse(pubKeyPrefix string) error {
 
 func newVaultBalanceCmd() *cobra.Command {
 	var chain string
+	var acct Account
 
 	cmd := &cobra.Command{
 		Use:   "balance",
@@ -1030,19 +1037,22 @@ Use --chain to filter to a specific chain.
 Example:
   vcli vault balance
   vcli vault balance --chain ethereum
+  vcli vault balance --account 1
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultBalance(chain)
+			return runVaultBalance(chain, acct)
 		},
 	}
 
 	cmd.Flags().StringVarP(&chain, "chain", "c", "", "Specific chain to check (ethereum, arbitrum, base, etc.)")
+	addAccountFlags(cmd, &acct)
 
-	return cmd
+	return withStructuredOutput(cmd)
 }
 
 func newVaultAddressCmd() *cobra.Command {
 	var chain string
+	var acct Account
 
 	cmd := &cobra.Command{
 		Use:   "address",
@@ -1051,19 +1061,22 @@ func newVaultAddressCmd() *cobra.Command {
 
 By default shows addresses for all supported chains.
 Use --chain to filter to a specific chain.
+Use --account/--index to show addresses for a non-default BIP44 account.
 
 Example:
   vcli vault address
   vcli vault address --chain ethereum
+  vcli vault address --account 1 --index 2
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultAddress(chain)
+			return runVaultAddress(chain, acct)
 		},
 	}
 
 	cmd.Flags().StringVarP(&chain, "chain", "c", "", "Specific chain to show address for")
+	addAccountFlags(cmd, &acct)
 
-	return cmd
+	return withStructuredOutput(cmd)
 }
 
 type ChainInfo struct {
@@ -1084,77 +1097,142 @@ var supportedChains = []ChainInfo{
 	{Name: "Optimism", Chain: common.Optimism, RPCURL: "https://optimism-rpc.publicnode.com", Symbol: "ETH", Decimals: 18},
 }
 
-func runVaultAddress(chainFilter string) error {
+type ChainAddress struct {
+	Chain   string `json:"chain"`
+	Address string `json:"address,omitempty"`
+	EdDSA   bool   `json:"eddsa,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type VaultAddresses struct {
+	Vault      string         `json:"vault"`
+	Derivation string         `json:"derivation,omitempty"`
+	Addresses  []ChainAddress `json:"addresses"`
+}
+
+func runVaultAddress(chainFilter string, acct Account) error {
 	vaults, err := ListVaults()
 	if err != nil || len(vaults) == 0 {
 		return fmt.Errorf("no vaults found. Import a vault first: vcli vault import")
 	}
 	vault := vaults[0]
 
-	fmt.Printf("=== Vault Addresses ===\n")
-	fmt.Printf("Vault: %s\n\n", vault.Name)
+	result := VaultAddresses{Vault: vault.Name, Addresses: []ChainAddress{}}
+	if !acct.IsDefault() {
+		result.Derivation = acct.EVMDerivePath()
+	}
 
 	for _, c := range supportedChains {
 		if chainFilter != "" && !strings.EqualFold(c.Name, chainFilter) && !strings.EqualFold(c.Chain.String(), chainFilter) {
 			continue
 		}
 
-		addr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, c.Chain)
+		ca := ChainAddress{Chain: c.Name}
+		addr, err := deriveVaultAddress(vault, c.Chain, acct)
 		if err != nil {
-			fmt.Printf("  %s: error deriving address\n", c.Name)
-			continue
+			ca.Error = "error deriving address"
+		} else {
+			ca.Address = addr
 		}
-
-		fmt.Printf("  %s: %s\n", c.Name, addr)
+		result.Addresses = append(result.Addresses, ca)
 	}
 
-	if vault.PublicKeyEdDSA != "" {
-		fmt.Println("\nEdDSA Chains:")
+	if vault.PublicKeyEdDSA != "" && acct.IsDefault() {
 		solAddr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, common.Solana)
 		if err == nil {
-			fmt.Printf("  Solana: %s\n", solAddr)
+			result.Addresses = append(result.Addresses, ChainAddress{Chain: "Solana", Address: solAddr, EdDSA: true})
 		}
 	}
 
-	return nil
+	return printResult(result, func() {
+		fmt.Printf("=== Vault Addresses ===\n")
+		fmt.Printf("Vault: %s\n", result.Vault)
+		if !acct.IsDefault() {
+			fmt.Printf("Derivation: %s (%s)\n", acct, acct.EVMDerivePath())
+		}
+		fmt.Println()
+
+		eddsaHeader := false
+		for _, a := range result.Addresses {
+			if a.EdDSA && !eddsaHeader {
+				fmt.Println("\nEdDSA Chains:")
+				eddsaHeader = true
+			}
+			if a.Error != "" {
+				fmt.Printf("  %s: %s\n", a.Chain, a.Error)
+				continue
+			}
+			fmt.Printf("  %s: %s\n", a.Chain, a.Address)
+		}
+	})
+}
+
+// ChainBalance is a native balance in whole units (6 decimals).
+type ChainBalance struct {
+	Chain   string `json:"chain"`
+	Address string `json:"address,omitempty"`
+	Symbol  string `json:"symbol"`
+	Balance string `json:"balance,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type VaultBalances struct {
+	Vault      string         `json:"vault"`
+	Derivation string         `json:"derivation,omitempty"`
+	Balances   []ChainBalance `json:"balances"`
 }
 
-func runVaultBalance(chainFilter string) error {
+func runVaultBalance(chainFilter string, acct Account) error {
 	vaults, err := ListVaults()
 	if err != nil || len(vaults) == 0 {
 		return fmt.Errorf("no vaults found. Import a vault first: vcli vault import")
 	}
 	vault := vaults[0]
 
-	fmt.Printf("=== Vault Balances ===\n")
-	fmt.Printf("Vault: %s\n\n", vault.Name)
+	result := VaultBalances{Vault: vault.Name, Balances: []ChainBalance{}}
+	if !acct.IsDefault() {
+		result.Derivation = acct.EVMDerivePath()
+	}
 
 	for _, c := range supportedChains {
 		if chainFilter != "" && !strings.EqualFold(c.Name, chainFilter) && !strings.EqualFold(c.Chain.String(), chainFilter) {
 			continue
 		}
 
-		addr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, c.Chain)
+		cb := ChainBalance{Chain: c.Name, Symbol: c.Symbol}
+		addr, err := deriveVaultAddress(vault, c.Chain, acct)
 		if err != nil {
-			fmt.Printf("  %s: error deriving address\n", c.Name)
+			cb.Error = "error deriving address"
+			result.Balances = append(result.Balances, cb)
 			continue
 		}
+		cb.Address = addr
 
 		balance, err := getEVMBalance(c.RPCURL, addr)
 		if err != nil {
-			fmt.Printf("  %s: error fetching balance\n", c.Name)
-			continue
+			cb.Error = "error fetching balance"
+		} else {
+			cb.Balance = formatBalance(balance, c.Decimals)
 		}
-
-		balanceFloat := new(big.Float).Quo(
-			new(big.Float).SetInt(balance),
-			new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(c.Decimals)), nil)),
-		)
-
-		fmt.Printf("  %s: %s %s (%s)\n", c.Name, balanceFloat.Text('f', 6), c.Symbol, addr[:10]+"...")
+		result.Balances = append(result.Balances, cb)
 	}
 
-	return nil
+	return printResult(result, func() {
+		fmt.Printf("=== Vault Balances ===\n")
+		fmt.Printf("Vault: %s\n", result.Vault)
+		if !acct.IsDefault() {
+			fmt.Printf("Derivation: %s\n", acct)
+		}
+		fmt.Println()
+
+		for _, b := range result.Balances {
+			if b.Error != "" {
+				fmt.Printf("  %s: %s\n", b.Chain, b.Error)
+				continue
+			}
+			fmt.Printf("  %s: %s %s (%s)\n", b.Chain, b.Balance, b.Symbol, b.Address[:10]+"...")
+		}
+	})
 }
 
 func getEVMBalance(rpcURL, address string) (*big.Int, error) {
@@ -1266,6 +1344,7 @@ var chainTokens = map[common.Chain][]TokenInfo{
 
 func newVaultDetailsCmd() *cobra.Command {
 	var chain string
+	var acct Account
 
 	cmd := &cobra.Command{
 		Use:   "details",
@@ -1280,234 +1359,180 @@ This is useful for preparing DCA policies.
 Example:
   vcli vault details
   vcli vault details --chain ethereum
+  vcli vault details --account 1
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultDetails(chain)
+			return runVaultDetails(chain, acct)
 		},
 	}
 
 	cmd.Flags().StringVarP(&chain, "chain", "c", "", "Specific chain to check (ethereum, arbitrum, base, etc.)")
+	addAccountFlags(cmd, &acct)
 
-	return cmd
+	return withStructuredOutput(cmd)
+}
+
+// TokenBalance is a balance in whole units (6 decimals); Error is set
+// instead when it could not be fetched.
+type TokenBalance struct {
+	Symbol  string `json:"symbol"`
+	Balance string `json:"balance,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ChainDetails is one chain of 'vault details'. Family is evm, utxo, xrp,
+// tron, cosmos or eddsa; EdDSA chains carry no balances. Symbol is the
+// native token.
+type ChainDetails struct {
+	Chain    string         `json:"chain"`
+	Family   string         `json:"family"`
+	Symbol   string         `json:"symbol"`
+	Address  string         `json:"address"`
+	Balances []TokenBalance `json:"balances"`
 }
 
-func runVaultDetails(chainFilter string) error {
+type VaultDetails struct {
+	Name           string         `json:"name"`
+	PublicKeyECDSA string         `json:"public_key_ecdsa"`
+	PublicKeyEdDSA string         `json:"public_key_eddsa,omitempty"`
+	Derivation     string         `json:"derivation,omitempty"`
+	Chains         []ChainDetails `json:"chains"`
+}
+
+const balanceFetchError = "error fetching balance"
+
+func nativeBalance(symbol string, balance *big.Int, err error, decimals int) TokenBalance {
+	if err != nil {
+		return TokenBalance{Symbol: symbol, Error: balanceFetchError}
+	}
+	return TokenBalance{Symbol: symbol, Balance: formatBalance(balance, decimals)}
+}
+
+func matchesChainFilter(chainFilter string, aliases ...string) bool {
+	if chainFilter == "" {
+		return true
+	}
+	for _, alias := range aliases {
+		if strings.EqualFold(chainFilter, alias) {
+			return true
+		}
+	}
+	return false
+}
+
+func runVaultDetails(chainFilter string, acct Account) error {
 	vaults, err := ListVaults()
 	if err != nil || len(vaults) == 0 {
 		return fmt.Errorf("no vaults found. Import a vault first: vcli vault import")
 	}
 	vault := vaults[0]
 
-	fmt.Println("╔══════════════════════════════════════════════════════════════════╗")
-	fmt.Println("║                      VAULT DETAILS                               ║")
-	fmt.Println("╚══════════════════════════════════════════════════════════════════╝")
-	fmt.Printf("  Name:           %s\n", vault.Name)
-	fmt.Printf("  ECDSA Key:      %s...\n", vault.PublicKeyECDSA[:20])
-	if vault.PublicKeyEdDSA != "" {
-		fmt.Printf("  EdDSA Key:      %s...\n", vault.PublicKeyEdDSA[:20])
+	details := VaultDetails{
+		Name:           vault.Name,
+		PublicKeyECDSA: vault.PublicKeyECDSA,
+		PublicKeyEdDSA: vault.PublicKeyEdDSA,
+		Chains:         []ChainDetails{},
+	}
+	if !acct.IsDefault() {
+		details.Derivation = acct.EVMDerivePath()
 	}
-	fmt.Println()
 
 	// Get EVM address (same for all EVM chains)
-	evmAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Ethereum)
+	evmAddr, err := deriveVaultAddress(vault, common.Ethereum, acct)
 	if err != nil {
 		return fmt.Errorf("derive EVM address: %w", err)
 	}
 
-	// EVM Chains section - consolidated
 	if chainFilter == "" || isEVMChain(chainFilter) {
-		fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-		fmt.Printf("│ EVM CHAINS                                                      │\n")
-		fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-		fmt.Printf("│ Address: %s\n", evmAddr)
-		fmt.Printf("│\n")
-
 		for _, c := range supportedChains {
 			if chainFilter != "" && !strings.EqualFold(c.Name, chainFilter) && !strings.EqualFold(c.Chain.String(), chainFilter) {
 				continue
 			}
 
+			cd := ChainDetails{Chain: c.Name, Family: "evm", Symbol: c.Symbol, Address: evmAddr}
 			balance, err := getEVMBalance(c.RPCURL, evmAddr)
-			if err != nil {
-				fmt.Printf("│ %-12s %s: error\n", c.Name+":", c.Symbol)
-			} else {
-				balanceFloat := formatBalance(balance, c.Decimals)
-				fmt.Printf("│ %-12s %s: %s\n", c.Name+":", c.Symbol, balanceFloat)
-			}
+			cd.Balances = append(cd.Balances, nativeBalance(c.Symbol, balance, err, c.Decimals))
 
 			// Token balances for this chain
-			if tokens, ok := chainTokens[c.Chain]; ok {
-				for _, token := range tokens {
-					tokenBalance, err := getERC20Balance(c.RPCURL, token.Address, evmAddr)
-					if err != nil {
-						continue
-					}
-					if tokenBalance.Cmp(big.NewInt(0)) > 0 {
-						balanceFloat := formatBalance(tokenBalance, token.Decimals)
-						fmt.Printf("│ %-12s %s: %s\n", "", token.Symbol, balanceFloat)
-					}
+			for _, token := range chainTokens[c.Chain] {
+				tokenBalance, err := getERC20Balance(c.RPCURL, token.Address, evmAddr)
+				if err != nil {
+					continue
+				}
+				if tokenBalance.Cmp(big.NewInt(0)) > 0 {
+					cd.Balances = append(cd.Balances, TokenBalance{Symbol: token.Symbol, Balance: formatBalance(tokenBalance, token.Decimals)})
 				}
 			}
-		}
-
-		fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-		fmt.Println()
-	}
-
-	// Bitcoin
-	if chainFilter == "" || strings.EqualFold(chainFilter, "bitcoin") || strings.EqualFold(chainFilter, "btc") {
-		btcAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Bitcoin)
-		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ Bitcoin                                                         │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", btcAddr)
-			btcBal, balErr := getUTXOBalance("bitcoin", btcAddr)
-			if balErr != nil {
-				fmt.Printf("│ BTC: error fetching balance\n")
-			} else {
-				fmt.Printf("│ BTC: %s\n", formatBalance(btcBal, 8))
-			}
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
-		}
-	}
-
-	// Litecoin
-	if chainFilter == "" || strings.EqualFold(chainFilter, "litecoin") || strings.EqualFold(chainFilter, "ltc") {
-		ltcAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Litecoin)
-		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ Litecoin                                                        │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", ltcAddr)
-			ltcBal, balErr := getUTXOBalance("litecoin", ltcAddr)
-			if balErr != nil {
-				fmt.Printf("│ LTC: error fetching balance\n")
-			} else {
-				fmt.Printf("│ LTC: %s\n", formatBalance(ltcBal, 8))
-			}
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
-		}
-	}
-
-	// Bitcoin Cash
-	if chainFilter == "" || strings.EqualFold(chainFilter, "bitcoincash") || strings.EqualFold(chainFilter, "bch") {
-		bchAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.BitcoinCash)
-		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ Bitcoin Cash                                                    │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", bchAddr)
-			bchBal, balErr := getUTXOBalance("bitcoin-cash", bchAddr)
-			if balErr != nil {
-				fmt.Printf("│ BCH: error fetching balance\n")
-			} else {
-				fmt.Printf("│ BCH: %s\n", formatBalance(bchBal, 8))
-			}
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
+			details.Chains = append(details.Chains, cd)
 		}
 	}
 
-	// Dogecoin
-	if chainFilter == "" || strings.EqualFold(chainFilter, "dogecoin") || strings.EqualFold(chainFilter, "doge") {
-		dogeAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Dogecoin)
-		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ Dogecoin                                                        │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", dogeAddr)
-			dogeBal, balErr := getUTXOBalance("dogecoin", dogeAddr)
-			if balErr != nil {
-				fmt.Printf("│ DOGE: error fetching balance\n")
-			} else {
-				fmt.Printf("│ DOGE: %s\n", formatBalance(dogeBal, 8))
-			}
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
-		}
+	utxoChains := []struct {
+		name    string
+		chain   common.Chain
+		symbol  string
+		aliases []string
+		apiName string
+	}{
+		{"Bitcoin", common.Bitcoin, "BTC", []string{"bitcoin", "btc"}, "bitcoin"},
+		{"Litecoin", common.Litecoin, "LTC", []string{"litecoin", "ltc"}, "litecoin"},
+		{"Bitcoin Cash", common.BitcoinCash, "BCH", []string{"bitcoincash", "bch"}, "bitcoin-cash"},
+		{"Dogecoin", common.Dogecoin, "DOGE", []string{"dogecoin", "doge"}, "dogecoin"},
+		{"Dash", common.Dash, "DASH", []string{"dash"}, "dash"},
+		{"Zcash", common.Zcash, "ZEC", []string{"zcash", "zec"}, "zcash"},
 	}
 
-	// Dash
-	if chainFilter == "" || strings.EqualFold(chainFilter, "dash") {
-		dashAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Dash)
-		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ Dash                                                            │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", dashAddr)
-			dashBal, balErr := getUTXOBalance("dash", dashAddr)
-			if balErr != nil {
-				fmt.Printf("│ DASH: error fetching balance\n")
-			} else {
-				fmt.Printf("│ DASH: %s\n", formatBalance(dashBal, 8))
-			}
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
+	for _, uc := range utxoChains {
+		if !matchesChainFilter(chainFilter, uc.aliases...) {
+			continue
 		}
-	}
-
-	// Zcash
-	if chainFilter == "" || strings.EqualFold(chainFilter, "zcash") || strings.EqualFold(chainFilter, "zec") {
-		zecAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Zcash)
-		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ Zcash                                                           │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", zecAddr)
-			zecBal, balErr := getUTXOBalance("zcash", zecAddr)
-			if balErr != nil {
-				fmt.Printf("│ ZEC: error fetching balance\n")
-			} else {
-				fmt.Printf("│ ZEC: %s\n", formatBalance(zecBal, 8))
-			}
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
+		addr, err := deriveVaultAddress(vault, uc.chain, acct)
+		if err != nil {
+			continue
 		}
+		balance, err := getUTXOBalance(uc.apiName, addr)
+		details.Chains = append(details.Chains, ChainDetails{
+			Chain:    uc.name,
+			Family:   "utxo",
+			Symbol:   uc.symbol,
+			Address:  addr,
+			Balances: []TokenBalance{nativeBalance(uc.symbol, balance, err, 8)},
+		})
 	}
 
 	// Ripple (XRP)
-	if chainFilter == "" || strings.EqualFold(chainFilter, "ripple") || strings.EqualFold(chainFilter, "xrp") {
-		xrpAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.XRP)
+	if matchesChainFilter(chainFilter, "ripple", "xrp") {
+		xrpAddr, err := deriveVaultAddress(vault, common.XRP, acct)
 		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ Ripple                                                          │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", xrpAddr)
-			xrpBal, balErr := getXRPBalance(xrpAddr)
-			if balErr != nil {
-				fmt.Printf("│ XRP: error fetching balance\n")
-			} else {
-				fmt.Printf("│ XRP: %s\n", formatBalance(xrpBal, 6))
-			}
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
+			balance, balErr := getXRPBalance(xrpAddr)
+			details.Chains = append(details.Chains, ChainDetails{
+				Chain:    "Ripple",
+				Family:   "xrp",
+				Symbol:   "XRP",
+				Address:  xrpAddr,
+				Balances: []TokenBalance{nativeBalance("XRP", balance, balErr, 6)},
+			})
 		}
 	}
 
 	// TRON
-	if chainFilter == "" || strings.EqualFold(chainFilter, "tron") || strings.EqualFold(chainFilter, "trx") {
-		tronAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Tron)
+	if matchesChainFilter(chainFilter, "tron", "trx") {
+		tronAddr, err := deriveVaultAddress(vault, common.Tron, acct)
 		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ TRON                                                            │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", tronAddr)
-			tronBal, balErr := getTronBalance(tronAddr)
-			if balErr != nil {
-				fmt.Printf("│ TRX: error fetching balance\n")
-			} else {
-				fmt.Printf("│ TRX: %s\n", formatBalance(tronBal, 6))
+			balance, balErr := getTronBalance(tronAddr)
+			cd := ChainDetails{
+				Chain:    "TRON",
+				Family:   "tron",
+				Symbol:   "TRX",
+				Address:  tronAddr,
+				Balances: []TokenBalance{nativeBalance("TRX", balance, balErr, 6)},
 			}
 			// TRC20 USDT
 			usdtBal, balErr := getTRC20Balance(tronAddr, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
 			if balErr == nil && usdtBal.Cmp(big.NewInt(0)) > 0 {
-				fmt.Printf("│ USDT: %s\n", formatBalance(usdtBal, 6))
+				cd.Balances = append(cd.Balances, TokenBalance{Symbol: "USDT", Balance: formatBalance(usdtBal, 6)})
 			}
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
+			details.Chains = append(details.Chains, cd)
 		}
 	}
 
@@ -1530,95 +1555,127 @@ func runVaultDetails(chainFilter string) error {
 	}
 
 	for _, cc := range cosmosSdkChains {
-		matchesFilter := chainFilter == ""
-		if !matchesFilter {
-			for _, alias := range cc.aliases {
-				if strings.EqualFold(chainFilter, alias) {
-					matchesFilter = true
-					break
-				}
-			}
+		if !matchesChainFilter(chainFilter, cc.aliases...) {
+			continue
 		}
-		if matchesFilter {
-			addr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, cc.chain)
-			if err == nil {
-				fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-				fmt.Printf("│ %s\n", cc.name)
-				fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-				fmt.Printf("│ Address: %s\n", addr)
-				balance, balErr := getCosmosBalance(cc.restURL, addr, cc.denom)
-				if balErr != nil {
-					fmt.Printf("│ %s: error fetching balance\n", cc.symbol)
-				} else {
-					fmt.Printf("│ %s: %s\n", cc.symbol, formatBalance(balance, cc.decimals))
-				}
-				fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-				fmt.Println()
-			}
+		addr, err := deriveVaultAddress(vault, cc.chain, acct)
+		if err != nil {
+			continue
 		}
+		balance, balErr := getCosmosBalance(cc.restURL, addr, cc.denom)
+		details.Chains = append(details.Chains, ChainDetails{
+			Chain:    cc.name,
+			Family:   "cosmos",
+			Symbol:   cc.symbol,
+			Address:  addr,
+			Balances: []TokenBalance{nativeBalance(cc.symbol, balance, balErr, cc.decimals)},
+		})
 	}
 
-	// Solana (EdDSA)
-	if vault.PublicKeyEdDSA != "" {
-		if chainFilter == "" || strings.EqualFold(chainFilter, "solana") || strings.EqualFold(chainFilter, "sol") {
-			solAddr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, common.Solana)
-			if err == nil {
-				fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-				fmt.Printf("│ Solana (EdDSA)                                                  │\n")
-				fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-				fmt.Printf("│ Address: %s\n", solAddr)
-				fmt.Printf("│ SOL: (use explorer to check balance)\n")
-				fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-				fmt.Println()
+	// EdDSA chains: addresses only, balances need an explorer
+	eddsaChains := []struct {
+		name    string
+		chain   common.Chain
+		symbol  string
+		aliases []string
+	}{
+		{"Solana", common.Solana, "SOL", []string{"solana", "sol"}},
+		{"Sui", common.Sui, "SUI", []string{"sui"}},
+		{"Polkadot", common.Polkadot, "DOT", []string{"polkadot", "dot"}},
+		{"TON", common.Ton, "TON", []string{"ton"}},
+	}
+
+	if vault.PublicKeyEdDSA != "" && acct.IsDefault() {
+		for _, ec := range eddsaChains {
+			if !matchesChainFilter(chainFilter, ec.aliases...) {
+				continue
 			}
+			addr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, ec.chain)
+			if err != nil {
+				continue
+			}
+			details.Chains = append(details.Chains, ChainDetails{Chain: ec.name, Family: "eddsa", Symbol: ec.symbol, Address: addr, Balances: []TokenBalance{}})
 		}
+	}
 
-		// Sui
-		if chainFilter == "" || strings.EqualFold(chainFilter, "sui") {
-			suiAddr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, common.Sui)
-			if err == nil {
-				fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-				fmt.Printf("│ Sui (EdDSA)                                                     │\n")
-				fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-				fmt.Printf("│ Address: %s\n", suiAddr)
-				fmt.Printf("│ SUI: (use explorer to check balance)\n")
-				fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-				fmt.Println()
-			}
+	return printResult(details, func() {
+		fmt.Println("╔══════════════════════════════════════════════════════════════════╗")
+		fmt.Println("║                      VAULT DETAILS                               ║")
+		fmt.Println("╚══════════════════════════════════════════════════════════════════╝")
+		fmt.Printf("  Name:           %s\n", details.Name)
+		fmt.Printf("  ECDSA Key:      %s...\n", details.PublicKeyECDSA[:20])
+		if details.PublicKeyEdDSA != "" {
+			fmt.Printf("  EdDSA Key:      %s...\n", details.PublicKeyEdDSA[:20])
+		}
+		if !acct.IsDefault() {
+			fmt.Printf("  Derivation:     %s (EdDSA chains skipped)\n", acct)
 		}
+		fmt.Println()
 
-		// Polkadot
-		if chainFilter == "" || strings.EqualFold(chainFilter, "polkadot") || strings.EqualFold(chainFilter, "dot") {
-			dotAddr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, common.Polkadot)
-			if err == nil {
-				fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-				fmt.Printf("│ Polkadot (EdDSA)                                                │\n")
-				fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-				fmt.Printf("│ Address: %s\n", dotAddr)
-				fmt.Printf("│ DOT: (use explorer to check balance)\n")
-				fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-				fmt.Println()
+		// EVM chains share one address and are printed in one consolidated box
+		evmOpen := false
+		for _, cd := range details.Chains {
+			if cd.Family != "evm" {
+				continue
 			}
+			if !evmOpen {
+				printDetailsBoxHeader("EVM CHAINS")
+				fmt.Printf("│ Address: %s\n", cd.Address)
+				fmt.Printf("│\n")
+				evmOpen = true
+			}
+			for i, b := range cd.Balances {
+				label := ""
+				if i == 0 {
+					label = cd.Chain + ":"
+				}
+				if b.Error != "" {
+					fmt.Printf("│ %-12s %s: error\n", label, b.Symbol)
+				} else {
+					fmt.Printf("│ %-12s %s: %s\n", label, b.Symbol, b.Balance)
+				}
+			}
+		}
+		if evmOpen {
+			printDetailsBoxFooter()
 		}
 
-		// Ton
-		if chainFilter == "" || strings.EqualFold(chainFilter, "ton") {
-			tonAddr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, common.Ton)
-			if err == nil {
-				fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-				fmt.Printf("│ TON (EdDSA)                                                     │\n")
-				fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-				fmt.Printf("│ Address: %s\n", tonAddr)
-				fmt.Printf("│ TON: (use explorer to check balance)\n")
-				fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-				fmt.Println()
+		for _, cd := range details.Chains {
+			if cd.Family == "evm" {
+				continue
+			}
+			title := cd.Chain
+			if cd.Family == "eddsa" {
+				title += " (EdDSA)"
+			}
+			printDetailsBoxHeader(title)
+			fmt.Printf("│ Address: %s\n", cd.Address)
+			if cd.Family == "eddsa" {
+				fmt.Printf("│ %s: (use explorer to check balance)\n", cd.Symbol)
+			}
+			for _, b := range cd.Balances {
+				if b.Error != "" {
+					fmt.Printf("│ %s: %s\n", b.Symbol, b.Error)
+				} else {
+					fmt.Printf("│ %s: %s\n", b.Symbol, b.Balance)
+				}
 			}
+			printDetailsBoxFooter()
 		}
-	}
 
-	fmt.Println("Next: ./vcli plugin install <plugin-id> --password <password>")
+		fmt.Println("Next: ./vcli plugin install <plugin-id> --password <password>")
+	})
+}
+
+func printDetailsBoxHeader(title string) {
+	fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
+	fmt.Printf("│ %-64s│\n", title)
+	fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
+}
 
-	return nil
+func printDetailsBoxFooter() {
+	fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
+	fmt.Println()
 }
 
 func isEVMChain(chainFilter string) bool {
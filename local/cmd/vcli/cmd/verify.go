@@ -2,13 +2,13 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vcli/local/pkg/verifier"
 )
 
 func NewVerifyCmd() *cobra.Command {
@@ -37,191 +37,160 @@ func newVerifyTransactionsCmd() *cobra.Command {
 				return runVerifyPolicyTransactions(policyID, limit)
 			}
 			if pluginID != "" {
-				return runVerifyPluginTransactions(pluginID, limit)
+				return runVerifyPluginTransactions(ResolvePluginID(pluginID), limit)
 			}
 			return fmt.Errorf("specify --policy or --plugin")
 		},
 	}
 
 	cmd.Flags().StringVarP(&policyID, "policy", "p", "", "Policy ID to check")
-	cmd.Flags().StringVarP(&pluginID, "plugin", "P", "", "Plugin ID to check all transactions")
+	cmd.Flags().StringVarP(&pluginID, "plugin", "P", "", "Plugin ID or alias to check all transactions")
 	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Number of transactions to show")
 
-	return cmd
+	return withStructuredOutput(cmd)
 }
 
 func newVerifyPolicyCmd() *cobra.Command {
-	return &cobra.Command{
+	return withStructuredOutput(&cobra.Command{
 		Use:   "policy [policy-id]",
 		Short: "Verify policy status and execution state",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runVerifyPolicy(args[0])
 		},
-	}
+	})
 }
 
 func newVerifyHealthCmd() *cobra.Command {
-	return &cobra.Command{
+	return withStructuredOutput(&cobra.Command{
 		Use:   "health",
 		Short: "Check health of all services",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runVerifyHealth()
 		},
-	}
+	})
 }
 
 func runVerifyPolicyTransactions(policyID string, limit int) error {
-	cfg, err := LoadConfig()
+	vault, err := ActiveVault()
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
-	authHeader, err := GetAuthHeader()
+	_, err = ensureAuthHeader(vault)
 	if err != nil {
 		return fmt.Errorf("authentication required: %w", err)
 	}
 
 	fmt.Printf("Fetching transactions for policy %s...\n\n", policyID)
 
-	url := fmt.Sprintf("%s/plugin/policies/%s/history?take=%d", cfg.Verifier, policyID, limit)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	req.Header.Set("Authorization", authHeader)
-
-	resp, err := http.DefaultClient.Do(req)
+	client, err := newVerifierClient(vault)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
+	}
+	history, err := client.PolicyHistory(context.Background(), policyID, verifier.Page{Take: limit})
+	if err != nil {
+		return fmt.Errorf("fetch history: %w", err)
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
+	if history.History == nil {
+		history.History = []verifier.Transaction{}
+	}
 
-	if data, ok := result["data"].([]interface{}); ok {
-		if len(data) == 0 {
+	return printResult(history, func() {
+		if len(history.History) == 0 {
 			fmt.Println("No transactions found for this policy.")
 			fmt.Println("\nThe plugin may not have executed any transactions yet.")
-			return nil
+			return
 		}
 
-		fmt.Printf("Found %d transactions:\n\n", len(data))
-		for i, tx := range data {
-			txMap := tx.(map[string]interface{})
+		fmt.Printf("Found %d transactions (%d total):\n\n", len(history.History), history.TotalCount)
+		for i, tx := range history.History {
 			fmt.Printf("%d. Transaction:\n", i+1)
-			fmt.Printf("   ID: %v\n", txMap["id"])
-			fmt.Printf("   Status: %v\n", txMap["status"])
-			fmt.Printf("   Chain: %v\n", txMap["chain"])
-			fmt.Printf("   TxHash: %v\n", txMap["tx_hash"])
-			fmt.Printf("   Created: %v\n", txMap["created_at"])
+			fmt.Printf("   ID: %s\n", tx.ID)
+			fmt.Printf("   Status: %s\n", tx.Status)
+			fmt.Printf("   Chain: %s\n", tx.Chain)
+			fmt.Printf("   TxHash: %s\n", derefOr(tx.TxHash, "(none)"))
+			fmt.Printf("   Created: %s\n", tx.CreatedAt.Format(time.RFC3339))
 			fmt.Println()
 		}
-	} else {
-		prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(prettyJSON))
-	}
-
-	return nil
+	})
 }
 
 func runVerifyPluginTransactions(pluginID string, limit int) error {
-	cfg, err := LoadConfig()
+	vault, err := ActiveVault()
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
-	authHeader, err := GetAuthHeader()
+	_, err = ensureAuthHeader(vault)
 	if err != nil {
 		return fmt.Errorf("authentication required: %w", err)
 	}
 
 	fmt.Printf("Fetching transactions for plugin %s...\n\n", pluginID)
 
-	url := fmt.Sprintf("%s/plugin/transactions?plugin_id=%s&take=%d", cfg.Verifier, pluginID, limit)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	req.Header.Set("Authorization", authHeader)
-
-	resp, err := http.DefaultClient.Do(req)
+	client, err := newVerifierClient(vault)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
+	}
+	history, err := client.PluginTransactions(context.Background(), pluginID, verifier.Page{Take: limit})
+	if err != nil {
+		return fmt.Errorf("fetch transactions: %w", err)
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
 
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
+	if history.History == nil {
+		history.History = []verifier.Transaction{}
+	}
 
-	return nil
+	return printResult(history, func() { printJSON(history) })
 }
 
 func runVerifyPolicy(policyID string) error {
-	cfg, err := LoadConfig()
+	vault, err := ActiveVault()
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
-	authHeader, err := GetAuthHeader()
+	_, err = ensureAuthHeader(vault)
 	if err != nil {
 		return fmt.Errorf("authentication required: %w", err)
 	}
 
 	fmt.Printf("Verifying policy %s...\n\n", policyID)
 
-	url := fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	req.Header.Set("Authorization", authHeader)
-
-	resp, err := http.DefaultClient.Do(req)
+	client, err := newVerifierClient(vault)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("policy not found: %s", string(body))
+	policy, err := client.GetPolicy(context.Background(), policyID)
+	if err != nil {
+		return fmt.Errorf("policy not found: %w", err)
 	}
 
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
-
-	if data, ok := result["data"].(map[string]interface{}); ok {
+	return printResult(policy, func() {
 		fmt.Println("Policy Status:")
-		fmt.Printf("  ID: %v\n", data["id"])
-		fmt.Printf("  Plugin: %v\n", data["plugin_id"])
-		fmt.Printf("  Active: %v\n", data["active"])
-		fmt.Printf("  Version: %v\n", data["policy_version"])
-		fmt.Printf("  Public Key: %v\n", data["public_key"])
-		fmt.Printf("  Created: %v\n", data["created_at"])
-
-		if billing, ok := data["billing"].([]interface{}); ok && len(billing) > 0 {
+		fmt.Printf("  ID: %s\n", policy.ID)
+		fmt.Printf("  Plugin: %s\n", policy.PluginID)
+		fmt.Printf("  Active: %v\n", policy.Active)
+		fmt.Printf("  Version: %d\n", policy.PolicyVersion)
+		fmt.Printf("  Public Key: %s\n", policy.PublicKey)
+
+		if len(policy.Billing) > 0 {
 			fmt.Println("\nBilling:")
-			for _, b := range billing {
-				bMap := b.(map[string]interface{})
-				fmt.Printf("  - Type: %v, Amount: %v\n", bMap["type"], bMap["amount"])
+			for _, b := range policy.Billing {
+				fmt.Printf("  - Type: %s, Amount: %d\n", b.Type, b.Amount)
 			}
 		}
-	} else {
-		prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(prettyJSON))
-	}
+	})
+}
 
-	return nil
+// derefOr returns *s, or fallback when s is nil or empty.
+func derefOr(s *string, fallback string) string {
+	if s == nil || *s == "" {
+		return fallback
+	}
+	return *s
 }
 
 func runVerifyHealth() error {
@@ -246,6 +215,7 @@ func runVerifyHealth() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	result := HealthResult{Services: []ServiceStatus{}}
 	for _, svc := range services {
 		req, _ := http.NewRequestWithContext(ctx, "GET", svc.url, nil)
 		resp, err := http.DefaultClient.Do(req)
@@ -258,15 +228,19 @@ func runVerifyHealth() error {
 			resp.Body.Close()
 		}
 
-		if status == "OK" {
-			fmt.Printf("  ✓ %s: %s\n", svc.name, status)
-		} else {
-			fmt.Printf("  ✗ %s: %s\n", svc.name, status)
-		}
+		result.Services = append(result.Services, ServiceStatus{Name: svc.name, URL: svc.url, Status: status})
 	}
 
-	fmt.Println("\nDocker Services:")
-	fmt.Println("  Run 'docker compose ps' in devenv/ to check infrastructure")
+	return printResult(result, func() {
+		for _, svc := range result.Services {
+			fmt.Printf("  %s %s: %s\n", statusIcon(svc.Status == "OK"), svc.Name, svc.Status)
+		}
+
+		fmt.Println("\nDocker Services:")
+		fmt.Println("  Run 'docker compose ps' in devenv/ to check infrastructure")
+	})
+}
 
-	return nil
+type HealthResult struct {
+	Services []ServiceStatus `json:"services"`
 }
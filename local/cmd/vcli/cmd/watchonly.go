@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vultisig/vcli/local/pkg/devdb"
+)
+
+// requireKeyshares rejects vaults that cannot take part in TSS signing or
+// resharing, i.e. watch-only vaults imported from public keys.
+func requireKeyshares(v *LocalVault) error {
+	if v.WatchOnly || len(v.KeyShares) == 0 {
+		return fmt.Errorf("vault '%s' is watch-only (no keyshare): signing and resharing require a full .vult import (vcli vault import --file <vault.vult>)", v.Name)
+	}
+	return nil
+}
+
+// runVaultImportWatchOnly saves a watch-only vault next to the existing ones
+// and makes it the active vault. Unlike a full import it never clears the
+// vault directory.
+func runVaultImportWatchOnly(name, ecdsaKey, eddsaKey, chainCode, policyID string, replace bool) error {
+	if policyID != "" {
+		if ecdsaKey != "" {
+			return fmt.Errorf("--policy and --ecdsa are mutually exclusive")
+		}
+		fmt.Printf("Looking up public key for policy %s...\n", policyID)
+		pubKey, err := lookupPolicyPublicKey(policyID)
+		if err != nil {
+			return err
+		}
+		ecdsaKey = pubKey
+	}
+
+	ecdsaKey = strings.TrimPrefix(strings.TrimSpace(ecdsaKey), "0x")
+	eddsaKey = strings.TrimPrefix(strings.TrimSpace(eddsaKey), "0x")
+	chainCode = strings.TrimPrefix(strings.TrimSpace(chainCode), "0x")
+
+	if ecdsaKey == "" {
+		return fmt.Errorf("--ecdsa (or --policy) is required for a watch-only import")
+	}
+	if chainCode == "" {
+		return fmt.Errorf("--chain-code is required for a watch-only import (needed to derive addresses)")
+	}
+
+	err := validateHexKey("ECDSA public key", ecdsaKey, 33)
+	if err != nil {
+		return err
+	}
+	if eddsaKey != "" {
+		err = validateHexKey("EdDSA public key", eddsaKey, 32)
+		if err != nil {
+			return err
+		}
+	}
+	err = validateHexKey("chain code", chainCode, 32)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		name = "watch-" + ecdsaKey[:8]
+	}
+
+	localVault := LocalVault{
+		Name:           name,
+		PublicKeyECDSA: ecdsaKey,
+		PublicKeyEdDSA: eddsaKey,
+		HexChainCode:   chainCode,
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+		LibType:        1,
+		WatchOnly:      true,
+	}
+
+	// Vault files are keyed by public key: the only vault this import can
+	// overwrite is one with the same key, which may hold a keyshare
+	existingVaults, _ := ListVaults()
+	for _, v := range existingVaults {
+		if v.PublicKeyECDSA != ecdsaKey {
+			continue
+		}
+		if !v.WatchOnly && len(v.KeyShares) > 0 && !replace {
+			return fmt.Errorf("vault '%s' with this public key has a keyshare; a watch-only import would replace it (use --replace to do so)", v.Name)
+		}
+		fmt.Printf("Replacing vault '%s'...\n", v.Name)
+	}
+
+	err = SaveVault(&localVault)
+	if err != nil {
+		return fmt.Errorf("save vault: %w", err)
+	}
+
+	cfg, _ := LoadConfig()
+	cfg.VaultName = localVault.Name
+	cfg.PublicKeyECDSA = localVault.PublicKeyECDSA
+	cfg.PublicKeyEdDSA = localVault.PublicKeyEdDSA
+	err = SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println("=== Watch-Only Vault Imported ===")
+	fmt.Printf("Name: %s\n", localVault.Name)
+	fmt.Printf("Public Key (ECDSA): %s\n", localVault.PublicKeyECDSA)
+	if localVault.PublicKeyEdDSA != "" {
+		fmt.Printf("Public Key (EdDSA): %s\n", localVault.PublicKeyEdDSA)
+	} else {
+		fmt.Println("Public Key (EdDSA): (none - EdDSA chains unavailable)")
+	}
+	fmt.Printf("Chain Code: %s\n", localVault.HexChainCode)
+	fmt.Printf("Saved to: %s\n", VaultStoragePath())
+	fmt.Println()
+	fmt.Println("Now the active vault; switch back with 'vcli vault use <pubkey-prefix>'.")
+	fmt.Println("This vault is read-only. Addresses, balances and 'policy generate' work;")
+	fmt.Println("signing commands (policy add/delete, plugin install, auth login) are refused.")
+
+	return nil
+}
+
+func validateHexKey(label, value string, size int) error {
+	raw, err := hex.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: not hex: %w", label, err)
+	}
+	if len(raw) != size {
+		return fmt.Errorf("invalid %s: expected %d bytes, got %d", label, size, len(raw))
+	}
+	return nil
+}
+
+// lookupPolicyPublicKey reads the vault public key of a policy from the local
+// verifier database.
+func lookupPolicyPublicKey(policyID string) (string, error) {
+	_, err := uuid.Parse(policyID)
+	if err != nil {
+		return "", fmt.Errorf("invalid policy ID: %s", policyID)
+	}
+
+	var pubKey string
+	err = withDB(openVerifierDB, func(ctx context.Context, db *devdb.DB) error {
+		p, err := db.Policy(ctx, policyID)
+		if errors.Is(err, devdb.ErrNotFound) {
+			return fmt.Errorf("policy %s not found in plugin_policies", policyID)
+		}
+		if err != nil {
+			return err
+		}
+		pubKey = p.PublicKey
+		return nil
+	})
+	return pubKey, err
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -30,7 +31,9 @@ PLUGIN ALIASES (use short names instead of full IDs):
   fee    -> vultisig-fees-feee       (Fee collection)
   sends  -> vultisig-recurring-sends-0000 (Recurring Sends)
 
-  Run 'vcli plugin aliases' for full list.
+  Run 'vcli plugin aliases' for full list. Plugins registered with the
+  verifier are picked up automatically; add your own aliases with
+  'vcli plugin aliases set <alias> <plugin-id>'.
 
 ENVIRONMENT VARIABLES:
   VAULT_PASSWORD  - Default password for all TSS operations
@@ -50,13 +53,16 @@ FLAG CONVENTIONS:
   --password  = Vault/Fast Vault password (all commands)
   --plugin    = Plugin ID or alias
   -c, --policy-file    = Config file path
+  --log-level / --log-format / --log-file = Logging (secrets are always redacted)
+  -o, --output json|yaml|table = Result format (progress goes to stderr)
 
 Commands:
   start    - Start all local development services
   stop     - Stop all local development services
   vault    - Import, list, and manage vaults
-  plugin   - List, install, and manage plugins (use 'plugin aliases' for short names)
+  plugin   - List, install, register, and manage plugins (use 'plugin aliases' for short names)
   policy   - Create and manage policies
+  scheduler - Time-travel recurring policies (advance, run-next)
   auth     - Authenticate with verifier using TSS keysign
   verify   - Check transaction history and service health
   report   - Show comprehensive validation report
@@ -64,11 +70,15 @@ Commands:
 `,
 	}
 
+	cmd.AddLoggingFlags(rootCmd)
+	cmd.AddOutputFlags(rootCmd)
+
 	rootCmd.AddCommand(cmd.NewStartCmd())
 	rootCmd.AddCommand(cmd.NewStopCmd())
 	rootCmd.AddCommand(cmd.NewVaultCmd())
 	rootCmd.AddCommand(cmd.NewPluginCmd())
 	rootCmd.AddCommand(cmd.NewPolicyCmd())
+	rootCmd.AddCommand(cmd.NewSchedulerCmd())
 	rootCmd.AddCommand(cmd.NewServicesCmd())
 	rootCmd.AddCommand(cmd.NewStatusCmd())
 	rootCmd.AddCommand(cmd.NewAuthCmd())
@@ -76,8 +86,16 @@ Commands:
 	rootCmd.AddCommand(cmd.NewReportCmd())
 	rootCmd.AddCommand(cmd.NewDevTokenCmd())
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if closeErr := cmd.CloseLogging(); closeErr != nil {
+		fmt.Fprintln(os.Stderr, closeErr)
+	}
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
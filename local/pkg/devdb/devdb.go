@@ -0,0 +1,502 @@
+// Package devdb reads and updates the Postgres databases of a local Vultisig
+// environment: the verifier database (plugins, policies, plugin
+// installations, vault tokens, signed transactions) and a plugin database
+// (scheduler and the plugin's own tx_indexer).
+//
+// It connects with a DSN, so it works the same against the docker-compose
+// Postgres, a native install or a Kubernetes port-forward. All queries are
+// parameterized; lookups of a single row return ErrNotFound when there is
+// none.
+package devdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrNotFound is returned when a looked-up row does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrInUse is returned when a row cannot be deleted because other rows
+// reference it.
+var ErrInUse = errors.New("still referenced")
+
+type DB struct {
+	db *sql.DB
+}
+
+// Open returns a handle for dsn. Like sql.Open it does not connect; the
+// first query does.
+func Open(dsn string) (*DB, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	return &DB{db: db}, nil
+}
+
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+func (d *DB) Ping(ctx context.Context) error {
+	return d.db.PingContext(ctx)
+}
+
+// Policy is a row of the verifier's plugin_policies table.
+type Policy struct {
+	ID        string
+	PluginID  string
+	PublicKey string
+	Active    bool
+	CreatedAt time.Time
+}
+
+// Policy looks up a policy in the verifier database.
+func (d *DB) Policy(ctx context.Context, policyID string) (*Policy, error) {
+	var p Policy
+	err := d.db.QueryRowContext(ctx,
+		`SELECT id, plugin_id, public_key, active, created_at
+		FROM plugin_policies
+		WHERE id = $1`, policyID).
+		Scan(&p.ID, &p.PluginID, &p.PublicKey, &p.Active, &p.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query plugin_policies: %w", err)
+	}
+	return &p, nil
+}
+
+// PolicyIDs returns the IDs of a plugin's active policies, oldest first.
+func (d *DB) PolicyIDs(ctx context.Context, pluginID string) ([]string, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id FROM plugin_policies
+		WHERE plugin_id = $1 AND active = true
+		ORDER BY created_at`, pluginID)
+	if err != nil {
+		return nil, fmt.Errorf("query plugin_policies: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan plugin_policies: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Installation is a row of the verifier's plugin_installations table.
+type Installation struct {
+	PluginID    string
+	PublicKey   string
+	InstalledAt time.Time
+}
+
+// Installation looks up the installation of a plugin for a vault.
+func (d *DB) Installation(ctx context.Context, pluginID, publicKey string) (*Installation, error) {
+	var inst Installation
+	err := d.db.QueryRowContext(ctx,
+		`SELECT plugin_id, public_key, installed_at
+		FROM plugin_installations
+		WHERE plugin_id = $1 AND public_key = $2
+		LIMIT 1`, pluginID, publicKey).
+		Scan(&inst.PluginID, &inst.PublicKey, &inst.InstalledAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query plugin_installations: %w", err)
+	}
+	return &inst, nil
+}
+
+// RecentInstallations returns the latest plugin installations, newest first.
+func (d *DB) RecentInstallations(ctx context.Context, limit int) ([]Installation, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT plugin_id, public_key, installed_at
+		FROM plugin_installations
+		ORDER BY installed_at DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query plugin_installations: %w", err)
+	}
+	defer rows.Close()
+
+	var installs []Installation
+	for rows.Next() {
+		var inst Installation
+		err = rows.Scan(&inst.PluginID, &inst.PublicKey, &inst.InstalledAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan plugin_installations: %w", err)
+		}
+		installs = append(installs, inst)
+	}
+	return installs, rows.Err()
+}
+
+// DeleteInstallation removes the installation record of a plugin for a vault
+// and returns the number of rows deleted.
+func (d *DB) DeleteInstallation(ctx context.Context, pluginID, publicKey string) (int64, error) {
+	res, err := d.db.ExecContext(ctx,
+		`DELETE FROM plugin_installations WHERE plugin_id = $1 AND public_key = $2`,
+		pluginID, publicKey)
+	if err != nil {
+		return 0, fmt.Errorf("delete plugin_installations: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// ActiveVaultTokens counts unrevoked, unexpired verifier auth tokens.
+func (d *DB) ActiveVaultTokens(ctx context.Context) (int, error) {
+	var n int
+	err := d.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM vault_tokens WHERE revoked_at IS NULL AND expires_at > NOW()`).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("query vault_tokens: %w", err)
+	}
+	return n, nil
+}
+
+// Plugin is a row of the verifier's plugins table with its pricings.
+type Plugin struct {
+	ID             string
+	Title          string
+	Description    string
+	ServerEndpoint string
+	Category       string
+	LogoURL        string
+	ThumbnailURL   string
+	Features       []string
+	FAQs           []FAQ
+	Audited        bool
+	Pricing        []Pricing
+}
+
+// FAQ is an entry of the plugins.faqs JSON column.
+type FAQ struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// Pricing is a row of the pricings table. Frequency is empty for the "once"
+// and "per-tx" types, which store NULL.
+type Pricing struct {
+	Type      string
+	Frequency string
+	Amount    uint64
+	Asset     string
+	Metric    string
+}
+
+// Plugin looks up a plugin and its pricings in the verifier database.
+func (d *DB) Plugin(ctx context.Context, pluginID string) (*Plugin, error) {
+	var (
+		p                        Plugin
+		description, logo, thumb sql.NullString
+		features, faqs           []byte
+	)
+	err := d.db.QueryRowContext(ctx,
+		`SELECT id, title, description, server_endpoint, category, logo_url, thumbnail_url,
+			features, faqs, audited
+		FROM plugins
+		WHERE id = $1`, pluginID).
+		Scan(&p.ID, &p.Title, &description, &p.ServerEndpoint, &p.Category, &logo, &thumb,
+			&features, &faqs, &p.Audited)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query plugins: %w", err)
+	}
+	p.Description, p.LogoURL, p.ThumbnailURL = description.String, logo.String, thumb.String
+	if len(features) > 0 {
+		err = json.Unmarshal(features, &p.Features)
+		if err != nil {
+			return nil, fmt.Errorf("decode plugins.features: %w", err)
+		}
+	}
+	if len(faqs) > 0 {
+		err = json.Unmarshal(faqs, &p.FAQs)
+		if err != nil {
+			return nil, fmt.Errorf("decode plugins.faqs: %w", err)
+		}
+	}
+
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT type, COALESCE(frequency::text, ''), amount, asset, metric
+		FROM pricings
+		WHERE plugin_id = $1
+		ORDER BY type, frequency`, pluginID)
+	if err != nil {
+		return nil, fmt.Errorf("query pricings: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var pr Pricing
+		if err = rows.Scan(&pr.Type, &pr.Frequency, &pr.Amount, &pr.Asset, &pr.Metric); err != nil {
+			return nil, fmt.Errorf("scan pricings: %w", err)
+		}
+		p.Pricing = append(p.Pricing, pr)
+	}
+	return &p, rows.Err()
+}
+
+// UpsertPlugin inserts or updates a plugin row in one transaction. A nil
+// p.Pricing leaves the plugin's pricings alone; otherwise they are replaced
+// (pricings has no unique key to upsert on). A non-empty apiKey is added to
+// plugin_apikey if it is not there yet.
+func (d *DB) UpsertPlugin(ctx context.Context, p Plugin, apiKey string) error {
+	features, err := json.Marshal(NonNil(p.Features))
+	if err != nil {
+		return fmt.Errorf("encode features: %w", err)
+	}
+	faqs, err := json.Marshal(NonNil(p.FAQs))
+	if err != nil {
+		return fmt.Errorf("encode faqs: %w", err)
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO plugins (id, title, description, server_endpoint, category, logo_url, thumbnail_url,
+			images, features, faqs, audited, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, '[]', $8, $9, $10, NOW(), NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			title = EXCLUDED.title,
+			description = EXCLUDED.description,
+			server_endpoint = EXCLUDED.server_endpoint,
+			category = EXCLUDED.category,
+			logo_url = EXCLUDED.logo_url,
+			thumbnail_url = EXCLUDED.thumbnail_url,
+			features = EXCLUDED.features,
+			faqs = EXCLUDED.faqs,
+			audited = EXCLUDED.audited,
+			updated_at = NOW()`,
+		p.ID, p.Title, p.Description, p.ServerEndpoint, p.Category, p.LogoURL, p.ThumbnailURL,
+		string(features), string(faqs), p.Audited)
+	if err != nil {
+		return fmt.Errorf("upsert plugins: %w", err)
+	}
+
+	if p.Pricing != nil {
+		_, err = tx.ExecContext(ctx, `DELETE FROM pricings WHERE plugin_id = $1`, p.ID)
+		if err != nil {
+			return fmt.Errorf("delete pricings: %w", err)
+		}
+		for _, pr := range p.Pricing {
+			_, err = tx.ExecContext(ctx,
+				`INSERT INTO pricings (type, frequency, amount, asset, metric, plugin_id, created_at, updated_at)
+				VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, NOW(), NOW())`,
+				pr.Type, pr.Frequency, pr.Amount, pr.Asset, pr.Metric, p.ID)
+			if err != nil {
+				return fmt.Errorf("insert pricings: %w", err)
+			}
+		}
+	}
+
+	if apiKey != "" {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO plugin_apikey (plugin_id, apikey, status) VALUES ($1, $2, 1)
+			ON CONFLICT (apikey) DO NOTHING`, p.ID, apiKey)
+		if err != nil {
+			return fmt.Errorf("insert plugin_apikey: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// DeletePlugin removes a plugin with its pricings and API keys and returns
+// the number of plugins rows deleted. It returns ErrInUse while policies or
+// installations still reference the plugin.
+func (d *DB) DeletePlugin(ctx context.Context, pluginID string) (int64, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"pricings", "plugin_apikey"} {
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE plugin_id = $1`, pluginID)
+		if err != nil {
+			return 0, fmt.Errorf("delete %s: %w", table, err)
+		}
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM plugins WHERE id = $1`, pluginID)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return 0, fmt.Errorf("delete plugins: %w: %s", ErrInUse, pqErr.Detail)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("delete plugins: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("commit: %w", err)
+	}
+	return n, nil
+}
+
+// NonNil keeps empty JSON columns and documents as [] rather than null.
+func NonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// Tx is a row of a tx_indexer table. TxHash and OnChainStatus are empty
+// until the transaction is broadcast. ChainID is a vultisig-go common.Chain
+// and ProposedTx the base64 unsigned payload the plugin proposed.
+type Tx struct {
+	ID            string
+	TxHash        string
+	Status        string
+	OnChainStatus string
+	ChainID       int
+	ProposedTx    string
+	CreatedAt     time.Time
+}
+
+// RecentTxs returns the latest transactions of a policy, newest first.
+func (d *DB) RecentTxs(ctx context.Context, policyID string, limit int) ([]Tx, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, tx_hash, status, status_onchain, chain_id, proposed_tx_hex, created_at
+		FROM tx_indexer
+		WHERE policy_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, policyID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query tx_indexer: %w", err)
+	}
+	defer rows.Close()
+
+	var txs []Tx
+	for rows.Next() {
+		var tx Tx
+		var hash, onChain sql.NullString
+		err = rows.Scan(&tx.ID, &hash, &tx.Status, &onChain, &tx.ChainID, &tx.ProposedTx, &tx.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan tx_indexer: %w", err)
+		}
+		tx.TxHash = hash.String
+		tx.OnChainStatus = onChain.String
+		txs = append(txs, tx)
+	}
+	return txs, rows.Err()
+}
+
+// CountTxsSince counts a policy's transactions created within the last
+// window, the count the verifier checks a policy's rate limit against.
+func (d *DB) CountTxsSince(ctx context.Context, policyID string, window time.Duration) (int, error) {
+	var n int
+	err := d.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM tx_indexer
+		WHERE policy_id = $1 AND created_at >= NOW() - make_interval(secs => $2)`,
+		policyID, window.Seconds()).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("query tx_indexer: %w", err)
+	}
+	return n, nil
+}
+
+// NextExecution returns when the plugin scheduler next runs a policy.
+func (d *DB) NextExecution(ctx context.Context, policyID string) (time.Time, error) {
+	var next sql.NullTime
+	err := d.db.QueryRowContext(ctx,
+		`SELECT next_execution FROM scheduler WHERE policy_id = $1 LIMIT 1`, policyID).Scan(&next)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !next.Valid) {
+		return time.Time{}, ErrNotFound
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("query scheduler: %w", err)
+	}
+	return next.Time, nil
+}
+
+// TriggerNow sets a policy's next execution to now and returns the number of
+// scheduler rows updated (0 if the policy is not scheduled).
+func (d *DB) TriggerNow(ctx context.Context, policyID string) (int64, error) {
+	res, err := d.db.ExecContext(ctx,
+		`UPDATE scheduler SET next_execution = NOW() WHERE policy_id = $1`, policyID)
+	if err != nil {
+		return 0, fmt.Errorf("update scheduler: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// ScheduledPolicy is a row of a plugin's scheduler table.
+type ScheduledPolicy struct {
+	PolicyID      string
+	NextExecution time.Time
+}
+
+// Scheduled returns the scheduler entries of the given policies, or of every
+// scheduled policy when policyIDs is empty, soonest first.
+func (d *DB) Scheduled(ctx context.Context, policyIDs []string) ([]ScheduledPolicy, error) {
+	query := `SELECT policy_id, next_execution FROM scheduler
+		WHERE next_execution IS NOT NULL
+		ORDER BY next_execution`
+	args := []any{}
+	if len(policyIDs) > 0 {
+		query = `SELECT policy_id, next_execution FROM scheduler
+		WHERE next_execution IS NOT NULL AND policy_id = ANY($1)
+		ORDER BY next_execution`
+		args = append(args, pq.Array(policyIDs))
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query scheduler: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []ScheduledPolicy
+	for rows.Next() {
+		var e ScheduledPolicy
+		if err = rows.Scan(&e.PolicyID, &e.NextExecution); err != nil {
+			return nil, fmt.Errorf("scan scheduler: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// ShiftNextExecution moves the next execution of the given policies earlier
+// by d, as if that much time had passed, and returns the number of scheduler
+// rows updated.
+func (d *DB) ShiftNextExecution(ctx context.Context, policyIDs []string, by time.Duration) (int64, error) {
+	res, err := d.db.ExecContext(ctx,
+		`UPDATE scheduler SET next_execution = next_execution - make_interval(secs => $1)
+		WHERE policy_id = ANY($2) AND next_execution IS NOT NULL`,
+		by.Seconds(), pq.Array(policyIDs))
+	if err != nil {
+		return 0, fmt.Errorf("update scheduler: %w", err)
+	}
+	return res.RowsAffected()
+}
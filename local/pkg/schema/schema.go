@@ -0,0 +1,361 @@
+// Package schema validates JSON documents against the subset of JSON Schema
+// that plugin recipe specifications use: type, properties, required,
+// additionalProperties, enum, const, string/number/array bounds, pattern,
+// allOf/anyOf/oneOf and local $ref.
+//
+// Unlike a fail-fast validator it reports every violation, each with the
+// JSON path of the offending value, so a policy file can be fixed in one go.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Violation is a single validation failure.
+type Violation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// Validate checks doc against schema and returns all violations. root is the
+// path prefix of doc (e.g. "$.recipe"). Both values must be in the form
+// produced by encoding/json (map[string]any, []any, float64, ...).
+func Validate(schema map[string]any, doc any, root string) []Violation {
+	v := &validator{root: schema}
+	v.validate(schema, doc, root)
+	return v.violations
+}
+
+type validator struct {
+	root       map[string]any
+	violations []Violation
+}
+
+func (v *validator) add(path, format string, args ...any) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate(s map[string]any, doc any, path string) {
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			v.add(path, "schema: %v", err)
+			return
+		}
+		s = target
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, doc) {
+		v.add(path, "expected %s, got %s", typeNames(t), jsonType(doc))
+		return
+	}
+
+	if enum, ok := s["enum"].([]any); ok && !containsValue(enum, doc) {
+		v.add(path, "must be one of %s", formatValues(enum))
+	}
+	if c, ok := s["const"]; ok && !equal(c, doc) {
+		v.add(path, "must be %s", formatValue(c))
+	}
+
+	switch d := doc.(type) {
+	case map[string]any:
+		v.validateObject(s, d, path)
+	case []any:
+		v.validateArray(s, d, path)
+	case string:
+		v.validateString(s, d, path)
+	case float64:
+		v.validateNumber(s, d, path)
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			if m, ok := sub.(map[string]any); ok {
+				v.validate(m, doc, path)
+			}
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]any); ok && v.countMatches(anyOf, doc, path) == 0 {
+		v.add(path, "does not match any of the allowed schemas")
+	}
+	if oneOf, ok := s["oneOf"].([]any); ok {
+		n := v.countMatches(oneOf, doc, path)
+		if n != 1 {
+			v.add(path, "must match exactly one of the allowed schemas (matched %d)", n)
+		}
+	}
+}
+
+func (v *validator) validateObject(s map[string]any, d map[string]any, path string) {
+	props, _ := s["properties"].(map[string]any)
+
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := d[name]; !present {
+				v.add(Child(path, name), "is required")
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(d) {
+		value := d[name]
+		if ps, ok := props[name].(map[string]any); ok {
+			v.validate(ps, value, Child(path, name))
+			continue
+		}
+		switch ap := s["additionalProperties"].(type) {
+		case bool:
+			if !ap {
+				v.add(Child(path, name), "is not allowed")
+			}
+		case map[string]any:
+			v.validate(ap, value, Child(path, name))
+		}
+	}
+}
+
+func (v *validator) validateArray(s map[string]any, d []any, path string) {
+	if n, ok := number(s["minItems"]); ok && float64(len(d)) < n {
+		v.add(path, "must have at least %v items", n)
+	}
+	if n, ok := number(s["maxItems"]); ok && float64(len(d)) > n {
+		v.add(path, "must have at most %v items", n)
+	}
+	if items, ok := s["items"].(map[string]any); ok {
+		for i, item := range d {
+			v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func (v *validator) validateString(s map[string]any, d string, path string) {
+	length := float64(len([]rune(d)))
+	if n, ok := number(s["minLength"]); ok && length < n {
+		if n == 1 {
+			v.add(path, "must not be empty")
+		} else {
+			v.add(path, "must be at least %v characters", n)
+		}
+	}
+	if n, ok := number(s["maxLength"]); ok && length > n {
+		v.add(path, "must be at most %v characters", n)
+	}
+	if p, ok := s["pattern"].(string); ok {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			v.add(path, "schema: invalid pattern %q", p)
+		} else if !re.MatchString(d) {
+			v.add(path, "%q does not match pattern %s", d, p)
+		}
+	}
+}
+
+func (v *validator) validateNumber(s map[string]any, d float64, path string) {
+	if n, ok := number(s["minimum"]); ok && d < n {
+		v.add(path, "must be >= %v", n)
+	}
+	if n, ok := number(s["maximum"]); ok && d > n {
+		v.add(path, "must be <= %v", n)
+	}
+	if n, ok := number(s["exclusiveMinimum"]); ok && d <= n {
+		v.add(path, "must be > %v", n)
+	}
+	if n, ok := number(s["exclusiveMaximum"]); ok && d >= n {
+		v.add(path, "must be < %v", n)
+	}
+	if n, ok := number(s["multipleOf"]); ok && n != 0 && math.Mod(d, n) != 0 {
+		v.add(path, "must be a multiple of %v", n)
+	}
+}
+
+// countMatches returns how many of the schemas doc satisfies, without
+// recording their violations.
+func (v *validator) countMatches(schemas []any, doc any, path string) int {
+	n := 0
+	for _, sub := range schemas {
+		m, ok := sub.(map[string]any)
+		if !ok {
+			continue
+		}
+		probe := &validator{root: v.root}
+		probe.validate(m, doc, path)
+		if len(probe.violations) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Deref returns s, or the schema its local $ref points to within root.
+func Deref(root, s map[string]any) (map[string]any, error) {
+	ref, ok := s["$ref"].(string)
+	if !ok {
+		return s, nil
+	}
+	v := &validator{root: root}
+	return v.resolve(ref)
+}
+
+// resolve follows a local reference such as "#/definitions/asset".
+func (v *validator) resolve(ref string) (map[string]any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported $ref %q (only local references)", ref)
+	}
+	var node any = v.root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		node = m[part]
+	}
+	m, ok := node.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unresolvable $ref %q", ref)
+	}
+	return m, nil
+}
+
+func matchesType(t any, doc any) bool {
+	switch tt := t.(type) {
+	case string:
+		return isType(tt, doc)
+	case []any:
+		for _, x := range tt {
+			if name, ok := x.(string); ok && isType(name, doc) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func isType(name string, doc any) bool {
+	switch name {
+	case "object":
+		_, ok := doc.(map[string]any)
+		return ok
+	case "array":
+		_, ok := doc.([]any)
+		return ok
+	case "string":
+		_, ok := doc.(string)
+		return ok
+	case "number":
+		_, ok := doc.(float64)
+		return ok
+	case "integer":
+		f, ok := doc.(float64)
+		return ok && f == math.Trunc(f)
+	case "boolean":
+		_, ok := doc.(bool)
+		return ok
+	case "null":
+		return doc == nil
+	}
+	return true
+}
+
+func jsonType(doc any) string {
+	switch d := doc.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if d == math.Trunc(d) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", doc)
+}
+
+func typeNames(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, 0, len(list))
+		for _, x := range list {
+			names = append(names, fmt.Sprint(x))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func number(x any) (float64, bool) {
+	f, ok := x.(float64)
+	return f, ok
+}
+
+func containsValue(list []any, doc any) bool {
+	for _, x := range list {
+		if equal(x, doc) {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+func formatValue(x any) string {
+	b, err := json.Marshal(x)
+	if err != nil {
+		return fmt.Sprint(x)
+	}
+	return string(b)
+}
+
+func formatValues(list []any) string {
+	parts := make([]string, 0, len(list))
+	for _, x := range list {
+		parts = append(parts, formatValue(x))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Child appends an object key to a JSON path, quoting keys that are not
+// plain identifiers.
+func Child(path, name string) string {
+	if identifier.MatchString(name) {
+		return path + "." + name
+	}
+	return path + "[" + strconv.Quote(name) + "]"
+}
@@ -0,0 +1,186 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// decode parses a JSON literal the way callers pass documents to Validate.
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	err := json.Unmarshal([]byte(s), &v)
+	if err != nil {
+		t.Fatalf("decode %s: %v", s, err)
+	}
+	return v
+}
+
+const recipeSchema = `{
+	"type": "object",
+	"required": ["asset", "amount"],
+	"additionalProperties": false,
+	"properties": {
+		"asset": {"$ref": "#/definitions/asset"},
+		"amount": {"type": "string", "pattern": "^[0-9]+$"},
+		"frequency": {"enum": ["daily", "weekly"]},
+		"recipients": {
+			"type": "array",
+			"minItems": 1,
+			"items": {"$ref": "#/definitions/recipient"}
+		},
+		"route": {
+			"oneOf": [
+				{"type": "object", "required": ["pool"]},
+				{"type": "object", "required": ["router"]}
+			]
+		},
+		"fee-bps": {"type": "integer", "minimum": 0, "maximum": 10000}
+	},
+	"definitions": {
+		"asset": {
+			"type": "object",
+			"required": ["chain"],
+			"properties": {
+				"chain": {"type": "string", "minLength": 1},
+				"token": {"type": "string"}
+			}
+		},
+		"recipient": {
+			"type": "object",
+			"required": ["address"],
+			"properties": {"address": {"type": "string", "minLength": 1}}
+		}
+	}
+}`
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []Violation
+	}{
+		{
+			name: "valid",
+			doc:  `{"asset":{"chain":"Ethereum"},"amount":"100","frequency":"daily","route":{"pool":"x"}}`,
+		},
+		{
+			name: "required",
+			doc:  `{"asset":{}}`,
+			want: []Violation{
+				{"$.recipe.amount", "is required"},
+				{"$.recipe.asset.chain", "is required"},
+			},
+		},
+		{
+			name: "type mismatch",
+			doc:  `{"asset":{"chain":"Ethereum"},"amount":100}`,
+			want: []Violation{{"$.recipe.amount", "expected string, got integer"}},
+		},
+		{
+			name: "integer",
+			doc:  `{"asset":{"chain":"Ethereum"},"amount":"1","fee-bps":1.5}`,
+			want: []Violation{{`$.recipe["fee-bps"]`, "expected integer, got number"}},
+		},
+		{
+			name: "enum",
+			doc:  `{"asset":{"chain":"Ethereum"},"amount":"1","frequency":"hourly"}`,
+			want: []Violation{{"$.recipe.frequency", `must be one of ["daily", "weekly"]`}},
+		},
+		{
+			name: "ref",
+			doc:  `{"asset":{"chain":""},"amount":"1","recipients":[{"address":"a"},{}]}`,
+			want: []Violation{
+				{"$.recipe.asset.chain", "must not be empty"},
+				{"$.recipe.recipients[1].address", "is required"},
+			},
+		},
+		{
+			name: "oneOf none",
+			doc:  `{"asset":{"chain":"Ethereum"},"amount":"1","route":{}}`,
+			want: []Violation{{"$.recipe.route", "must match exactly one of the allowed schemas (matched 0)"}},
+		},
+		{
+			name: "oneOf both",
+			doc:  `{"asset":{"chain":"Ethereum"},"amount":"1","route":{"pool":"x","router":"y"}}`,
+			want: []Violation{{"$.recipe.route", "must match exactly one of the allowed schemas (matched 2)"}},
+		},
+		{
+			name: "additional property",
+			doc:  `{"asset":{"chain":"Ethereum"},"amount":"1","memo":"x"}`,
+			want: []Violation{{"$.recipe.memo", "is not allowed"}},
+		},
+		{
+			name: "pattern and bounds",
+			doc:  `{"asset":{"chain":"Ethereum"},"amount":"1e3","recipients":[],"fee-bps":20000}`,
+			want: []Violation{
+				{"$.recipe.amount", `"1e3" does not match pattern ^[0-9]+$`},
+				{`$.recipe["fee-bps"]`, "must be <= 10000"},
+				{"$.recipe.recipients", "must have at least 1 items"},
+			},
+		},
+		{
+			name: "root type",
+			doc:  `[]`,
+			want: []Violation{{"$.recipe", "expected object, got array"}},
+		},
+	}
+	schema := decode(t, recipeSchema).(map[string]any)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Validate(schema, decode(t, tt.doc), "$.recipe")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateUnresolvableRef(t *testing.T) {
+	schema := decode(t, `{"properties":{"a":{"$ref":"#/definitions/missing"}}}`).(map[string]any)
+
+	got := Validate(schema, decode(t, `{"a":1}`), "$")
+	want := []Violation{{"$.a", `schema: unresolvable $ref "#/definitions/missing"`}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("violations = %v, want %v", got, want)
+	}
+}
+
+func TestDeref(t *testing.T) {
+	root := decode(t, recipeSchema).(map[string]any)
+	props := root["properties"].(map[string]any)
+
+	asset, err := Deref(root, props["asset"].(map[string]any))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset["type"] != "object" {
+		t.Errorf("asset = %v", asset)
+	}
+
+	amount := props["amount"].(map[string]any)
+	got, err := Deref(root, amount)
+	if err != nil || !reflect.DeepEqual(got, amount) {
+		t.Errorf("Deref without $ref = %v, %v", got, err)
+	}
+
+	_, err = Deref(root, map[string]any{"$ref": "https://example.com/schema"})
+	if err == nil {
+		t.Error("expected an error for a remote $ref")
+	}
+}
+
+func TestChild(t *testing.T) {
+	tests := []struct{ path, name, want string }{
+		{"$", "amount", "$.amount"},
+		{"$.recipe", "fee-bps", `$.recipe["fee-bps"]`},
+		{"$", "_id", "$._id"},
+		{"$", "1st", `$["1st"]`},
+	}
+	for _, tt := range tests {
+		if got := Child(tt.path, tt.name); got != tt.want {
+			t.Errorf("Child(%q, %q) = %s, want %s", tt.path, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Authenticate exchanges a signed auth message for a JWT.
+func (c *Client) Authenticate(ctx context.Context, req AuthRequest) (string, error) {
+	var resp authResponse
+	err := c.do(ctx, request{method: http.MethodPost, path: "/auth", body: req}, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.Token, nil
+}
+
+func (c *Client) ListPlugins(ctx context.Context, page Page) (*PluginList, error) {
+	var list PluginList
+	err := c.do(ctx, request{method: http.MethodGet, path: "/plugins", query: page.values()}, &list)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *Client) GetPlugin(ctx context.Context, pluginID string) (*Plugin, error) {
+	var plugin Plugin
+	err := c.do(ctx, request{method: http.MethodGet, path: "/plugins/" + url.PathEscape(pluginID)}, &plugin)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin, nil
+}
+
+func (c *Client) RecipeSpecification(ctx context.Context, pluginID string) (RecipeSpecification, error) {
+	var spec json.RawMessage
+	path := "/plugins/" + url.PathEscape(pluginID) + "/recipe-specification"
+	err := c.do(ctx, request{method: http.MethodGet, path: path}, &spec)
+	if err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// SuggestPolicy asks the plugin (through the verifier) for the rules and
+// rate limits matching a recipe configuration. The result is the protojson
+// encoding of a recipes PolicySuggest.
+func (c *Client) SuggestPolicy(ctx context.Context, pluginID string, configuration map[string]any) (json.RawMessage, error) {
+	var suggest json.RawMessage
+	path := "/plugins/" + url.PathEscape(pluginID) + "/recipe-specification/suggest"
+	body := map[string]any{"configuration": configuration}
+	err := c.do(ctx, request{method: http.MethodPost, path: path, body: body}, &suggest)
+	if err != nil {
+		return nil, err
+	}
+	return suggest, nil
+}
+
+func (c *Client) CreatePolicy(ctx context.Context, policy Policy) (*Policy, error) {
+	var created Policy
+	err := c.do(ctx, request{method: http.MethodPost, path: "/plugin/policy", body: policy, authed: true}, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdatePolicy replaces the policy with policy.ID; the signature must cover
+// the new recipe, public key and versions.
+func (c *Client) UpdatePolicy(ctx context.Context, policy Policy) (*Policy, error) {
+	var updated Policy
+	err := c.do(ctx, request{method: http.MethodPut, path: "/plugin/policy", body: policy, authed: true}, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *Client) GetPolicy(ctx context.Context, policyID string) (*Policy, error) {
+	var policy Policy
+	err := c.do(ctx, request{method: http.MethodGet, path: "/plugin/policy/" + url.PathEscape(policyID), authed: true}, &policy)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// DeletePolicy deletes a policy; signature covers the stored policy's
+// recipe, public key and versions.
+func (c *Client) DeletePolicy(ctx context.Context, policyID, signature string) error {
+	body := map[string]string{"signature": signature}
+	return c.do(ctx, request{method: http.MethodDelete, path: "/plugin/policy/" + url.PathEscape(policyID), body: body, authed: true}, nil)
+}
+
+// ListPolicies lists the authenticated vault's policies for a plugin.
+func (c *Client) ListPolicies(ctx context.Context, pluginID, publicKey string, filter PolicyFilter) (*PolicyList, error) {
+	query := filter.Page.values()
+	if publicKey != "" {
+		query.Set("public_key", publicKey)
+	}
+	if filter.Active != nil {
+		query.Set("active", strconv.FormatBool(*filter.Active))
+	}
+
+	var list PolicyList
+	err := c.do(ctx, request{method: http.MethodGet, path: "/plugin/policies/" + url.PathEscape(pluginID), query: query, authed: true}, &list)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *Client) PolicyHistory(ctx context.Context, policyID string, page Page) (*TransactionHistory, error) {
+	var history TransactionHistory
+	path := "/plugin/policies/" + url.PathEscape(policyID) + "/history"
+	err := c.do(ctx, request{method: http.MethodGet, path: path, query: page.values(), authed: true}, &history)
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// PluginTransactions lists the vault's transactions, optionally for one plugin.
+func (c *Client) PluginTransactions(ctx context.Context, pluginID string, page Page) (*TransactionHistory, error) {
+	query := page.values()
+	if pluginID != "" {
+		query.Set("pluginId", pluginID)
+	}
+
+	var history TransactionHistory
+	err := c.do(ctx, request{method: http.MethodGet, path: "/plugin/transactions", query: query, authed: true}, &history)
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// Reshare asks the verifier to join a reshare session.
+func (c *Client) Reshare(ctx context.Context, req ReshareRequest) error {
+	return c.do(ctx, request{method: http.MethodPost, path: "/vault/reshare", body: req, authed: true}, nil)
+}
+
+func (p Page) values() url.Values {
+	query := url.Values{}
+	if p.Skip > 0 {
+		query.Set("skip", strconv.Itoa(p.Skip))
+	}
+	if p.Take > 0 {
+		query.Set("take", strconv.Itoa(p.Take))
+	}
+	return query
+}
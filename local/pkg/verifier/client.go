@@ -0,0 +1,304 @@
+// Package verifier is a typed client for the Vultisig Verifier HTTP API.
+//
+// Responses are unwrapped from the verifier's {"data": ..., "error": {...}}
+// envelope; non-2xx answers are returned as *APIError. Authenticated endpoints
+// take their Authorization header from an AuthProvider, which is asked to
+// refresh once when the verifier answers 401.
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultTimeout      = 30 * time.Second
+	DefaultRetries      = 2
+	DefaultRetryBackoff = 500 * time.Millisecond
+
+	// ReauthTimeout bounds the refresh and retry that follow a 401.
+	ReauthTimeout = 3 * time.Minute
+)
+
+// AuthProvider supplies the Authorization header for authenticated requests.
+type AuthProvider interface {
+	// AuthHeader returns the current header value, e.g. "Bearer <jwt>".
+	AuthHeader(ctx context.Context) (string, error)
+	// Refresh obtains a new header after the verifier rejected the current one.
+	Refresh(ctx context.Context) (string, error)
+}
+
+// StaticAuth is an AuthProvider with a fixed header that cannot be refreshed.
+// An empty header sends authenticated requests without Authorization.
+type StaticAuth string
+
+func (a StaticAuth) AuthHeader(ctx context.Context) (string, error) {
+	return string(a), nil
+}
+
+func (a StaticAuth) Refresh(ctx context.Context) (string, error) {
+	return "", fmt.Errorf("auth token rejected and cannot be refreshed")
+}
+
+type Client struct {
+	baseURL      string
+	httpClient   *http.Client
+	auth         AuthProvider
+	timeout      time.Duration
+	retries      int
+	retryBackoff time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the underlying HTTP client (default http.DefaultClient).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithAuth sets the provider used for authenticated endpoints.
+func WithAuth(auth AuthProvider) Option {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// WithTimeout bounds each HTTP attempt; zero disables the per-attempt timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithRetries sets how often idempotent (GET) requests are retried after a
+// transport error, 429 or 5xx, and the backoff before the first retry (doubled
+// for each further one).
+func WithRetries(n int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = n
+		c.retryBackoff = backoff
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		httpClient:   http.DefaultClient,
+		timeout:      DefaultTimeout,
+		retries:      DefaultRetries,
+		retryBackoff: DefaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// APIError is a non-2xx verifier response.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Details    string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = strings.TrimSpace(e.Body)
+	}
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Details != "" {
+		msg += ": " + e.Details
+	}
+	return fmt.Sprintf("verifier returned %d: %s", e.StatusCode, msg)
+}
+
+// StatusCode returns the HTTP status of an *APIError in err's chain, or 0.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+type envelope struct {
+	Data  json.RawMessage `json:"data"`
+	Error struct {
+		Message string `json:"message"`
+		Details string `json:"details"`
+	} `json:"error"`
+}
+
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   any
+	authed bool
+}
+
+// do sends r and decodes the envelope's data into out (if non-nil).
+func (c *Client) do(ctx context.Context, r request, out any) error {
+	var payload []byte
+	if r.body != nil {
+		var err error
+		payload, err = json.Marshal(r.body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+	}
+
+	header := ""
+	if r.authed && c.auth != nil {
+		var err error
+		header, err = c.auth.AuthHeader(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	status, body, err := c.send(ctx, r, payload, header)
+	if err == nil && status == http.StatusUnauthorized && r.authed && c.auth != nil {
+		// Re-authentication runs a TSS keysign that can outlast what is left
+		// of the caller's deadline, so it and the retry get a budget of their own.
+		retryCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ReauthTimeout)
+		defer cancel()
+		header, err = c.auth.Refresh(retryCtx)
+		if err != nil {
+			return fmt.Errorf("re-authenticate after 401: %w", err)
+		}
+		status, body, err = c.send(retryCtx, r, payload, header)
+	}
+	if err != nil {
+		return err
+	}
+
+	var env envelope
+	envErr := json.Unmarshal(body, &env)
+
+	if status < 200 || status >= 300 {
+		apiErr := &APIError{StatusCode: status, Body: string(body)}
+		if envErr == nil {
+			apiErr.Message = env.Error.Message
+			apiErr.Details = env.Error.Details
+			if apiErr.Message == "" {
+				// Plugin servers answer {"message": "..."} without the envelope
+				var flat struct {
+					Message string `json:"message"`
+				}
+				if json.Unmarshal(body, &flat) == nil {
+					apiErr.Message = flat.Message
+				}
+			}
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	if envErr != nil {
+		return fmt.Errorf("decode %s %s response: %w", r.method, r.path, envErr)
+	}
+	if len(env.Data) == 0 || string(env.Data) == "null" {
+		return fmt.Errorf("%s %s: response has no data", r.method, r.path)
+	}
+	err = json.Unmarshal(env.Data, out)
+	if err != nil {
+		return fmt.Errorf("decode %s %s response: %w", r.method, r.path, err)
+	}
+	return nil
+}
+
+// send performs one request, retrying idempotent ones on transient failures.
+func (c *Client) send(ctx context.Context, r request, payload []byte, header string) (int, []byte, error) {
+	attempts := 1
+	if r.method == http.MethodGet && c.retries > 0 {
+		attempts += c.retries
+	}
+
+	backoff := c.retryBackoff
+	var status int
+	var body []byte
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return 0, nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		status, body, err = c.attempt(ctx, r, payload, header)
+		if err == nil && status != http.StatusTooManyRequests && status < 500 {
+			return status, body, nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return status, body, nil
+}
+
+func (c *Client) attempt(ctx context.Context, r request, payload []byte, header string) (int, []byte, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	u := c.baseURL + r.path
+	if len(r.query) > 0 {
+		u += "?" + r.query.Encode()
+	}
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, u, reqBody)
+	if err != nil {
+		return 0, nil, fmt.Errorf("create request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s %s: %w", r.method, r.path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("read %s %s response: %w", r.method, r.path, err)
+	}
+	return resp.StatusCode, body, nil
+}
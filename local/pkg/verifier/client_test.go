@@ -0,0 +1,270 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient starts server with handler and returns a client for it with
+// fast retries.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	opts = append([]Option{WithRetries(2, time.Millisecond)}, opts...)
+	return New(server.URL, opts...)
+}
+
+func TestDoDecodesEnvelope(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/plugins/vultisig-dca-0000" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"data":{"id":"vultisig-dca-0000","title":"DCA","audited":true}}`))
+	})
+
+	plugin, err := client.GetPlugin(context.Background(), "vultisig-dca-0000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plugin.ID != "vultisig-dca-0000" || plugin.Title != "DCA" || !plugin.Audited {
+		t.Errorf("plugin = %+v", plugin)
+	}
+}
+
+func TestDoMissingData(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":null}`))
+	})
+
+	_, err := client.GetPlugin(context.Background(), "x")
+	if err == nil {
+		t.Fatal("expected an error for a response without data")
+	}
+}
+
+func TestDoAPIError(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		message string
+		details string
+	}{
+		{"envelope", http.StatusBadRequest, `{"error":{"message":"invalid policy","details":"bad recipe"}}`, "invalid policy", "bad recipe"},
+		{"flat", http.StatusNotFound, `{"message":"policy not found"}`, "policy not found", ""},
+		{"plain", http.StatusForbidden, `forbidden`, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			_, err := client.GetPolicy(context.Background(), "p1")
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Message != tt.message || apiErr.Details != tt.details {
+				t.Errorf("APIError = %+v", apiErr)
+			}
+			if StatusCode(err) != tt.status {
+				t.Errorf("StatusCode = %d, want %d", StatusCode(err), tt.status)
+			}
+		})
+	}
+}
+
+func TestGetRetriesTransientStatus(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var calls atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) < 3 {
+					w.WriteHeader(status)
+					return
+				}
+				w.Write([]byte(`{"data":{"id":"p1"}}`))
+			})
+
+			policy, err := client.GetPolicy(context.Background(), "p1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if policy.ID != "p1" {
+				t.Errorf("policy = %+v", policy)
+			}
+			if n := calls.Load(); n != 3 {
+				t.Errorf("calls = %d, want 3", n)
+			}
+		})
+	}
+}
+
+func TestGetRetriesExhausted(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := client.GetPolicy(context.Background(), "p1")
+	if StatusCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want 503", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("calls = %d, want 3", n)
+	}
+}
+
+func TestNonGetIsNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	err := client.DeletePolicy(context.Background(), "p1", "0xsig")
+	if StatusCode(err) != http.StatusInternalServerError {
+		t.Fatalf("err = %v, want 500", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("calls = %d, want 1", n)
+	}
+}
+
+// countingAuth starts out with "Bearer old" and refreshes to "Bearer new".
+type countingAuth struct {
+	refreshes atomic.Int32
+	err       error
+}
+
+func (a *countingAuth) AuthHeader(ctx context.Context) (string, error) {
+	return "Bearer old", nil
+}
+
+func (a *countingAuth) Refresh(ctx context.Context) (string, error) {
+	a.refreshes.Add(1)
+	if a.err != nil {
+		return "", a.err
+	}
+	return "Bearer new", nil
+}
+
+func TestUnauthorizedRefreshesOnce(t *testing.T) {
+	auth := &countingAuth{}
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"p1"}}`))
+	}, WithAuth(auth))
+
+	_, err := client.GetPolicy(context.Background(), "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := auth.refreshes.Load(); n != 1 {
+		t.Errorf("refreshes = %d, want 1", n)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("calls = %d, want 2", n)
+	}
+}
+
+func TestUnauthorizedAfterRefresh(t *testing.T) {
+	auth := &countingAuth{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}, WithAuth(auth))
+
+	_, err := client.GetPolicy(context.Background(), "p1")
+	if StatusCode(err) != http.StatusUnauthorized {
+		t.Fatalf("err = %v, want 401", err)
+	}
+	if n := auth.refreshes.Load(); n != 1 {
+		t.Errorf("refreshes = %d, want 1", n)
+	}
+}
+
+func TestUnauthorizedRefreshError(t *testing.T) {
+	auth := &countingAuth{err: errors.New("keysign failed")}
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}, WithAuth(auth))
+
+	_, err := client.GetPolicy(context.Background(), "p1")
+	if !errors.Is(err, auth.err) {
+		t.Fatalf("err = %v, want the refresh error", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("calls = %d, want 1", n)
+	}
+}
+
+func TestUnauthorizedRetryOutlivesCallerDeadline(t *testing.T) {
+	auth := &slowAuth{countingAuth: &countingAuth{}, delay: 100 * time.Millisecond}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"p1"}}`))
+	}, WithAuth(auth))
+
+	// The caller's deadline passes while the client re-authenticates.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetPolicy(ctx, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := auth.refreshes.Load(); n != 1 {
+		t.Errorf("refreshes = %d, want 1", n)
+	}
+}
+
+// slowAuth delays Refresh and fails if its context is done by then.
+type slowAuth struct {
+	*countingAuth
+	delay time.Duration
+}
+
+func (a *slowAuth) Refresh(ctx context.Context) (string, error) {
+	time.Sleep(a.delay)
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return a.countingAuth.Refresh(ctx)
+}
+
+func TestUnauthenticatedRequestSkipsAuth(t *testing.T) {
+	auth := &countingAuth{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if h := r.Header.Get("Authorization"); h != "" {
+			t.Errorf("Authorization = %q on a public endpoint", h)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}, WithAuth(auth))
+
+	_, err := client.GetPlugin(context.Background(), "x")
+	if StatusCode(err) != http.StatusUnauthorized {
+		t.Fatalf("err = %v, want 401", err)
+	}
+	if n := auth.refreshes.Load(); n != 0 {
+		t.Errorf("refreshes = %d, want 0", n)
+	}
+}
@@ -0,0 +1,129 @@
+package verifier
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuthRequest is the body of POST /auth. Message is the signed JSON
+// {nonce, expiresAt} and Signature its EIP-191 signature as 0x R||S||V.
+type AuthRequest struct {
+	Message      string `json:"message"`
+	Signature    string `json:"signature"`
+	ChainCodeHex string `json:"chain_code_hex"`
+	PublicKey    string `json:"public_key"`
+}
+
+type authResponse struct {
+	Token string `json:"token"`
+}
+
+type Pricing struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	Frequency *string `json:"frequency,omitempty"`
+	Amount    uint64  `json:"amount"`
+	Asset     string  `json:"asset"`
+	Metric    string  `json:"metric,omitempty"`
+}
+
+type Plugin struct {
+	ID             string    `json:"id"`
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	ServerEndpoint string    `json:"server_endpoint"`
+	Category       string    `json:"category_id"`
+	Pricing        []Pricing `json:"pricing,omitempty"`
+	Audited        bool      `json:"audited"`
+	Installations  int       `json:"installations"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type PluginList struct {
+	Plugins    []Plugin `json:"plugins"`
+	TotalCount int      `json:"total_count"`
+}
+
+// Billing is one fee entry of a policy; it must match the plugin's pricing.
+type Billing struct {
+	ID        string     `json:"id,omitempty"`
+	Type      string     `json:"type"`
+	Frequency *string    `json:"frequency,omitempty"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	Amount    uint64     `json:"amount"`
+	Asset     string     `json:"asset,omitempty"`
+}
+
+// Policy is a plugin policy as stored by the verifier. Recipe is the
+// base64-encoded recipes Policy protobuf the signature was made over.
+type Policy struct {
+	ID                 string    `json:"id,omitempty"`
+	PublicKey          string    `json:"public_key"`
+	PluginID           string    `json:"plugin_id"`
+	PluginVersion      string    `json:"plugin_version"`
+	PolicyVersion      int       `json:"policy_version"`
+	Signature          string    `json:"signature"`
+	Recipe             string    `json:"recipe"`
+	Billing            []Billing `json:"billing"`
+	Active             bool      `json:"active"`
+	DeactivationReason *string   `json:"deactivation_reason,omitempty"`
+}
+
+type PolicyList struct {
+	Policies   []Policy `json:"policies"`
+	TotalCount int      `json:"total_count"`
+}
+
+type Transaction struct {
+	ID            string     `json:"id"`
+	PluginID      string     `json:"plugin_id"`
+	AppName       string     `json:"app_name"`
+	PolicyID      string     `json:"policy_id"`
+	PublicKey     string     `json:"public_key"`
+	ToPublicKey   string     `json:"to_public_key"`
+	Chain         string     `json:"chain"`
+	TokenID       string     `json:"token_id"`
+	Amount        *string    `json:"amount"`
+	TxHash        *string    `json:"tx_hash"`
+	Status        string     `json:"status"`
+	StatusOnChain *string    `json:"status_onchain"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	BroadcastedAt *time.Time `json:"broadcasted_at"`
+}
+
+type TransactionHistory struct {
+	History    []Transaction `json:"history"`
+	TotalCount uint32        `json:"total_count"`
+}
+
+// Page selects a window of a paginated list; zero values use the server
+// defaults (skip 0, take 20).
+type Page struct {
+	Skip int
+	Take int
+}
+
+// PolicyFilter narrows ListPolicies. A nil Active returns all policies.
+type PolicyFilter struct {
+	Page
+	Active *bool
+}
+
+// ReshareRequest is the body of POST /vault/reshare, which asks the verifier
+// (and through it the plugin) to join a reshare session.
+type ReshareRequest struct {
+	Name             string   `json:"name"`
+	PublicKey        string   `json:"public_key"`
+	SessionID        string   `json:"session_id"`
+	HexEncryptionKey string   `json:"hex_encryption_key"`
+	HexChainCode     string   `json:"hex_chain_code"`
+	LocalPartyID     string   `json:"local_party_id"`
+	OldParties       []string `json:"old_parties"`
+	Email            string   `json:"email"`
+	PluginID         string   `json:"plugin_id"`
+}
+
+// RecipeSpecification is kept raw: its schema is plugin-defined.
+type RecipeSpecification = json.RawMessage